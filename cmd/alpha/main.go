@@ -2,12 +2,16 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/alpha/internal/codegen"
+	"github.com/alpha/internal/diag"
 	"github.com/alpha/internal/ir"
 	"github.com/alpha/internal/lexer"
 	"github.com/alpha/internal/parser"
@@ -50,6 +54,17 @@ type AnalysisResult struct {
 	Lines          []string // Armazena linhas do código para contexto
 }
 
+// keywordLang é o idioma das palavras-chave escolhido com --lang. Arquivos
+// também podem escolhê-lo no cabeçalho: "package main lang pt"
+var keywordLang = lexer.LangEN
+
+// passOptions são os passes de otimização escolhidos com -O, --passes,
+// --disable-pass e --print-after; timePasses mostra o tempo de cada passe
+var (
+	passOptions = ir.PassOptions{Level: ir.DefaultOptLevel}
+	timePasses  bool
+)
+
 type parserError struct {
 	Line    int
 	Col     int
@@ -67,7 +82,31 @@ type semanticError struct {
 // ==========================================
 
 func main() {
-	printBanner("🧪 COMPILADOR ALPHA - FULL STACK")
+	// 'analyze --cfg' escreve só o grafo na saída padrão, para ir direto ao dot
+	if !dotOnly(os.Args) {
+		printBanner("🧪 COMPILADOR ALPHA - FULL STACK")
+	}
+
+	// Mensagens de erro seguem o locale (LANG), a menos que --msg-lang seja usado
+	diag.SetLanguage(diag.LanguageFromEnv(os.Getenv))
+
+	args, err := extractLangFlag(os.Args)
+	if err != nil {
+		printError(err.Error())
+		return
+	}
+	os.Args = args
+
+	args, err = extractPassFlags(os.Args)
+	if err != nil {
+		printError(err.Error())
+		return
+	}
+	os.Args = args
+	if _, err := ir.NewPassManager(passOptions); err != nil {
+		printError(err.Error())
+		return
+	}
 
 	// Verificar argumentos
 	if len(os.Args) < 2 {
@@ -79,22 +118,63 @@ func main() {
 
 	switch command {
 	case "analyze":
-		if len(os.Args) < 3 {
-			printError("Uso: alpha analyze <arquivo.alpha>")
+		filename, cfgFile, showSSA, valid := "", "", false, true
+		for _, arg := range os.Args[2:] {
+			switch {
+			case arg == "--cfg":
+				cfgFile = "-"
+			case strings.HasPrefix(arg, "--cfg="):
+				cfgFile = strings.TrimPrefix(arg, "--cfg=")
+				valid = valid && cfgFile != ""
+			case arg == "--ssa":
+				showSSA = true
+			case filename == "":
+				filename = arg
+			}
+		}
+		if filename == "" || !valid {
+			printError("Uso: alpha analyze <arquivo.alpha> [--cfg[=arquivo.dot]] [--ssa]")
 			return
 		}
-		analyzeFileCommand(os.Args[2])
+		if cfgFile == "-" {
+			cfgCommand(filename)
+			return
+		}
+		analyzeFileCommand(filename, cfgFile, showSSA)
 
 	case "compile":
-		if len(os.Args) < 3 {
-			printError("Uso: alpha compile <arquivo.alpha> [output.go]")
+		input, output, emit := "", "", "go"
+		for _, arg := range os.Args[2:] {
+			switch {
+			case strings.HasPrefix(arg, "--emit="):
+				emit = strings.TrimPrefix(arg, "--emit=")
+			case input == "":
+				input = arg
+			case output == "":
+				output = arg
+			}
+		}
+		if input == "" || (emit != "go" && emit != "ir") {
+			printError("Uso: alpha compile <arquivo.alpha|arquivo.air> [output] [--emit=go|ir]")
 			return
 		}
-		output := "output.go"
-		if len(os.Args) > 3 {
-			output = os.Args[3]
+		switch {
+		case emit == "ir":
+			if output == "" {
+				output = strings.TrimSuffix(input, filepath.Ext(input)) + ".air"
+			}
+			emitIRCommand(input, output)
+		case filepath.Ext(input) == ".air":
+			if output == "" {
+				output = "output.go"
+			}
+			compileIRFileCommand(input, output)
+		default:
+			if output == "" {
+				output = "output.go"
+			}
+			compileFileCommand(input, output)
 		}
-		compileFileCommand(os.Args[2], output)
 
 	case "run":
 		if len(os.Args) < 3 {
@@ -103,6 +183,24 @@ func main() {
 		}
 		runFileCommand(os.Args[2])
 
+	case "check":
+		filename, fix := "", false
+		for _, arg := range os.Args[2:] {
+			if arg == "--fix" {
+				fix = true
+			} else if filename == "" {
+				filename = arg
+			}
+		}
+		if filename == "" {
+			printError("Uso: alpha check <arquivo.alpha> [--fix]")
+			return
+		}
+		checkFileCommand(filename, fix)
+
+	case "explain":
+		explainCommand(os.Args[2:])
+
 	default:
 		printError(fmt.Sprintf("Comando desconhecido: %s", command))
 		printHelp()
@@ -113,13 +211,135 @@ func printHelp() {
 	fmt.Println(ColorBold + ColorCyan + "Uso: alpha <comando> [argumentos]" + ColorReset)
 	fmt.Println()
 	fmt.Println("Comandos disponíveis:")
-	fmt.Println("  analyze <arquivo.alpha>  - Analisa o arquivo e mostra detalhes")
+	fmt.Println("  analyze <arquivo.alpha> [--cfg[=arquivo.dot]] [--ssa] - Analisa o arquivo e mostra detalhes")
+	fmt.Println("                           (--cfg escreve só o grafo de fluxo em DOT na saída, para 'dot -Tpng';")
+	fmt.Println("                            --cfg=arquivo.dot grava o grafo junto da análise; --ssa mostra o IR em forma SSA)")
 	fmt.Println("  compile <arquivo.alpha> [output.go] - Compila para Go")
+	fmt.Println("                           (--emit=ir grava o IR em .air; compile <arquivo.air> continua a partir dele)")
 	fmt.Println("  run <arquivo.alpha>      - Compila e executa")
+	fmt.Println("  check <arquivo.alpha> [--fix] - Verifica o arquivo (--fix aplica as correções sugeridas)")
+	fmt.Println("  explain [código]         - Explica um erro (ex: alpha explain A0101)")
+	fmt.Println()
+	fmt.Println("Opções:")
+	fmt.Println("  --lang <en|pt>           - Idioma das palavras-chave (pt aceita se, enquanto, funcao...)")
+	fmt.Println("  --msg-lang <en|pt>       - Idioma das mensagens de erro (padrão: LANG)")
+	fmt.Println("  -O0, -O1, -O2            - Nível de otimização do IR (padrão: -O1)")
+	fmt.Println("  --passes=a,b,c           - Roda exatamente estes passes, em ordem")
+	fmt.Println("  --disable-pass=a,b       - Remove passes do nível escolhido")
+	fmt.Println("  --print-after=a,b|all    - Mostra o que cada passe mudou no IR")
+	fmt.Println("  --time-passes            - Mostra o tempo gasto em cada passe")
+	fmt.Println()
+	fmt.Println("Passes disponíveis:")
+	for _, pass := range ir.RegisteredPasses() {
+		fmt.Printf("  %-24s - %s\n", pass.Name, pass.Description)
+	}
 	fmt.Println()
 }
 
-func analyzeFileCommand(filename string) {
+// extractPassFlags remove as opções de otimização dos argumentos e preenche
+// passOptions. --passes, --disable-pass e --print-after aceitam listas
+// separadas por vírgula, com '=' ou como argumento seguinte
+func extractPassFlags(args []string) ([]string, error) {
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case len(arg) == 3 && strings.HasPrefix(arg, "-O"):
+			level, err := strconv.Atoi(arg[2:])
+			if err != nil {
+				return nil, fmt.Errorf("nível de otimização inválido: %s (use -O0, -O1 ou -O2)", arg)
+			}
+			passOptions.Level = level
+			continue
+		case arg == "--time-passes":
+			timePasses = true
+			continue
+		}
+
+		var list *[]string
+		name := arg
+		if before, _, found := strings.Cut(arg, "="); found {
+			name = before
+		}
+		switch name {
+		case "--passes":
+			list = &passOptions.Passes
+		case "--disable-pass":
+			list = &passOptions.Disabled
+		case "--print-after":
+			list = &passOptions.PrintAfter
+		default:
+			rest = append(rest, arg)
+			continue
+		}
+
+		value, found := strings.CutPrefix(arg, name+"=")
+		if !found {
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("a opção %s exige uma lista de passes", name)
+			}
+			i++
+			value = args[i]
+		}
+		// --passes= (vazio) é uma lista vazia, diferente de não informar
+		if *list == nil {
+			*list = []string{}
+		}
+		for _, pass := range strings.Split(value, ",") {
+			if pass = strings.TrimSpace(pass); pass != "" {
+				*list = append(*list, pass)
+			}
+		}
+	}
+	return rest, nil
+}
+
+// extractLangFlag remove --lang pt e --msg-lang pt (ou --lang=pt) dos
+// argumentos e define o idioma das palavras-chave e das mensagens de erro
+func extractLangFlag(args []string) ([]string, error) {
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		option, name, found := "", "", false
+		for _, flag := range []string{"--lang", "--msg-lang"} {
+			switch {
+			case args[i] == flag:
+				if i+1 >= len(args) {
+					return nil, fmt.Errorf("a opção %s exige um idioma (en ou pt)", flag)
+				}
+				i++
+				option, name, found = flag, args[i], true
+			case strings.HasPrefix(args[i], flag+"="):
+				option, name, found = flag, strings.TrimPrefix(args[i], flag+"="), true
+			}
+			if found {
+				break
+			}
+		}
+
+		if !found {
+			rest = append(rest, args[i])
+			continue
+		}
+		if option == "--msg-lang" {
+			lang, ok := diag.ParseLanguage(name)
+			if !ok {
+				return nil, fmt.Errorf("idioma desconhecido: %s (use en ou pt)", name)
+			}
+			diag.SetLanguage(lang)
+			continue
+		}
+		lang, ok := lexer.ParseLanguage(name)
+		if !ok {
+			return nil, fmt.Errorf("idioma desconhecido: %s (use en ou pt)", name)
+		}
+		keywordLang = lang
+	}
+	return rest, nil
+}
+
+// analyzeFileCommand mostra todas as etapas da análise. cfgFile, se não for
+// vazio, recebe o grafo de fluxo de controle em DOT
+func analyzeFileCommand(filename, cfgFile string, showSSA bool) {
 	printBanner(fmt.Sprintf("🧪 ANÁLISE DO ARQUIVO %s", filename))
 
 	code, err := os.ReadFile(filename)
@@ -145,6 +365,12 @@ func analyzeFileCommand(filename string) {
 
 	if result.Success && result.IRModule != nil {
 		printIR(result.IRModule)
+		if cfgFile != "" {
+			saveCFG(result.IRModule, cfgFile)
+		}
+		if showSSA {
+			printSSA(result.IRModule)
+		}
 	}
 }
 
@@ -195,6 +421,62 @@ func compileFileCommand(inputFile, outputFile string) {
 	}
 }
 
+// ============================
+// IR TEXTUAL (alpha compile --emit=ir / arquivo.air)
+// ============================
+
+// emitIRCommand analisa o arquivo e grava o IR otimizado no formato textual
+func emitIRCommand(inputFile, outputFile string) {
+	printBanner(fmt.Sprintf("🛠️  GERANDO IR %s → %s", inputFile, outputFile))
+
+	code, err := os.ReadFile(inputFile)
+	if err != nil {
+		printError("Erro ao ler o arquivo " + inputFile + ": " + err.Error())
+		return
+	}
+
+	codeStr := string(code)
+	result := analyzeFile(codeStr, strings.Split(codeStr, "\n"))
+	if !result.Success || result.IRModule == nil {
+		printError("Geração de IR abortada devido a erros na análise")
+		return
+	}
+
+	text := ir.FormatModule(result.IRModule)
+	if err := os.WriteFile(outputFile, []byte(text), 0644); err != nil {
+		printError("Erro ao salvar arquivo: " + err.Error())
+		return
+	}
+	printSuccess(fmt.Sprintf("IR gravado em: %s (%d funções)", outputFile, len(result.IRModule.Functions)))
+}
+
+// compileIRFileCommand continua a compilação a partir de um arquivo .air,
+// pulando lexer, parser e análise semântica
+func compileIRFileCommand(inputFile, outputFile string) {
+	printBanner(fmt.Sprintf("🛠️  COMPILANDO %s → %s", inputFile, outputFile))
+
+	code, err := os.ReadFile(inputFile)
+	if err != nil {
+		printError("Erro ao ler o arquivo " + inputFile + ": " + err.Error())
+		return
+	}
+
+	module, err := ir.ParseModule(string(code))
+	if err != nil {
+		printError(fmt.Sprintf("%s: %s", inputFile, err.Error()))
+		return
+	}
+
+	generated := codegen.NewCodeGenerator(nil).GenerateCode(module)
+	if err := os.WriteFile(outputFile, []byte(generated), 0644); err != nil {
+		printError("Erro ao salvar arquivo: " + err.Error())
+		return
+	}
+	printSuccess(fmt.Sprintf("Código Go gerado com sucesso em: %s", outputFile))
+	fmt.Printf("%s📊 Estatísticas:%s\n", ColorBold+ColorWhite, ColorReset)
+	fmt.Printf("   Linhas de código: %d\n", strings.Count(generated, "\n"))
+}
+
 func runFileCommand(filename string) {
 	printBanner(fmt.Sprintf("🚀 EXECUTANDO %s", filename))
 
@@ -230,6 +512,7 @@ func analyzeFile(code string, lines []string) AnalysisResult {
 	printSection("🧪 ETAPA 1: ANÁLISE LÉXICA", ColorBlue)
 	printStep("Analisando tokens...", 1, 6)
 	scanner := lexer.NewScanner(code)
+	scanner.SetLanguage(keywordLang)
 
 	// Coletar tokens
 	tokens := []lexer.Token{}
@@ -248,7 +531,7 @@ func analyzeFile(code string, lines []string) AnalysisResult {
 	for _, tok := range tokens {
 		if tok.Type == lexer.ERROR {
 			hasLexerErrors = true
-			result.LexerErrors = append(result.LexerErrors, tok.Value)
+			result.LexerErrors = append(result.LexerErrors, fmt.Sprintf("%d:%d: %s", tok.Line, tok.Col, diag.Label(tok.Code, tok.Value)))
 		}
 	}
 
@@ -264,6 +547,7 @@ func analyzeFile(code string, lines []string) AnalysisResult {
 	printSection("🧪 ETAPA 2: ANÁLISE SINTÁTICA", ColorYellow)
 	printStep("Analisando estrutura sintática...", 2, 6)
 	scanner = lexer.NewScanner(code)
+	scanner.SetLanguage(keywordLang)
 	p := parser.New(scanner)
 	program := p.ParseProgram()
 
@@ -281,11 +565,13 @@ func analyzeFile(code string, lines []string) AnalysisResult {
 				Message: msg,
 			})
 		}
-		return result
+	} else {
+		printStepResult("✅", true)
 	}
-	printStepResult("✅", true)
 
 	// ========== ETAPA 3: SEMANTIC ==========
+	// Com erros sintáticos a AST é parcial (trechos com erro viram nós Bad*),
+	// mas o resto do arquivo ainda é verificado
 	printSection("🧪 ETAPA 3: ANÁLISE SEMÂNTICA", ColorMagenta)
 	printStep("Analisando semântica...", 3, 6)
 	checker := semantic.NewChecker()
@@ -294,20 +580,26 @@ func analyzeFile(code string, lines []string) AnalysisResult {
 	if len(checker.Errors) > 0 {
 		printStepResult("❌", false)
 		result.Success = false
-		result.Message = "Erros semânticos encontrados"
+		if len(result.ParserErrors) == 0 {
+			result.Message = "Erros semânticos encontrados"
+		}
 
 		// Converter erros semânticos
 		for _, err := range checker.Errors {
 			result.SemanticErrors = append(result.SemanticErrors, semanticError{
 				Line:    err.Line,
 				Col:     err.Col,
-				Message: err.Msg,
+				Message: diag.Label(err.Code, err.Msg),
 			})
 		}
 		return result
 	}
 	printStepResult("✅", true)
 
+	if len(result.ParserErrors) > 0 {
+		return result
+	}
+
 	// ========== ETAPA 4: GERAÇÃO DE IR ==========
 	printSection("🧪 ETAPA 4: GERAÇÃO DE IR", ColorCyan)
 	printStep("Gerando IR (Representação Intermediária)...", 4, 6)
@@ -317,11 +609,17 @@ func analyzeFile(code string, lines []string) AnalysisResult {
 
 	// ========== ETAPA 5: OTIMIZAÇÃO DE IR ==========
 	printSection("🧪 ETAPA 5: OTIMIZAÇÃO DE IR", ColorGreen)
-	printStep("Aplicando Constant Folding e Dead Code Elimination...", 5, 6)
-	optimizer := ir.NewOptimizer(irModule)
-	optimizer.Optimize()
+	printStep("Aplicando passes de otimização...", 5, 6)
+	passes, _ := ir.NewPassManager(passOptions) // Opções já validadas em main
+	var dumps strings.Builder
+	passes.Out = &dumps
+	passes.Run(irModule)
 	result.IRModule = irModule
 	printStepResult("✅", true)
+	fmt.Print(dumps.String())
+	if timePasses {
+		printPassTimings(passes.Timings)
+	}
 
 	// ========== ETAPA 6: GERAÇÃO DE CÓDIGO ==========
 	printSection("🧪 ETAPA 6: GERAÇÃO DE CÓDIGO GO", ColorGreen)
@@ -337,6 +635,278 @@ func analyzeFile(code string, lines []string) AnalysisResult {
 	return result
 }
 
+// ==========================================
+// VERIFICAÇÃO E CORREÇÕES (alpha check)
+// ==========================================
+
+// checkDiagnostic é um erro de qualquer etapa, com a correção sugerida quando
+// existe uma
+type checkDiagnostic struct {
+	Line    int
+	Col     int
+	Message string
+	Fix     *diag.Fix
+}
+
+// checkFileCommand verifica o arquivo sem gerar código e, com --fix, aplica
+// as correções sugeridas e reescreve o arquivo
+func checkFileCommand(filename string, fix bool) {
+	printBanner(fmt.Sprintf("🔍 VERIFICANDO %s", filename))
+
+	code, err := os.ReadFile(filename)
+	if err != nil {
+		printError("Erro ao ler o arquivo " + filename + ": " + err.Error())
+		return
+	}
+
+	codeStr := string(code)
+	diagnostics := collectDiagnostics(codeStr)
+
+	if fix {
+		var fixes []diag.Fix
+		for _, d := range diagnostics {
+			if d.Fix != nil {
+				fixes = append(fixes, *d.Fix)
+			}
+		}
+
+		if fixed, applied := diag.ApplyFixes(codeStr, fixes); applied > 0 {
+			info, err := os.Stat(filename)
+			if err != nil {
+				printError("Erro ao ler o arquivo " + filename + ": " + err.Error())
+				return
+			}
+			if err := os.WriteFile(filename, []byte(fixed), info.Mode().Perm()); err != nil {
+				printError("Erro ao salvar arquivo: " + err.Error())
+				return
+			}
+			printSuccess(fmt.Sprintf("%d correção(ões) aplicada(s) em %s", applied, filename))
+
+			// Verifica de novo para mostrar só o que ainda falta corrigir
+			diagnostics = collectDiagnostics(fixed)
+		}
+	}
+
+	if len(diagnostics) == 0 {
+		printSuccess("Nenhum erro encontrado")
+		return
+	}
+
+	printErrorSection("❌ ERROS ENCONTRADOS", len(diagnostics))
+	pending := 0
+	for _, d := range diagnostics {
+		fmt.Printf("%s%s:%d:%d:%s %s\n", ColorBold, filename, d.Line, d.Col, ColorReset, d.Message)
+		if d.Fix != nil {
+			pending++
+			fmt.Printf("   %s🔧 troque '%s' por '%s'%s\n", ColorGreen, d.Fix.Old, d.Fix.New, ColorReset)
+		}
+	}
+
+	fmt.Println()
+	if pending > 0 && !fix {
+		fmt.Println(ColorYellow + "💡 Use 'alpha check " + filename + " --fix' para aplicar as correções sugeridas." + ColorReset)
+	}
+}
+
+// collectDiagnostics roda lexer, parser e análise semântica sem mostrar as
+// etapas e junta os erros de todas elas
+func collectDiagnostics(code string) []checkDiagnostic {
+	_, _, diagnostics := checkCode(code)
+	return diagnostics
+}
+
+// checkCode é collectDiagnostics junto da AST e do checker, para quem continua
+// até o IR quando não há erros
+func checkCode(code string) (*parser.Program, *semantic.Checker, []checkDiagnostic) {
+	var diagnostics []checkDiagnostic
+
+	scanner := lexer.NewScanner(code)
+	scanner.SetLanguage(keywordLang)
+	for {
+		tok := scanner.NextToken()
+		if tok.Type == lexer.ERROR {
+			diagnostics = append(diagnostics, checkDiagnostic{
+				Line:    tok.Line,
+				Col:     tok.Col,
+				Message: diag.Label(tok.Code, tok.Value),
+			})
+		}
+		if tok.Type == lexer.EOF {
+			break
+		}
+	}
+	if len(diagnostics) > 0 {
+		return nil, nil, diagnostics
+	}
+
+	scanner = lexer.NewScanner(code)
+	scanner.SetLanguage(keywordLang)
+	p := parser.New(scanner)
+	program := p.ParseProgram()
+	for _, err := range p.Errors {
+		line, col, msg := parseErrorPosition(err)
+		diagnostics = append(diagnostics, checkDiagnostic{Line: line, Col: col, Message: msg})
+	}
+
+	checker := semantic.NewChecker()
+	checker.CheckProgram(program)
+	for _, err := range checker.Errors {
+		diagnostics = append(diagnostics, checkDiagnostic{
+			Line:    err.Line,
+			Col:     err.Col,
+			Message: diag.Label(err.Code, err.Msg),
+			Fix:     err.Fix,
+		})
+	}
+
+	return program, checker, diagnostics
+}
+
+// ==========================================
+// EXPLICAÇÃO DE ERROS
+// ==========================================
+
+// explainCommand mostra a explicação longa de um código de erro ou, sem
+// argumentos, a lista de todos os códigos
+func explainCommand(args []string) {
+	if len(args) == 0 {
+		printBanner("📚 CÓDIGOS DE ERRO")
+		for _, code := range diag.Codes() {
+			fmt.Printf("   %s%s%s  %s\n", ColorBold, code, ColorReset, diag.Summary(code))
+		}
+		fmt.Println()
+		fmt.Println(ColorYellow + "💡 Use 'alpha explain <código>' para ver a explicação de um erro." + ColorReset)
+		return
+	}
+
+	code, ok := diag.ParseCode(args[0])
+	if !ok {
+		printError(fmt.Sprintf("Código de erro desconhecido: %s", args[0]))
+		fmt.Println(ColorYellow + "💡 Use 'alpha explain' para listar todos os códigos." + ColorReset)
+		return
+	}
+
+	printBanner(fmt.Sprintf("📚 ERRO %s", code))
+	fmt.Printf("\n%s%s%s\n", ColorBold+ColorRed, diag.Summary(code), ColorReset)
+
+	exp, ok := diag.Explain(code)
+	if !ok {
+		return
+	}
+	fmt.Println()
+	for _, line := range strings.Split(exp.Description(), "\n") {
+		fmt.Println("   " + line)
+	}
+
+	if exp.Wrong != "" {
+		printSection("❌ Código com o erro", ColorRed)
+		printExample(exp.Wrong)
+	}
+	if exp.Right != "" {
+		printSection("✅ Código corrigido", ColorGreen)
+		printExample(exp.Right)
+	}
+	fmt.Println()
+}
+
+// printExample mostra um exemplo de código com números de linha
+func printExample(code string) {
+	for i, line := range strings.Split(strings.TrimRight(code, "\n"), "\n") {
+		fmt.Printf("%s%3d │ %s%s\n", ColorGray, i+1, ColorReset, line)
+	}
+}
+
+// printPassTimings mostra o tempo gasto em cada passe, na ordem de execução
+func printPassTimings(timings []ir.PassTiming) {
+	printSubsection("⏱️  Tempo por passe")
+	var total time.Duration
+	for _, t := range timings {
+		fmt.Printf("   %-24s %10s\n", t.Pass, t.Duration)
+		total += t.Duration
+	}
+	fmt.Printf("   %s%-24s %10s%s\n", ColorBold, "total", total, ColorReset)
+}
+
+// ==========================================
+// GRAFO DE FLUXO DE CONTROLE (alpha analyze --cfg)
+// ==========================================
+
+// dotOnly indica se a saída padrão deve ter só o DOT ('analyze --cfg')
+func dotOnly(args []string) bool {
+	return len(args) > 1 && args[1] == "analyze" && slices.Contains(args[2:], "--cfg")
+}
+
+// cfgCommand escreve na saída padrão só o grafo de fluxo de controle do
+// arquivo, sem cores nem as etapas da análise. Erros vão para stderr
+func cfgCommand(filename string) {
+	code, err := os.ReadFile(filename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "alpha: %v\n", err)
+		os.Exit(1)
+	}
+
+	program, checker, diagnostics := checkCode(string(code))
+	if len(diagnostics) > 0 {
+		for _, d := range diagnostics {
+			fmt.Fprintf(os.Stderr, "%s:%d:%d: %s\n", filename, d.Line, d.Col, d.Message)
+		}
+		os.Exit(1)
+	}
+
+	module := ir.NewGenerator(checker).Generate(program)
+	passes, _ := ir.NewPassManager(passOptions) // Opções já validadas em main
+	passes.Run(module)
+	writeCFG(os.Stdout, module)
+}
+
+// saveCFG grava o grafo de fluxo de controle em um arquivo .dot
+func saveCFG(module *ir.Module, filename string) {
+	var sb strings.Builder
+	writeCFG(&sb, module)
+	if err := os.WriteFile(filename, []byte(sb.String()), 0644); err != nil {
+		printError("Erro ao salvar arquivo: " + err.Error())
+		return
+	}
+	printSuccess("Grafo de fluxo de controle gravado em " + filename + " (use 'dot -Tsvg' para desenhar)")
+}
+
+// writeCFG escreve o grafo de fluxo de controle do módulo no formato DOT, um
+// único digraph com um cluster por função, precedido de comentários com o
+// resumo dos blocos e loops de cada uma
+func writeCFG(w io.Writer, module *ir.Module) {
+	cfgs := make([]*ir.CFG, 0, len(module.Functions))
+	for _, fn := range module.Functions {
+		cfg := ir.BuildCFG(fn)
+		cfgs = append(cfgs, cfg)
+
+		unreachable := 0
+		for _, b := range cfg.Blocks {
+			if !cfg.Reachable(b) {
+				unreachable++
+			}
+		}
+		fmt.Fprintf(w, "// %s: %d bloco(s), %d inalcançável(is), %d loop(s)\n",
+			fn.Name, len(cfg.Blocks), unreachable, len(cfg.Loops))
+		for _, loop := range cfg.Loops {
+			fmt.Fprintf(w, "//   loop em %s (nível %d, %d bloco(s))\n",
+				loop.Header.Label, loop.Depth, len(loop.Blocks))
+		}
+	}
+	fmt.Fprint(w, ir.DOT(cfgs...))
+}
+
+// printSSA mostra as funções do módulo em forma SSA. O módulo já foi
+// convertido para Go, então a conversão não afeta o código gerado
+func printSSA(module *ir.Module) {
+	printSection("🧬 IR EM FORMA SSA", ColorCyan)
+
+	module.ToSSA()
+	for _, fn := range module.Functions {
+		printFunction(fn)
+	}
+	module.FromSSA()
+}
+
 // ==========================================
 // FUNÇÕES AUXILIARES DE PARSING DE ERROS
 // ==========================================
@@ -447,6 +1017,7 @@ func printTokens(tokens []lexer.Token) {
 		lexer.INT:     "INT",
 		lexer.FLOAT:   "FLOAT",
 		lexer.STRING:  "STRING",
+		lexer.CHAR:    "CHAR",
 		lexer.OP:      "OP",
 		lexer.GENERIC: "GENERIC",
 	}
@@ -472,7 +1043,7 @@ func printTokens(tokens []lexer.Token) {
 			color = ColorCyan
 		case lexer.INT, lexer.FLOAT:
 			color = ColorYellow
-		case lexer.STRING:
+		case lexer.STRING, lexer.CHAR:
 			color = ColorGreen
 		case lexer.OP:
 			color = ColorMagenta
@@ -563,6 +1134,7 @@ func printAnalysisResult(result AnalysisResult) {
 	} else {
 		fmt.Println(ColorRed + "⚠️  ANÁLISE ENCONTROU ERROS" + ColorReset)
 		fmt.Println(ColorYellow + "💡 Dica: Verifique a sintaxe e os tipos mencionados nos erros acima." + ColorReset)
+		fmt.Println(ColorYellow + "💡 Use 'alpha explain <código>' para ver a explicação de um erro com um exemplo corrigido." + ColorReset)
 	}
 }
 
@@ -0,0 +1,187 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/alpha/internal/ir"
+	"github.com/alpha/internal/lexer"
+	"github.com/alpha/internal/parser"
+	"github.com/alpha/internal/semantic"
+)
+
+// compile leva um programa Alpha até o código Go (nível de otimização padrão)
+func compile(t *testing.T, src string) string {
+	t.Helper()
+	prog, checker := check(t, src)
+	return NewCodeGenerator(checker).GenerateFromAST(prog)
+}
+
+// compileAt leva um programa Alpha até o código Go no nível de otimização dado
+func compileAt(t *testing.T, src string, level int) string {
+	t.Helper()
+	prog, checker := check(t, src)
+	module := ir.NewGenerator(checker).Generate(prog)
+	passes, err := ir.NewPassManager(ir.PassOptions{Level: level})
+	if err != nil {
+		t.Fatal(err)
+	}
+	passes.Run(module)
+	return NewCodeGenerator(checker).GenerateCode(module)
+}
+
+// check analisa um programa Alpha que precisa estar correto
+func check(t *testing.T, src string) (*parser.Program, *semantic.Checker) {
+	t.Helper()
+	p := parser.New(lexer.NewScanner(src))
+	prog := p.ParseProgram()
+	if p.HasErrors() {
+		t.Fatalf("parse errors: %v", p.Errors)
+	}
+	checker := semantic.NewChecker()
+	checker.CheckProgram(prog)
+	if len(checker.Errors) > 0 {
+		t.Fatalf("semantic errors: %v", checker.Errors)
+	}
+	return prog, checker
+}
+
+// typeCheck falha o teste se o código Go gerado não compila
+func typeCheck(t *testing.T, code string) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, "out.go", code, 0)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, code)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("main", fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("generated code does not compile: %v\n%s", err, code)
+	}
+}
+
+func TestGetElementType(t *testing.T) {
+	tm := NewTypeMapper()
+	tests := []struct{ goType, want string }{
+		{"[]int", "int"},
+		{"[3]int", "int"},
+		{"[2][3]float64", "[3]float64"},
+		{"[][4]string", "[4]string"},
+		{"map[string]bool", "bool"},
+		{"int", "int"},
+	}
+	for _, tt := range tests {
+		if got := tm.GetElementType(tt.goType); got != tt.want {
+			t.Errorf("GetElementType(%q) = %q, want %q", tt.goType, got, tt.want)
+		}
+	}
+}
+
+func TestIndexFixedArray(t *testing.T) {
+	code := compile(t, `package main
+int function get(int[3] xs, int i) {
+    return xs[i] + xs[0]
+}
+`)
+	if strings.Contains(code, "var t0 [3]int") {
+		t.Errorf("element temp declared with the array type:\n%s", code)
+	}
+	typeCheck(t, code)
+}
+
+func TestCharArithmetic(t *testing.T) {
+	code := compile(t, `package main
+char function next(char c) {
+    return c + 1
+}
+char function shifted() {
+    return 'a' + 2
+}
+`)
+	typeCheck(t, code)
+}
+
+// Constantes que chegam a uma operação pela propagação não podem virar uma
+// expressão que o Go recusa (divisão por zero, overflow de constante). Só os
+// níveis que otimizam: -O0 não roda nenhum passe
+func TestPropagatedConstantsCompile(t *testing.T) {
+	tests := []struct{ name, src string }{
+		{"division by zero", `package main
+int function div(int x) {
+    int z = 0
+    int r = x / z
+    return r
+}
+`},
+		{"literal division by zero", `package main
+int function div(int x) {
+    int z = 0
+    return 10 % z
+}
+`},
+		{"negative shift", `package main
+int function shift(int x) {
+    int n = -1
+    return x << n
+}
+`},
+		{"overflow", `package main
+int function next() {
+    int m = 9223372036854775807
+    int o = m + 1
+    return o
+}
+`},
+		{"float overflow", `package main
+float function big() {
+    float m = 1e308
+    return m * 10.0
+}
+`},
+	}
+	for _, tt := range tests {
+		for level := 1; level < len(ir.OptLevels); level++ {
+			t.Run(fmt.Sprintf("%s/O%d", tt.name, level), func(t *testing.T) {
+				typeCheck(t, compileAt(t, tt.src, level))
+			})
+		}
+	}
+}
+
+// Switches de valores e sem expressão viram switch nativos do Go; o índice do
+// segundo caso só é lido se o primeiro não correspondeu
+func TestSwitchNative(t *testing.T) {
+	code := compile(t, `package main
+int function pick(int x) {
+    switch (x) {
+    case 1, 2:
+        return 1
+    default:
+        return 0
+    }
+    return -1
+}
+int function first(int[] arr) {
+    switch {
+    case length(arr) == 0:
+        return -1
+    case arr[0] == 1:
+        return 1
+    }
+    return 0
+}
+`)
+	if strings.Count(code, "\tswitch ") != 3 {
+		t.Errorf("want one native switch for the values and two for the conditions:\n%s", code)
+	}
+	if i, j := strings.Index(code, "== 0"), strings.Index(code, "arr[0]"); i < 0 || j < i || !strings.Contains(code[i:j], "\tswitch ") {
+		t.Errorf("arr[0] is read before the first case is tested:\n%s", code)
+	}
+	typeCheck(t, code)
+}
@@ -3,8 +3,11 @@ package codegen
 import (
 	"fmt"
 	"go/format"
+	"sort"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/alpha/internal/ir"
 	"github.com/alpha/internal/parser"
@@ -20,6 +23,7 @@ type OptimizedEmitter struct {
 	loopStack  []LoopContext
 	inFunction string
 	funcVars   map[string]VarInfo
+	imports    map[string]bool // Pacotes usados pelo código gerado
 }
 
 type VarInfo struct {
@@ -70,19 +74,14 @@ func NewOptimizedEmitter(mod *ir.Module) *OptimizedEmitter {
 		typeMapper: NewTypeMapper(),
 		tempPool:   NewTempPool(),
 		funcVars:   make(map[string]VarInfo),
+		imports:    make(map[string]bool),
 	}
 }
 
 func (e *OptimizedEmitter) Emit() string {
 	e.output.Reset()
 
-	// Header
-	e.output.WriteString("// Code generated by Alpha Compiler v1.0\n")
-	e.output.WriteString("// DO NOT EDIT\n\n")
-	e.output.WriteString("package main\n\n")
-
-	// Imports otimizados
-	e.emitImports()
+	// O corpo é gerado antes do cabeçalho: os imports dependem do código emitido
 
 	// Runtime
 	e.output.WriteString(GetRuntime())
@@ -92,6 +91,7 @@ func (e *OptimizedEmitter) Emit() string {
 
 	// Globals
 	e.emitGlobals()
+	e.emitTaskGroup()
 
 	// Functions
 	for _, fn := range e.module.Functions {
@@ -103,6 +103,18 @@ func (e *OptimizedEmitter) Emit() string {
 		e.emitMainWrapper()
 	}
 
+	body := e.output.String()
+	e.output.Reset()
+
+	// Header
+	e.output.WriteString("// Code generated by Alpha Compiler v1.0\n")
+	e.output.WriteString("// DO NOT EDIT\n\n")
+	e.output.WriteString("package main\n\n")
+
+	// Imports usados pelo código
+	e.emitImports()
+	e.output.WriteString(body)
+
 	// Formata o código Go
 	formatted, err := format.Source([]byte(e.output.String()))
 	if err != nil {
@@ -113,27 +125,29 @@ func (e *OptimizedEmitter) Emit() string {
 	return string(formatted)
 }
 
+// useImport registra um pacote usado pelo código gerado
+func (e *OptimizedEmitter) useImport(path string) {
+	e.imports[path] = true
+}
+
 func (e *OptimizedEmitter) emitImports() {
-	if len(e.module.Imports) == 0 && !e.moduleHasMaps() {
-		return
+	for _, imp := range e.module.Imports {
+		e.useImport(imp)
 	}
-
-	e.output.WriteString("import (\n")
-
-	// Imports essenciais
-	imports := []string{
-		"fmt",
+	if len(e.imports) == 0 {
+		return
 	}
 
-	// Adiciona imports condicionais baseado no código
-	if e.moduleHasMaps() {
-		imports = append(imports, `"sync"`)
+	paths := make([]string, 0, len(e.imports))
+	for path := range e.imports {
+		paths = append(paths, path)
 	}
+	sort.Strings(paths)
 
-	for _, imp := range imports {
-		e.output.WriteString("\t" + imp + "\n")
+	e.output.WriteString("import (\n")
+	for _, path := range paths {
+		e.output.WriteString(fmt.Sprintf("\t%q\n", path))
 	}
-
 	e.output.WriteString(")\n\n")
 }
 
@@ -144,7 +158,7 @@ func (e *OptimizedEmitter) emitStructs() {
 }
 
 func (e *OptimizedEmitter) emitStructWithLayout(s *parser.StructDecl) {
-	decl := fmt.Sprintf("type %s", s.Name)
+	decl := fmt.Sprintf("type %s", goIdent(s.Name))
 
 	if len(s.Generics) > 0 {
 		var params []string
@@ -175,7 +189,7 @@ func (e *OptimizedEmitter) emitStructWithLayout(s *parser.StructDecl) {
 			for _, field := range fields {
 				semType := semantic.ToType(field.Type)
 				goType := e.typeMapper.ToGoType(semType)
-				fieldName := e.exportFieldName(field.Name)
+				fieldName := e.memberName(field.Name, field.IsPrivate)
 
 				e.output.WriteString(fmt.Sprintf("\t%s %s\n", fieldName, goType))
 			}
@@ -214,46 +228,69 @@ func (e *OptimizedEmitter) getTypeSizeKey(goType string) string {
 func (e *OptimizedEmitter) emitStructMethods(s *parser.StructDecl) {
 	// Para cada função que tem receiver deste struct
 	for _, fn := range e.module.Functions {
-		if e.isMethodOf(fn, s.Name) {
+		if !e.isMethodOf(fn, s.Name) {
+			continue
+		}
+		if fn.IsConstructor() {
+			e.emitConstructor(fn, s)
+		} else {
 			e.emitMethod(fn, s.Name)
 		}
 	}
 }
 
+// isMethodOf indica se a função pertence a um bloco implement da struct
+// (qualquer struct quando structName é vazio)
 func (e *OptimizedEmitter) isMethodOf(fn *ir.Function, structName string) bool {
-	// Verifica se o primeiro parâmetro é o receiver
-	if len(fn.Params) > 0 {
-		firstParam := fn.Params[0]
-		if strings.Contains(firstParam.Value, "self") ||
-			strings.Contains(firstParam.Value, "this") {
-			return true
+	return fn.Receiver != "" && (structName == "" || fn.Receiver == structName)
+}
+
+// emitConstructor gera NewUser(...) User a partir do init: self é criado
+// no início e devolvido pelos RET do corpo
+func (e *OptimizedEmitter) emitConstructor(fn *ir.Function, s *parser.StructDecl) {
+	e.inFunction = fn.Name
+	e.funcVars = make(map[string]VarInfo)
+
+	e.output.WriteString(fmt.Sprintf("func %s(", constructorName(s.Name)))
+	for i := 1; i < len(fn.Params); i++ {
+		p := fn.Params[i]
+		if i > 1 {
+			e.output.WriteString(", ")
 		}
+		e.output.WriteString(fmt.Sprintf("%s %s", goIdent(p.Value), e.paramType(fn, i)))
 	}
-	return false
+	e.output.WriteString(fmt.Sprintf(") %s {\n", goIdent(s.Name)))
+	e.output.WriteString(fmt.Sprintf("\t%s := %s{}\n", goIdent(fn.Params[0].Value), goIdent(s.Name)))
+
+	e.emitLocalVariables(fn)
+	e.emitFunctionBody(fn)
+
+	e.output.WriteString("}\n\n")
+	e.inFunction = ""
 }
 
 func (e *OptimizedEmitter) emitMethod(fn *ir.Function, structName string) {
 	receiverName := "self"
 	if len(fn.Params) > 0 {
-		receiverName = fn.Params[0].Value
+		receiverName = goIdent(fn.Params[0].Value)
 	}
 
-	receiverType := structName
+	receiverType := goIdent(structName)
 	if e.typeMapper.IsReferenceType(e.typeMapper.ToGoType(fn.Params[0].Type)) {
 		receiverType = "*" + receiverType
 	}
 
 	e.output.WriteString(fmt.Sprintf("func (%s %s) %s(",
-		receiverName, receiverType, fn.Name))
+		receiverName, receiverType, e.memberName(fn.Name, !fn.IsExported)))
 
 	// Parâmetros (excluindo receiver)
 	for i := 1; i < len(fn.Params); i++ {
 		p := fn.Params[i]
-		goType := e.typeMapper.ToGoType(p.Type)
+		goType := e.paramType(fn, i)
 		if i > 1 {
 			e.output.WriteString(", ")
 		}
-		e.output.WriteString(fmt.Sprintf("%s %s", p.Value, goType))
+		e.output.WriteString(fmt.Sprintf("%s %s", goIdent(p.Value), goType))
 	}
 
 	// Tipo de retorno
@@ -270,12 +307,23 @@ func (e *OptimizedEmitter) emitMethod(fn *ir.Function, structName string) {
 
 	// Corpo do método
 	e.inFunction = fn.Name
+	e.funcVars = make(map[string]VarInfo)
+	e.emitLocalVariables(fn)
 	e.emitFunctionBody(fn)
 
 	e.output.WriteString("}\n\n")
 	e.inFunction = ""
 }
 
+// paramType retorna o tipo Go do i-ésimo parâmetro; o variádico vira ...T
+func (e *OptimizedEmitter) paramType(fn *ir.Function, i int) string {
+	goType := e.typeMapper.ToGoType(fn.Params[i].Type)
+	if fn.Variadic && i == len(fn.Params)-1 {
+		return "..." + strings.TrimPrefix(goType, "[]")
+	}
+	return goType
+}
+
 func (e *OptimizedEmitter) emitFunction(fn *ir.Function) {
 	if e.isMethodOf(fn, "") {
 		return // Já foi emitido como método
@@ -285,7 +333,7 @@ func (e *OptimizedEmitter) emitFunction(fn *ir.Function) {
 	e.funcVars = make(map[string]VarInfo)
 
 	// Assinatura
-	e.output.WriteString(fmt.Sprintf("func %s", fn.Name))
+	e.output.WriteString(fmt.Sprintf("func %s", goIdent(fn.Name)))
 
 	if len(fn.Generics) > 0 {
 		var params []string
@@ -299,15 +347,15 @@ func (e *OptimizedEmitter) emitFunction(fn *ir.Function) {
 
 	// Parâmetros
 	for i, p := range fn.Params {
-		goType := e.typeMapper.ToGoType(p.Type)
+		goType := e.paramType(fn, i)
 		if i > 0 {
 			e.output.WriteString(", ")
 		}
-		e.output.WriteString(fmt.Sprintf("%s %s", p.Value, goType))
+		e.output.WriteString(fmt.Sprintf("%s %s", goIdent(p.Value), goType))
 
 		// Registra variável
 		e.funcVars[p.Value] = VarInfo{
-			Type:    goType,
+			Type:    e.typeMapper.ToGoType(p.Type),
 			IsParam: true,
 		}
 	}
@@ -384,7 +432,7 @@ func (e *OptimizedEmitter) inferTempType(fn *ir.Function, tempName string) strin
 
 			// Inferência baseada na operação
 			switch instr.Op {
-			case ir.ADD, ir.SUB, ir.MUL, ir.DIV, ir.MOD:
+			case ir.ADD, ir.SUB, ir.MUL, ir.DIV, ir.MOD, ir.AND, ir.OR, ir.XOR, ir.SHL, ir.SHR, ir.NOT:
 				return "int"
 			case ir.EQ, ir.NEQ, ir.LT, ir.GT, ir.LE, ir.GE:
 				return "bool"
@@ -443,15 +491,23 @@ func (e *OptimizedEmitter) emitOptimizedInstruction(instr *ir.Instruction) {
 		src := e.emitOperand(instr.Arg1)
 		e.output.WriteString(fmt.Sprintf("\t%s = %s\n", dst, src))
 
-	case ir.ADD, ir.SUB, ir.MUL, ir.DIV, ir.MOD:
+	case ir.ADD, ir.SUB, ir.MUL, ir.DIV, ir.MOD, ir.AND, ir.OR, ir.XOR, ir.SHL, ir.SHR:
 		e.emitBinaryOp(instr)
 
+	case ir.NOT:
+		dst := e.emitOperand(instr.Result)
+		src := e.emitOperand(instr.Arg1)
+		e.output.WriteString(fmt.Sprintf("\t%s = ^%s\n", dst, src))
+
 	case ir.EQ, ir.NEQ, ir.LT, ir.GT, ir.LE, ir.GE:
 		e.emitComparison(instr)
 
 	case ir.GET_FIELD:
 		e.emitFieldAccess(instr)
 
+	case ir.GET_ADDR:
+		e.emitAddrOf(instr)
+
 	case ir.GET_INDEX:
 		e.emitIndexAccess(instr)
 
@@ -476,6 +532,38 @@ func (e *OptimizedEmitter) emitOptimizedInstruction(instr *ir.Instruction) {
 	case ir.APPEND:
 		e.emitAppend(instr)
 
+	case ir.ARRAY_LIT:
+		e.emitArrayLiteral(instr)
+
+	case ir.CONST:
+		e.output.WriteString(fmt.Sprintf("\tconst %s\n", e.emitConstSpec(instr)))
+
+	case ir.SPAWN:
+		e.emitSpawn(instr)
+
+	case ir.SEND:
+		e.output.WriteString(fmt.Sprintf("\t%s <- %s\n", e.emitOperand(instr.Arg1), e.emitOperand(instr.Arg2)))
+
+	case ir.RECV:
+		e.output.WriteString(fmt.Sprintf("\t%s = <-%s\n", e.emitOperand(instr.Result), e.emitOperand(instr.Arg1)))
+
+	case ir.CLOSE:
+		e.output.WriteString(fmt.Sprintf("\tclose(%s)\n", e.emitOperand(instr.Arg1)))
+
+	case ir.WAIT:
+		e.output.WriteString("\talphaWG.Wait()\n")
+
+	case ir.MAKE_CHAN:
+		e.emitMakeChan(instr)
+
+	case ir.SELECT:
+		e.emitSelect(instr)
+	case ir.SWITCH:
+		e.emitSwitch(instr)
+
+	case ir.CONCAT:
+		e.emitConcat(instr)
+
 	case ir.CAST:
 		// Usa emitOperand que é o nome correto no seu emmiter.go
 		dst := e.emitOperand(instr.Result)
@@ -484,11 +572,19 @@ func (e *OptimizedEmitter) emitOptimizedInstruction(instr *ir.Instruction) {
 		// Usa o e.typeMapper que já está definido no seu OptimizedEmitter
 		targetType := e.typeMapper.ToGoType(instr.Result.Type)
 
+		if strings.HasPrefix(targetType, "[") {
+			e.emitArrayCast(instr, targetType)
+			break
+		}
+
 		// Lógica de conversão
 		switch targetType {
 		case "string":
-			// No Alpha, string(int) costuma ser conversão de valor, não cast de byte
-			e.output.WriteString(fmt.Sprintf("\t%s = fmt.Sprintf(\"%%v\", %s)\n", dst, src))
+			var srcType semantic.Type
+			if instr.Arg2 != nil {
+				srcType = instr.Arg2.Type
+			}
+			e.output.WriteString(fmt.Sprintf("\t%s = %s\n", dst, e.stringConversion(src, srcType)))
 		case "int", "float64":
 			e.output.WriteString(fmt.Sprintf("\t%s = %s(%s)\n", dst, targetType, src))
 		default:
@@ -517,11 +613,70 @@ func (e *OptimizedEmitter) emitBinaryOp(instr *ir.Instruction) {
 		op = "/"
 	case ir.MOD:
 		op = "%"
+	case ir.AND:
+		op = "&"
+	case ir.OR:
+		op = "|"
+	case ir.XOR:
+		op = "^"
+	case ir.SHL:
+		op = "<<"
+	case ir.SHR:
+		op = ">>"
 	}
 
 	e.output.WriteString(fmt.Sprintf("\t%s = %s %s %s\n", dst, left, op, right))
 }
 
+// stringConversion retorna a expressão Go que converte src para string
+// conforme o tipo de origem. No Alpha, string(x) formata o valor: números em
+// decimal e caracteres como o próprio caractere
+func (e *OptimizedEmitter) stringConversion(src string, srcType semantic.Type) string {
+	switch semantic.StringifyType(srcType) {
+	case "string":
+		return src
+	case "int":
+		e.useImport("strconv")
+		return fmt.Sprintf("strconv.Itoa(%s)", src)
+	case "float":
+		e.useImport("strconv")
+		return fmt.Sprintf("strconv.FormatFloat(%s, 'g', -1, 64)", src)
+	case "bool":
+		e.useImport("strconv")
+		return fmt.Sprintf("strconv.FormatBool(%s)", src)
+	case "char", "byte":
+		return fmt.Sprintf("string(%s)", src)
+	}
+	e.useImport("fmt")
+	return fmt.Sprintf("fmt.Sprint(%s)", src)
+}
+
+// concatBuilderMin é o número de partes a partir do qual a concatenação usa
+// strings.Builder em vez de uma expressão com +
+const concatBuilderMin = 5
+
+// emitConcat concatena as partes de uma string interpolada
+func (e *OptimizedEmitter) emitConcat(instr *ir.Instruction) {
+	dst := e.emitOperand(instr.Result)
+	parts := make([]string, len(instr.Args))
+	for i, arg := range instr.Args {
+		parts[i] = e.emitOperand(arg)
+	}
+
+	if len(parts) < concatBuilderMin {
+		e.output.WriteString(fmt.Sprintf("\t%s = %s\n", dst, strings.Join(parts, " + ")))
+		return
+	}
+
+	// O bloco isola o builder: o goto do código gerado não pode saltar declarações
+	e.useImport("strings")
+	e.output.WriteString("\t{\n\t\tvar sb strings.Builder\n")
+	for _, part := range parts {
+		e.output.WriteString(fmt.Sprintf("\t\tsb.WriteString(%s)\n", part))
+	}
+	e.output.WriteString(fmt.Sprintf("\t\t%s = sb.String()\n\t}\n", dst))
+}
+
 func (e *OptimizedEmitter) emitComparison(instr *ir.Instruction) {
 	dst := e.emitOperand(instr.Result)
 	left := e.emitOperand(instr.Arg1)
@@ -551,12 +706,27 @@ func (e *OptimizedEmitter) emitFieldAccess(instr *ir.Instruction) {
 	obj := e.emitOperand(instr.Arg1)
 	field := instr.Arg2.Value
 
-	// Converte para PascalCase para exportação Go
-	fieldName := e.exportFieldName(field)
+	// Membros públicos são exportados no Go, privados não
+	fieldName := e.memberName(field, e.isPrivateMember(instr.Arg1.Type, field))
 
 	e.output.WriteString(fmt.Sprintf("\t%s = %s.%s\n", dst, obj, fieldName))
 }
 
+// emitAddrOf gera o endereço de um campo ou elemento, usado por STORE
+func (e *OptimizedEmitter) emitAddrOf(instr *ir.Instruction) {
+	dst := e.emitOperand(instr.Result)
+	obj := e.emitOperand(instr.Arg1)
+
+	if instr.Arg2 != nil && instr.Arg2.Kind == ir.OpField {
+		field := instr.Arg2.Value
+		fieldName := e.memberName(field, e.isPrivateMember(instr.Arg1.Type, field))
+		e.output.WriteString(fmt.Sprintf("\t%s = &%s.%s\n", dst, obj, fieldName))
+		return
+	}
+
+	e.output.WriteString(fmt.Sprintf("\t%s = &%s[%s]\n", dst, obj, e.emitOperand(instr.Arg2)))
+}
+
 func (e *OptimizedEmitter) emitIndexAccess(instr *ir.Instruction) {
 	dst := e.emitOperand(instr.Result)
 	arr := e.emitOperand(instr.Arg1)
@@ -588,7 +758,7 @@ func (e *OptimizedEmitter) emitAlloca(instr *ir.Instruction) {
 	dst := e.emitOperand(instr.Result)
 	goType := e.typeMapper.ToGoType(instr.Arg1.Type)
 
-	if e.typeMapper.CanUseStack(goType) {
+	if e.typeMapper.CanUseStack(goType) || e.typeMapper.IsFixedArray(goType) || strings.HasPrefix(goType, "chan ") {
 		// Alocação na stack
 		zeroVal := e.typeMapper.ZeroValue(goType)
 		e.output.WriteString(fmt.Sprintf("\t%s = %s\n", dst, zeroVal))
@@ -624,9 +794,53 @@ func (e *OptimizedEmitter) emitAppend(instr *ir.Instruction) {
 	dst := e.emitOperand(instr.Result)
 	slice := e.emitOperand(instr.Arg1)
 	val := e.emitOperand(instr.Arg2)
+	if instr.Spread {
+		val = e.spreadOperand(instr.Arg2)
+	}
+
+	e.output.WriteString(fmt.Sprintf("\t%s = append(%s, %s)\n", dst, slice, val))
+}
+
+// spreadOperand emite xs... (arrays fixos são fatiados com [:] antes)
+func (e *OptimizedEmitter) spreadOperand(op *ir.Operand) string {
+	val := e.emitOperand(op)
+	if op.Type != nil && e.typeMapper.IsFixedArray(e.typeMapper.ToGoType(op.Type)) {
+		val += "[:]"
+	}
+	return val + "..."
+}
+
+func (e *OptimizedEmitter) emitArrayLiteral(instr *ir.Instruction) {
+	dst := e.emitOperand(instr.Result)
+	goType := e.typeMapper.ToGoType(instr.Result.Type)
+	if goType == "" || goType == "interface{}" {
+		goType = "[]interface{}"
+	}
+
+	elems := make([]string, len(instr.Args))
+	for i, arg := range instr.Args {
+		elems[i] = e.emitOperand(arg)
+	}
 
-	// Usa runtime otimizado
-	e.output.WriteString(fmt.Sprintf("\t%s = AlphaAppend(%s, %s)\n", dst, slice, val))
+	e.output.WriteString(fmt.Sprintf("\t%s = %s{%s}\n", dst, goType, strings.Join(elems, ", ")))
+}
+
+// emitArrayCast converte entre arrays fixos ([N]T) e dinâmicos ([]T)
+func (e *OptimizedEmitter) emitArrayCast(instr *ir.Instruction, targetType string) {
+	dst := e.emitOperand(instr.Result)
+	src := e.emitOperand(instr.Arg1)
+	sourceType := e.typeMapper.ToGoType(instr.Arg1.Type)
+
+	switch {
+	case sourceType == targetType:
+		e.output.WriteString(fmt.Sprintf("\t%s = %s\n", dst, src))
+	case e.typeMapper.IsFixedArray(sourceType) && !e.typeMapper.IsFixedArray(targetType):
+		// [N]T -> []T: cópia para que o slice não compartilhe o array de origem
+		e.output.WriteString(fmt.Sprintf("\t%s = append(%s(nil), %s[:]...)\n", dst, targetType, src))
+	default:
+		// []T -> [N]T (Go verifica o tamanho em tempo de execução)
+		e.output.WriteString(fmt.Sprintf("\t%s = %s(%s)\n", dst, targetType, src))
+	}
 }
 
 func (e *OptimizedEmitter) emitOperand(op *ir.Operand) string {
@@ -636,9 +850,13 @@ func (e *OptimizedEmitter) emitOperand(op *ir.Operand) string {
 
 	switch op.Kind {
 	case ir.OpLiteral:
-		// Adiciona aspas para strings
+		// Adiciona aspas para strings (o valor já vem sem escapes do lexer)
 		if op.Type != nil && semantic.StringifyType(op.Type) == "string" {
-			return fmt.Sprintf(`"%s"`, op.Value)
+			return strconv.Quote(op.Value)
+		}
+		if op.Type != nil && semantic.StringifyType(op.Type) == "char" {
+			r, _ := utf8.DecodeRuneInString(op.Value)
+			return strconv.QuoteRune(r)
 		}
 		return op.Value
 
@@ -646,19 +864,96 @@ func (e *OptimizedEmitter) emitOperand(op *ir.Operand) string {
 		return op.Value
 
 	case ir.OpVar:
-		return op.Value
+		return goIdent(op.Value)
 
 	case ir.OpLabel:
 		return op.Value
 
 	case ir.OpFunction:
-		return op.Value
+		if structName, ok := strings.CutSuffix(op.Value, ".init"); ok {
+			return constructorName(structName)
+		}
+		return goIdent(op.Value)
 
 	default:
 		return op.Value
 	}
 }
 
+// memberName mapeia a visibilidade de um campo ou método para o Go:
+// membros públicos são exportados e privados não
+func (e *OptimizedEmitter) memberName(name string, private bool) string {
+	if private {
+		return goIdent(unexportName(name))
+	}
+	return goIdent(e.exportFieldName(name))
+}
+
+// isPrivateMember consulta a struct do objeto para saber se o membro é
+// privado. Sem o tipo do objeto, o membro é privado se todas as structs que
+// o declaram o declaram como privado
+func (e *OptimizedEmitter) isPrivateMember(objType semantic.Type, name string) bool {
+	structName := ""
+	if wrapper, ok := objType.(*semantic.ParserTypeWrapper); ok {
+		t := wrapper.Type
+		if ptr, ok := t.(*parser.PointerType); ok {
+			t = ptr.BaseType
+		}
+		switch v := t.(type) {
+		case *parser.IdentifierType:
+			structName = v.Name
+		case *parser.GenericType:
+			structName = v.Name
+		}
+	}
+
+	found, private := false, true
+	for _, s := range e.module.Structs {
+		if structName != "" && s.Name != structName {
+			continue
+		}
+		for _, field := range s.Fields {
+			if field.Name == name {
+				found = true
+				private = private && field.IsPrivate
+			}
+		}
+	}
+	for _, fn := range e.module.Functions {
+		if fn.Receiver == "" || fn.IsConstructor() || fn.Name != name {
+			continue
+		}
+		if structName == "" || fn.Receiver == structName {
+			found = true
+			private = private && !fn.IsExported
+		}
+	}
+
+	return found && private
+}
+
+// constructorName é o nome Go do construtor de uma struct, exportado
+// conforme o nome da própria struct (User -> NewUser, user -> newUser)
+func constructorName(structName string) string {
+	if structName == "" {
+		return "new"
+	}
+	first, size := utf8.DecodeRuneInString(structName)
+	if unicode.IsUpper(first) {
+		return goIdent("New" + structName)
+	}
+	return goIdent("new" + string(unicode.ToUpper(first)) + structName[size:])
+}
+
+// unexportName converte a primeira letra para minúscula
+func unexportName(name string) string {
+	if len(name) == 0 {
+		return name
+	}
+	first, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(first)) + name[size:]
+}
+
 func (e *OptimizedEmitter) exportFieldName(name string) string {
 	// Converte para PascalCase
 	if len(name) == 0 {
@@ -666,32 +961,56 @@ func (e *OptimizedEmitter) exportFieldName(name string) string {
 	}
 
 	// Verifica se já está em PascalCase
-	first := rune(name[0])
+	first, size := utf8.DecodeRuneInString(name)
 	if unicode.IsUpper(first) {
 		return name
 	}
 
 	// Converte primeira letra para maiúscula
-	return string(unicode.ToUpper(first)) + name[1:]
+	return string(unicode.ToUpper(first)) + name[size:]
 }
 
 func (e *OptimizedEmitter) emitGlobals() {
-	if len(e.module.Globals) > 0 {
+	var consts, vars []*ir.Instruction
+	for _, instr := range e.module.Globals {
+		switch {
+		case instr.Op == ir.CONST:
+			consts = append(consts, instr)
+		case instr.Op == ir.ALLOCA && instr.Result != nil:
+			vars = append(vars, instr)
+		}
+	}
+
+	if len(consts) > 0 {
+		e.output.WriteString("// Global constants\n")
+		e.output.WriteString("const (\n")
+		for _, instr := range consts {
+			e.output.WriteString(fmt.Sprintf("\t%s\n", e.emitConstSpec(instr)))
+		}
+		e.output.WriteString(")\n\n")
+	}
+
+	if len(vars) > 0 {
 		e.output.WriteString("// Global variables\n")
 		e.output.WriteString("var (\n")
 
-		for _, instr := range e.module.Globals {
-			if instr.Op == ir.ALLOCA && instr.Result != nil {
-				goType := e.typeMapper.ToGoType(instr.Arg1.Type)
-				e.output.WriteString(fmt.Sprintf("\t%s %s\n",
-					instr.Result.Value, goType))
-			}
+		for _, instr := range vars {
+			goType := e.typeMapper.ToGoType(instr.Arg1.Type)
+			e.output.WriteString(fmt.Sprintf("\t%s %s\n",
+				goIdent(instr.Result.Value), goType))
 		}
 
 		e.output.WriteString(")\n\n")
 	}
 }
 
+// emitConstSpec gera "nome tipo = valor" de uma constante. O tipo é explícito
+// para que floats inteiros (2.0) não virem constantes int no Go
+func (e *OptimizedEmitter) emitConstSpec(instr *ir.Instruction) string {
+	goType := e.typeMapper.ToGoType(instr.Result.Type)
+	return fmt.Sprintf("%s %s = %s", goIdent(instr.Result.Value), goType, e.emitOperand(instr.Arg1))
+}
+
 func (e *OptimizedEmitter) emitMainWrapper() {
 	e.output.WriteString(`func main() {
 	// Inicialização do runtime
@@ -717,11 +1036,11 @@ func (e *OptimizedEmitter) hasMainFunction() bool {
 	return false
 }
 
-func (e *OptimizedEmitter) moduleHasMaps() bool {
-	// Verifica se há operações com mapas no módulo
+// moduleHasTasks verifica se o módulo usa spawn/waitAll (e portanto o WaitGroup)
+func (e *OptimizedEmitter) moduleHasTasks() bool {
 	for _, fn := range e.module.Functions {
 		for _, instr := range fn.Instructions {
-			if instr.Op == ir.MAKE_MAP {
+			if instr.Op == ir.SPAWN || instr.Op == ir.WAIT {
 				return true
 			}
 		}
@@ -773,7 +1092,7 @@ func (e *OptimizedEmitter) emitCast(instr *ir.Instruction) {
 	// Conversões básicas
 	switch targetType {
 	case "string":
-		e.output.WriteString(fmt.Sprintf("\t%s = fmt.Sprintf(\"%%v\", %s)\n", dst, src))
+		e.output.WriteString(fmt.Sprintf("\t%s = %s\n", dst, e.stringConversion(src, nil)))
 	case "int":
 		e.output.WriteString(fmt.Sprintf("\t%s = int(%s)\n", dst, src))
 	case "float64":
@@ -792,12 +1111,23 @@ func (e *OptimizedEmitter) emitCall(instr *ir.Instruction) {
 
 	funcName := e.emitOperand(instr.Arg1)
 
+	// Chamada de método: Arg1 é o método e Arg2 o receiver
+	isMethodCall := instr.Arg1 != nil && instr.Arg1.Kind == ir.OpField && instr.Arg2 != nil
+	if isMethodCall {
+		method := instr.Arg1.Value
+		funcName = fmt.Sprintf("%s.%s", e.emitOperand(instr.Arg2),
+			e.memberName(method, e.isPrivateMember(instr.Arg2.Type, method)))
+	}
+
 	// Construir lista de argumentos
 	var args []string
-	if len(instr.Args) > 0 {
+	if len(instr.Args) > 0 || isMethodCall {
 		for _, arg := range instr.Args {
 			args = append(args, e.emitOperand(arg))
 		}
+		if instr.Spread && len(args) > 0 {
+			args[len(args)-1] = e.spreadOperand(instr.Args[len(instr.Args)-1])
+		}
 	} else {
 		// Usar Arg1 e Arg2 se Args estiver vazio
 		if instr.Arg1 != nil && instr.Arg1.Kind != ir.OpFunction {
@@ -810,3 +1140,115 @@ func (e *OptimizedEmitter) emitCall(instr *ir.Instruction) {
 
 	e.output.WriteString(fmt.Sprintf("\t%s%s(%s)\n", dst, funcName, strings.Join(args, ", ")))
 }
+
+// ============================
+// Concorrência
+// ============================
+
+// emitTaskGroup declara o WaitGroup global usado por spawn/waitAll
+func (e *OptimizedEmitter) emitTaskGroup() {
+	if !e.moduleHasTasks() {
+		return
+	}
+	e.output.WriteString("// Tarefas disparadas com spawn\n")
+	e.useImport("sync")
+	e.output.WriteString("var alphaWG sync.WaitGroup\n\n")
+}
+
+// emitSpawn dispara uma goroutine registrada no WaitGroup. Os argumentos são
+// copiados para variáveis do bloco antes do go, pois os temporários podem ser
+// reutilizados pela função enquanto a tarefa ainda executa
+func (e *OptimizedEmitter) emitSpawn(instr *ir.Instruction) {
+	funcName := e.emitOperand(instr.Arg1)
+
+	names := make([]string, len(instr.Args))
+	values := make([]string, len(instr.Args))
+	for i, arg := range instr.Args {
+		names[i] = fmt.Sprintf("a%d", i)
+		values[i] = e.emitOperand(arg)
+	}
+
+	e.output.WriteString("\talphaWG.Add(1)\n")
+	e.output.WriteString("\t{\n")
+	if len(names) > 0 {
+		e.output.WriteString(fmt.Sprintf("\t\t%s := %s\n", strings.Join(names, ", "), strings.Join(values, ", ")))
+	}
+	e.output.WriteString("\t\tgo func() {\n")
+	e.output.WriteString("\t\t\tdefer alphaWG.Done()\n")
+	e.output.WriteString(fmt.Sprintf("\t\t\t%s(%s)\n", funcName, strings.Join(names, ", ")))
+	e.output.WriteString("\t\t}()\n")
+	e.output.WriteString("\t}\n")
+}
+
+func (e *OptimizedEmitter) emitMakeChan(instr *ir.Instruction) {
+	dst := e.emitOperand(instr.Result)
+	goType := e.typeMapper.ToGoType(instr.Result.Type)
+
+	if instr.Arg1 != nil {
+		e.output.WriteString(fmt.Sprintf("\t%s = make(%s, %s)\n", dst, goType, e.emitOperand(instr.Arg1)))
+	} else {
+		e.output.WriteString(fmt.Sprintf("\t%s = make(%s)\n", dst, goType))
+	}
+}
+
+// emitSelect emite um select do Go onde cada caso salta para o label do seu corpo
+func (e *OptimizedEmitter) emitSelect(instr *ir.Instruction) {
+	e.output.WriteString("\tselect {\n")
+
+	for _, c := range instr.Select {
+		switch c.Kind {
+		case ir.SelectRecv:
+			if c.Value != nil {
+				e.output.WriteString(fmt.Sprintf("\tcase %s = <-%s:\n", e.emitOperand(c.Value), e.emitOperand(c.Chan)))
+			} else {
+				e.output.WriteString(fmt.Sprintf("\tcase <-%s:\n", e.emitOperand(c.Chan)))
+			}
+		case ir.SelectSend:
+			e.output.WriteString(fmt.Sprintf("\tcase %s <- %s:\n", e.emitOperand(c.Chan), e.emitOperand(c.Value)))
+		case ir.SelectDefault:
+			e.output.WriteString("\tdefault:\n")
+		}
+		e.output.WriteString(fmt.Sprintf("\t\tgoto %s\n", c.Label.Value))
+	}
+
+	e.output.WriteString("\t}\n")
+}
+
+// emitSwitch emite um switch nativo do Go (de valor, de tipo ou sem condição)
+// onde cada caso salta para o label do seu corpo
+func (e *OptimizedEmitter) emitSwitch(instr *ir.Instruction) {
+	typeSwitch := false
+	for _, c := range instr.Switch {
+		if len(c.Types) > 0 {
+			typeSwitch = true
+		}
+	}
+
+	switch {
+	case instr.Arg1 == nil:
+		e.output.WriteString("\tswitch {\n")
+	case typeSwitch:
+		e.output.WriteString(fmt.Sprintf("\tswitch any(%s).(type) {\n", e.emitOperand(instr.Arg1)))
+	default:
+		e.output.WriteString(fmt.Sprintf("\tswitch %s {\n", e.emitOperand(instr.Arg1)))
+	}
+
+	for _, c := range instr.Switch {
+		items := make([]string, 0, len(c.Values)+len(c.Types))
+		for _, v := range c.Values {
+			items = append(items, e.emitOperand(v))
+		}
+		for _, t := range c.Types {
+			items = append(items, e.typeMapper.ToGoType(t))
+		}
+
+		if c.IsDefault() {
+			e.output.WriteString("\tdefault:\n")
+		} else {
+			e.output.WriteString(fmt.Sprintf("\tcase %s:\n", strings.Join(items, ", ")))
+		}
+		e.output.WriteString(fmt.Sprintf("\t\tgoto %s\n", c.Label.Value))
+	}
+
+	e.output.WriteString("\t}\n")
+}
@@ -0,0 +1,34 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// ============================
+// IDENTIFICADORES GO
+// ============================
+
+// goIdent converte um identificador Alpha em um identificador Go válido.
+// Letras Unicode (número, ação) passam como estão; marcas combinantes, que o
+// Alpha aceita e o Go não, viram _uXXXX, e palavras-chave do Go ganham um '_'
+func goIdent(name string) string {
+	if token.IsKeyword(name) {
+		return name + "_"
+	}
+	if token.IsIdentifier(name) {
+		return name
+	}
+
+	var sb strings.Builder
+	for _, r := range name {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+			continue
+		}
+		fmt.Fprintf(&sb, "_u%04X", r)
+	}
+	return sb.String()
+}
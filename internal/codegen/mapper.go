@@ -8,6 +8,12 @@ import (
 	"github.com/alpha/internal/semantic"
 )
 
+// primitiveNames são os tipos primitivos do Alpha
+var primitiveNames = map[string]bool{
+	"int": true, "float": true, "bool": true, "string": true, "byte": true,
+	"char": true, "error": true, "void": true, "any": true,
+}
+
 // TypeMapper gerencia conversões de tipos Alpha -> Go
 type TypeMapper struct {
 	structTypes map[string]string // cache de tipos de struct
@@ -67,6 +73,12 @@ func (tm *TypeMapper) GetElementType(goType string) string {
 	if strings.HasPrefix(goType, "[]") {
 		return goType[2:]
 	}
+	if tm.IsFixedArray(goType) {
+		// Extrai tipo elemento de [N]T
+		if _, elem, ok := strings.Cut(goType, "]"); ok {
+			return elem
+		}
+	}
 	if strings.HasPrefix(goType, "map[") {
 		// Extrai tipo valor de map[K]V
 		parts := strings.SplitN(goType, "]", 2)
@@ -77,11 +89,17 @@ func (tm *TypeMapper) GetElementType(goType string) string {
 	return goType
 }
 
+// IsFixedArray verifica se o tipo Go é um array de tamanho fixo ([N]T)
+func (tm *TypeMapper) IsFixedArray(goType string) bool {
+	return strings.HasPrefix(goType, "[") && !strings.HasPrefix(goType, "[]")
+}
+
 // IsReferenceType verifica se o tipo é passado por referência
 func (tm *TypeMapper) IsReferenceType(goType string) bool {
 	return strings.HasPrefix(goType, "*") ||
 		strings.HasPrefix(goType, "[]") ||
 		strings.HasPrefix(goType, "map[") ||
+		strings.HasPrefix(goType, "chan ") ||
 		strings.HasPrefix(goType, "interface{}")
 }
 
@@ -102,6 +120,7 @@ func (tm *TypeMapper) ZeroValue(goType string) string {
 		if strings.HasPrefix(goType, "*") ||
 			strings.HasPrefix(goType, "[]") ||
 			strings.HasPrefix(goType, "map[") ||
+			strings.HasPrefix(goType, "chan ") ||
 			goType == "interface{}" ||
 			goType == "error" {
 			return "nil"
@@ -160,8 +179,12 @@ func (tm *TypeMapper) mapParserType(t parser.Type) string {
 		}
 
 	case *parser.IdentifierType:
+		// Tipos primitivos declarados no código chegam como identificadores
+		if primitiveNames[pt.Name] {
+			return tm.mapParserType(&parser.PrimitiveType{Name: pt.Name})
+		}
 		// Tipos definidos pelo usuário
-		return pt.Name
+		return goIdent(pt.Name)
 
 	case *parser.GenericType:
 		// Para tipos genéricos, usamos interface{} como fallback
@@ -172,6 +195,10 @@ func (tm *TypeMapper) mapParserType(t parser.Type) string {
 
 	case *parser.ArrayType:
 		elemType := tm.mapParserType(pt.ElementType)
+		// Arrays fixos são tipos valor em Go ([N]T); dinâmicos viram slices
+		if size, ok := semantic.ArraySize(pt); ok {
+			return fmt.Sprintf("[%d]%s", size, elemType)
+		}
 		return "[]" + elemType
 
 	case *parser.MapType:
@@ -195,6 +222,10 @@ func (tm *TypeMapper) mapParserType(t parser.Type) string {
 		elemType := tm.mapParserType(pt.ElementType)
 		return fmt.Sprintf("map[%s]struct{}", elemType)
 
+	case *parser.ChannelType:
+		elemType := tm.mapParserType(pt.ElementType)
+		return "chan " + elemType
+
 	default:
 		return "interface{}"
 	}
@@ -0,0 +1,161 @@
+// Package diag reúne as mensagens de erro do compilador (lexer, parser e
+// análise semântica) em um catálogo indexado por códigos estáveis, com
+// traduções em inglês e português
+package diag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================
+// CÓDIGOS E IDIOMAS
+// ============================
+
+// Code identifica um diagnóstico de forma estável entre versões e idiomas.
+// Faixas: A00xx lexer, A01xx-A07xx análise semântica, A1xxx parser
+type Code string
+
+// Language é o idioma em que os diagnósticos são escritos
+type Language string
+
+const (
+	EN Language = "en"
+	PT Language = "pt"
+)
+
+// Message é o texto de um diagnóstico em cada idioma (formato do fmt)
+type Message struct {
+	EN string
+	PT string
+}
+
+// text retorna o formato da mensagem no idioma, com o inglês como reserva
+func (m Message) text(lang Language) string {
+	if lang == PT && m.PT != "" {
+		return m.PT
+	}
+	return m.EN
+}
+
+// current é o idioma usado ao formatar diagnósticos
+var current = EN
+
+// SetLanguage define o idioma dos próximos diagnósticos
+func SetLanguage(lang Language) { current = lang }
+
+// CurrentLanguage retorna o idioma em uso
+func CurrentLanguage() Language { return current }
+
+// ParseLanguage converte "en", "pt" (ou locales como "pt_BR.UTF-8") em Language
+func ParseLanguage(name string) (Language, bool) {
+	name = strings.ToLower(name)
+	switch {
+	case name == "en" || strings.HasPrefix(name, "en_"):
+		return EN, true
+	case name == "pt" || strings.HasPrefix(name, "pt_"):
+		return PT, true
+	}
+	return "", false
+}
+
+// LanguageFromEnv escolhe o idioma a partir do locale (LC_ALL, LC_MESSAGES e
+// LANG, nessa ordem de prioridade). Locales desconhecidos usam o inglês
+func LanguageFromEnv(getenv func(string) string) Language {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := getenv(name); value != "" {
+			if lang, ok := ParseLanguage(value); ok {
+				return lang
+			}
+			return EN
+		}
+	}
+	return EN
+}
+
+// ============================
+// FORMATAÇÃO
+// ============================
+
+// Msg formata o diagnóstico no idioma atual
+func Msg(code Code, args ...any) string {
+	msg, ok := catalog[code]
+	if !ok {
+		return fmt.Sprintf("unknown diagnostic %s", code)
+	}
+	return fmt.Sprintf(msg.text(current), args...)
+}
+
+// Label prefixa a mensagem com o código do diagnóstico: "[A0101] Undeclared identifier 'x'"
+func Label(code Code, msg string) string {
+	return "[" + string(code) + "] " + msg
+}
+
+// Error é um diagnóstico devolvido como error (ex: avaliação de constantes),
+// formatado apenas quando exibido
+type Error struct {
+	Code Code
+	Args []any
+}
+
+// Errorf cria um diagnóstico com o código e os argumentos da mensagem
+func Errorf(code Code, args ...any) *Error {
+	return &Error{Code: code, Args: args}
+}
+
+func (e *Error) Error() string { return Msg(e.Code, e.Args...) }
+
+// Term é um trecho traduzível usado como argumento de mensagens ("binary",
+// "Function '%s'"). O texto em inglês é a própria chave
+type Term struct {
+	key  string
+	args []any
+}
+
+// T cria um trecho traduzível
+func T(key string, args ...any) Term {
+	return Term{key: key, args: args}
+}
+
+func (t Term) String() string {
+	format := t.key
+	if pt, ok := terms[t.key]; ok && current == PT {
+		format = pt
+	}
+	if len(t.args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, t.args...)
+}
+
+// catalog reúne as mensagens de todas as etapas
+var catalog = merge(lexerMessages, parserMessages, semanticMessages)
+
+func merge(groups ...map[Code]Message) map[Code]Message {
+	all := make(map[Code]Message)
+	for _, group := range groups {
+		for code, msg := range group {
+			all[code] = msg
+		}
+	}
+	return all
+}
+
+// terms traduz os trechos usados como argumento (chave em inglês -> português)
+var terms = map[string]string{
+	// Bases de literais numéricos
+	"hexadecimal": "hexadecimal",
+	"octal":       "octal",
+	"binary":      "binário",
+	"decimal":     "decimal",
+	"float":       "float",
+
+	// Descrições usadas pela análise semântica
+	"Function '%s'":                        "Função '%s'",
+	"Method '%s'":                          "Método '%s'",
+	"Constructor of '%s'":                  "Construtor de '%s'",
+	"the conversion target":                "o destino da conversão",
+	"the return type":                      "o tipo de retorno",
+	"; use an explicit conversion %s(...)": "; use uma conversão explícita %s(...)",
+	"; did you mean '%s'?":                 "; você quis dizer '%s'?",
+}
@@ -0,0 +1,76 @@
+package diag
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ============================
+// EXPLICAÇÕES (alpha explain)
+// ============================
+
+// Explanation é a explicação longa de um diagnóstico: o que ele significa, um
+// exemplo que o provoca e a versão corrigida do mesmo exemplo. Códigos que
+// nenhum programa provoca hoje são marcados Unreachable e não têm exemplos; o
+// texto diz por quê
+type Explanation struct {
+	Text        Message
+	Wrong       string
+	Right       string
+	Unreachable bool
+}
+
+// explanations reúne as explicações de todas as etapas
+var explanations = mergeExplanations(lexerExplanations, parserExplanations, semanticExplanations)
+
+func mergeExplanations(groups ...map[Code]Explanation) map[Code]Explanation {
+	all := make(map[Code]Explanation)
+	for _, group := range groups {
+		for code, exp := range group {
+			all[code] = exp
+		}
+	}
+	return all
+}
+
+// ParseCode normaliza um código digitado pelo usuário ("a0101" -> "A0101")
+func ParseCode(text string) (Code, bool) {
+	code := Code(strings.ToUpper(strings.TrimSpace(text)))
+	_, ok := catalog[code]
+	return code, ok
+}
+
+// Codes retorna todos os códigos do catálogo em ordem
+func Codes() []Code {
+	codes := make([]Code, 0, len(catalog))
+	for code := range catalog {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	return codes
+}
+
+// verb encontra os argumentos de formato de uma mensagem (%s, %d, %T...)
+var verb = regexp.MustCompile(`\\?%[-+# 0-9.]*[a-zA-Z]`)
+
+// Summary retorna a mensagem do diagnóstico no idioma atual, com os
+// argumentos trocados por "…" ("Undeclared identifier '…'")
+func Summary(code Code) string {
+	msg, ok := catalog[code]
+	if !ok {
+		return ""
+	}
+	return verb.ReplaceAllString(msg.text(current), "…")
+}
+
+// Explain retorna a explicação longa do diagnóstico
+func Explain(code Code) (Explanation, bool) {
+	exp, ok := explanations[code]
+	return exp, ok
+}
+
+// Description retorna o texto da explicação no idioma atual
+func (e Explanation) Description() string {
+	return e.Text.text(current)
+}
@@ -0,0 +1,162 @@
+package diag
+
+// ============================
+// EXPLICAÇÕES DO LEXER (A00xx)
+// ============================
+
+var lexerExplanations = map[Code]Explanation{
+	UnrecognizedOperator: {
+		Text: Message{
+			EN: `The scanner found a character that is not part of any Alpha operator or
+punctuation. Symbols such as '@', '#' and '$' (outside a string) have no
+meaning in Alpha. Check for a typo or for a symbol copied from another
+language.`,
+			PT: `O analisador léxico encontrou um caractere que não faz parte de nenhum
+operador ou pontuação do Alpha. Símbolos como '@', '#' e '$' (fora de uma
+string) não têm significado em Alpha. Verifique se há um erro de digitação
+ou um símbolo copiado de outra linguagem.`,
+		},
+		Wrong: `int total = 10 @ 2`,
+		Right: `int total = 10 * 2`,
+	},
+	UnterminatedString: {
+		Text: Message{
+			EN: `A string opened with '"' was never closed: the file ended before the
+closing quote. Every string literal needs a '"' at the end. A quote inside
+the text must be escaped as \".`,
+			PT: `Uma string aberta com '"' nunca foi fechada: o arquivo terminou antes da
+aspa final. Todo literal de string precisa de um '"' no fim. Uma aspa dentro
+do texto deve ser escrita como \".`,
+		},
+		Wrong: `string name = "Alpha`,
+		Right: `string name = "Alpha"`,
+	},
+	UnterminatedInterp: {
+		Text: Message{
+			EN: `An interpolation "${" inside a string was not closed with '}' on the same
+line. The expression between "${" and "}" is inserted into the text; to write
+a literal "${" use \${ instead.`,
+			PT: `Uma interpolação "${" dentro de uma string não foi fechada com '}' na
+mesma linha. A expressão entre "${" e "}" é inserida no texto; para escrever
+"${" literalmente use \${.`,
+		},
+		Wrong: `string name = "Ana"
+string msg = "Olá, ${name"`,
+		Right: `string name = "Ana"
+string msg = "Olá, ${name}"`,
+	},
+	UnterminatedRawString: {
+		Text: Message{
+			EN: "A raw string opened with a backtick (`) was never closed. Raw strings\n" +
+				"may span several lines, so the missing backtick is often far below the\n" +
+				"place where the string starts.",
+			PT: "Uma string bruta aberta com crase (`) nunca foi fechada. Strings brutas\n" +
+				"podem ocupar várias linhas, então a crase que falta costuma estar bem\n" +
+				"abaixo do ponto em que a string começa.",
+		},
+		Wrong: "string path = `C:\\alpha\\bin",
+		Right: "string path = `C:\\alpha\\bin`",
+	},
+	UnterminatedChar: {
+		Text: Message{
+			EN: `A character literal opened with ' was not closed before the end of the
+line. Character literals hold a single character between single quotes,
+like 'a' or '\n'.`,
+			PT: `Um literal de caractere aberto com ' não foi fechado antes do fim da
+linha. Literais de caractere guardam um único caractere entre aspas simples,
+como 'a' ou '\n'.`,
+		},
+		Wrong: `char letter = 'a`,
+		Right: `char letter = 'a'`,
+	},
+	EmptyChar: {
+		Text: Message{
+			EN: `The literal '' has no character between the quotes. A char always holds
+exactly one character; to represent "no text" use an empty string "" instead.`,
+			PT: `O literal '' não tem nenhum caractere entre as aspas. Um char sempre
+guarda exatamente um caractere; para representar "nenhum texto" use a string
+vazia "".`,
+		},
+		Wrong: `char letter = ''`,
+		Right: `char letter = ' '`,
+	},
+	CharTooLong: {
+		Text: Message{
+			EN: `Single quotes delimit one character, but this literal contains several.
+Text with more than one character is a string and uses double quotes.`,
+			PT: `Aspas simples delimitam um caractere, mas este literal contém vários.
+Textos com mais de um caractere são strings e usam aspas duplas.`,
+		},
+		Wrong: `char word = 'abc'`,
+		Right: `string word = "abc"`,
+	},
+	UnknownEscape: {
+		Text: Message{
+			EN: `A backslash inside a character literal starts an escape sequence, but the
+character after it is not a known escape. The valid escapes are \n, \t, \r,
+\0, \\, \', \" and \$.`,
+			PT: `Uma barra invertida dentro de um literal de caractere inicia uma sequência
+de escape, mas o caractere seguinte não é um escape conhecido. Os escapes
+válidos são \n, \t, \r, \0, \\, \', \" e \$.`,
+		},
+		Wrong: `char tab = '\q'`,
+		Right: `char tab = '\t'`,
+	},
+	NoDigits: {
+		Text: Message{
+			EN: `A number prefix (0x for hexadecimal, 0o for octal or 0b for binary) must
+be followed by at least one digit of that base.`,
+			PT: `Um prefixo numérico (0x para hexadecimal, 0o para octal ou 0b para
+binário) deve ser seguido de pelo menos um dígito daquela base.`,
+		},
+		Wrong: `int mask = 0x`,
+		Right: `int mask = 0xFF`,
+	},
+	MalformedExponent: {
+		Text: Message{
+			EN: `A number in scientific notation needs digits after the 'e': 1e3 means
+1 × 10³ and 2.5e-4 means 2.5 × 10⁻⁴. The exponent may have a sign, but not
+be empty.`,
+			PT: `Um número em notação científica precisa de dígitos após o 'e': 1e3 é
+1 × 10³ e 2.5e-4 é 2.5 × 10⁻⁴. O expoente pode ter sinal, mas não pode ser
+vazio.`,
+		},
+		Wrong: `float big = 1e`,
+		Right: `float big = 1e6`,
+	},
+	MisplacedDigitSep: {
+		Text: Message{
+			EN: `The '_' separator makes long numbers easier to read (1_000_000), but it
+may only appear between two digits. It cannot start or end the number or
+appear twice in a row.`,
+			PT: `O separador '_' facilita a leitura de números longos (1_000_000), mas só
+pode aparecer entre dois dígitos. Ele não pode iniciar nem terminar o número
+nem aparecer duas vezes seguidas.`,
+		},
+		Wrong: `int million = 1__000_000`,
+		Right: `int million = 1_000_000`,
+	},
+	InvalidDigit: {
+		Text: Message{
+			EN: `The literal uses a digit that does not exist in its base. Binary numbers
+(0b) only use 0 and 1, and octal numbers (0o) only use 0 to 7.`,
+			PT: `O literal usa um dígito que não existe na sua base. Números binários (0b)
+usam apenas 0 e 1, e números octais (0o) usam apenas de 0 a 7.`,
+		},
+		Wrong: `int flags = 0b1021`,
+		Right: `int flags = 0b1011`,
+	},
+	InvalidCharInLiteral: {
+		Text: Message{
+			EN: `A number is immediately followed by a letter that is not part of it.
+Hexadecimal digits go from 0 to F, and only 'e' (exponent) and 'f' (float
+suffix) may follow a decimal number. Identifiers cannot start with a digit.`,
+			PT: `Um número é seguido imediatamente por uma letra que não faz parte dele.
+Dígitos hexadecimais vão de 0 a F, e apenas 'e' (expoente) e 'f' (sufixo de
+float) podem seguir um número decimal. Identificadores não podem começar com
+dígito.`,
+		},
+		Wrong: `int color = 0xFG`,
+		Right: `int color = 0xFF`,
+	},
+}
@@ -0,0 +1,1132 @@
+package diag
+
+// ============================
+// EXPLICAÇÕES DO PARSER (A1xxx)
+// ============================
+
+var parserExplanations = map[Code]Explanation{
+	// Gerais
+	ExpectedToken: {
+		Text: Message{
+			EN: `The parser expected a specific symbol or keyword at this point but found
+something else. The most common causes are an unclosed parenthesis, bracket
+or brace, or a missing keyword such as 'from' or 'while'.`,
+			PT: `O parser esperava um símbolo ou palavra-chave específica neste ponto, mas
+encontrou outra coisa. As causas mais comuns são um parêntese, colchete ou
+chave sem fechamento, ou uma palavra-chave faltando, como 'from' ou 'while'.`,
+		},
+		Wrong: `int x = (1 + 2`,
+		Right: `int x = (1 + 2)`,
+	},
+	UnexpectedKeyword: {
+		Text: Message{
+			EN: `A keyword appeared where an expression was expected. Keywords such as
+'else', 'return' or 'case' only have meaning in their own statements and
+cannot be used as values or variable names.`,
+			PT: `Uma palavra-chave apareceu onde era esperada uma expressão. Palavras-chave
+como 'else', 'return' ou 'case' só têm significado nas suas próprias
+instruções e não podem ser usadas como valores ou nomes de variáveis.`,
+		},
+		Wrong: `int else = 1`,
+		Right: `int otherwise = 1`,
+	},
+	UnexpectedOperator: {
+		Text: Message{
+			EN: `An operator or punctuation symbol appeared where an expression was
+expected. Check for a missing operand, an extra closing parenthesis or two
+operators in a row.`,
+			PT: `Um operador ou símbolo de pontuação apareceu onde era esperada uma
+expressão. Verifique se falta um operando, se há um parêntese de fechamento
+a mais ou dois operadores seguidos.`,
+		},
+		Wrong: `int x = (1 + 2))`,
+		Right: `int x = (1 + 2)`,
+	},
+	ExpectedParenAfter: {
+		Text: Message{
+			EN: `Conditions of 'if', 'while' and 'switch' are always written between
+parentheses in Alpha, unlike languages such as Python or Go.`,
+			PT: `As condições de 'if', 'while' e 'switch' são sempre escritas entre
+parênteses em Alpha, ao contrário de linguagens como Python ou Go.`,
+		},
+		Wrong: `int x = 1
+if x > 0 {
+    x = 2
+}`,
+		Right: `int x = 1
+if (x > 0) {
+    x = 2
+}`,
+	},
+	ExpectedCondition: {
+		Text: Message{
+			EN: `The parentheses of an 'if', 'while' or 'switch' are empty. Write the
+condition to be tested between them.`,
+			PT: `Os parênteses de um 'if', 'while' ou 'switch' estão vazios. Escreva entre
+eles a condição a ser testada.`,
+		},
+		Wrong: `int x = 1
+if () {
+    x = 2
+}`,
+		Right: `int x = 1
+if (x > 0) {
+    x = 2
+}`,
+	},
+	ExpectedCloseParenCond: {
+		Text: Message{
+			EN: `The condition was opened with '(' but not closed with ')' before the body
+of the statement.`,
+			PT: `A condição foi aberta com '(' mas não foi fechada com ')' antes do corpo
+da instrução.`,
+		},
+		Wrong: `int x = 1
+if (x > 0 {
+    x = 2
+}`,
+		Right: `int x = 1
+if (x > 0) {
+    x = 2
+}`,
+	},
+	TooManyErrors: {
+		Text: Message{
+			EN: `The file has more syntax errors than the compiler reports at once. Fix
+the first errors and compile again: the later ones are often consequences of
+them and disappear together.`,
+			PT: `O arquivo tem mais erros de sintaxe do que o compilador reporta de uma
+vez. Corrija os primeiros erros e compile de novo: os seguintes muitas vezes
+são consequência deles e desaparecem juntos.`,
+		},
+		Wrong: `int a = *
+int b = *
+int c = *
+int d = *
+int e = *
+int f = *
+int g = *
+int h = *
+int i = *
+int j = *
+int k = *`,
+		Right: `int a = 1
+int b = 2
+int c = 3
+int d = 4
+int e = 5
+int f = 6
+int g = 7
+int h = 8
+int i = 9
+int j = 10
+int k = 11`,
+	},
+
+	// Declarações
+	ExpectedVarName: {
+		Text: Message{
+			EN: `A 'var' declaration needs the name of the variable right after the
+keyword: var name = value.`,
+			PT: `Uma declaração 'var' precisa do nome da variável logo após a palavra-chave:
+var nome = valor.`,
+		},
+		Wrong: `var = 10`,
+		Right: `var total = 10`,
+	},
+	ExpectedVarNameAfterComma: {
+		Text: Message{
+			EN: `In a declaration of several variables each comma must be followed by
+another name, as in var a, b = pair().`,
+			PT: `Em uma declaração de várias variáveis, cada vírgula deve ser seguida de
+outro nome, como em var a, b = par().`,
+		},
+		Wrong: `var a, = 1`,
+		Right: `var a = 1`,
+	},
+	ExpectedVarInit: {
+		Text: Message{
+			EN: `The '=' of a declaration must be followed by the initial value. Remove the
+'=' to declare the variable with its zero value.`,
+			PT: `O '=' de uma declaração deve ser seguido do valor inicial. Remova o '='
+para declarar a variável com o valor zero do tipo.`,
+		},
+		Wrong: `var total =`,
+		Right: `var total = 0`,
+	},
+	MultiVarNeedsInit: {
+		Text: Message{
+			EN: `Declaring several variables with 'var' only works when they are
+initialized together from a function that returns several values; their
+types come from that function.`,
+			PT: `Declarar várias variáveis com 'var' só funciona quando elas são
+inicializadas juntas por uma função que retorna vários valores; os tipos
+delas vêm dessa função.`,
+		},
+		Wrong: `var a, b`,
+		Right: `int, int function pair() {
+    return 1, 2
+}
+var a, b = pair()`,
+	},
+	ExpectedConstName: {
+		Text: Message{
+			EN: `A 'const' declaration needs the name of the constant right after the
+keyword: const NAME = value.`,
+			PT: `Uma declaração 'const' precisa do nome da constante logo após a
+palavra-chave: const NOME = valor.`,
+		},
+		Wrong: `const = 3.14`,
+		Right: `const PI = 3.14`,
+	},
+	ExpectedConstNameAfterComma: {
+		Text: Message{
+			EN: `In a declaration of several constants each comma must be followed by
+another name.`,
+			PT: `Em uma declaração de várias constantes, cada vírgula deve ser seguida de
+outro nome.`,
+		},
+		Wrong: `const MIN, = 1`,
+		Right: `const MIN = 1`,
+	},
+	ExpectedConstAssign: {
+		Text: Message{
+			EN: `A constant must receive its value in the declaration itself, so its name
+must be followed by '='.`,
+			PT: `Uma constante deve receber o valor na própria declaração, então o nome
+dela deve ser seguido de '='.`,
+		},
+		Wrong: `const MAX 100`,
+		Right: `const MAX = 100`,
+	},
+	ExpectedConstValue: {
+		Text: Message{
+			EN: `The '=' of a constant must be followed by its value. Constants cannot be
+declared empty and assigned later.`,
+			PT: `O '=' de uma constante deve ser seguido do valor. Constantes não podem ser
+declaradas vazias e receber o valor depois.`,
+		},
+		Wrong: `const MAX =`,
+		Right: `const MAX = 100`,
+	},
+	ExpectedExprAfterAssign: {
+		Text: Message{
+			EN: `An '=' in a typed declaration or an assignment must be followed by the
+value to store.`,
+			PT: `Um '=' em uma declaração com tipo ou em uma atribuição deve ser seguido do
+valor a ser guardado.`,
+		},
+		Wrong: `int count =`,
+		Right: `int count = 0`,
+	},
+	ExpectedFuncName: {
+		Text: Message{
+			EN: `The keyword 'function' must be followed by the name of the function:
+return type, 'function', name and then the parameter list.`,
+			PT: `A palavra-chave 'function' deve ser seguida do nome da função: tipo de
+retorno, 'function', nome e depois a lista de parâmetros.`,
+		},
+		Wrong: `int function (int a, int b) {
+    return a + b
+}`,
+		Right: `int function sum(int a, int b) {
+    return a + b
+}`,
+	},
+	ExpectedParamType: {
+		Text: Message{
+			EN: `Each parameter is written as a type followed by a name (int count). This
+position of the parameter list should start with a type.`,
+			PT: `Cada parâmetro é escrito como um tipo seguido de um nome (int total). Esta
+posição da lista de parâmetros deveria começar com um tipo.`,
+		},
+		Wrong: `int function twice(, int n) {
+    return n * 2
+}`,
+		Right: `int function twice(int n) {
+    return n * 2
+}`,
+	},
+	ExpectedParamName: {
+		Text: Message{
+			EN: `A parameter type must be followed by the parameter name, which is how the
+function body refers to the argument.`,
+			PT: `O tipo de um parâmetro deve ser seguido do nome do parâmetro, que é como o
+corpo da função se refere ao argumento.`,
+		},
+		Wrong: `int function twice(int) {
+    return 0
+}`,
+		Right: `int function twice(int n) {
+    return n * 2
+}`,
+	},
+	ExpectedDefaultValue: {
+		Text: Message{
+			EN: `A parameter followed by '=' declares a default value, so a value must
+follow the '='. Remove the '=' if the parameter has no default.`,
+			PT: `Um parâmetro seguido de '=' declara um valor padrão, então um valor deve
+vir após o '='. Remova o '=' se o parâmetro não tiver valor padrão.`,
+		},
+		Wrong: `string function greet(string name =) {
+    return "Olá, " + name
+}`,
+		Right: `string function greet(string name = "mundo") {
+    return "Olá, " + name
+}`,
+	},
+	VariadicNotLast: {
+		Text: Message{
+			EN: `A variadic parameter (int... nums) collects all remaining arguments, so it
+must be the last parameter of the function.`,
+			PT: `Um parâmetro variádico (int... nums) recebe todos os argumentos restantes,
+então ele deve ser o último parâmetro da função.`,
+		},
+		Wrong: `int function total(int... nums, int start) {
+    return start
+}`,
+		Right: `int function total(int start, int... nums) {
+    return start
+}`,
+	},
+	ExpectedParamSep: {
+		Text: Message{
+			EN: `Parameters are separated by commas and the list ends with ')'. A comma is
+probably missing between two parameters.`,
+			PT: `Os parâmetros são separados por vírgulas e a lista termina com ')'.
+Provavelmente falta uma vírgula entre dois parâmetros.`,
+		},
+		Wrong: `int function sum(int a int b) {
+    return a + b
+}`,
+		Right: `int function sum(int a, int b) {
+    return a + b
+}`,
+	},
+	ExpectedFuncBody: {
+		Text: Message{
+			EN: `The body of a function or method is a block between braces, even when it
+has a single statement.`,
+			PT: `O corpo de uma função ou método é um bloco entre chaves, mesmo quando tem
+uma única instrução.`,
+		},
+		Wrong: `int function one() return 1`,
+		Right: `int function one() {
+    return 1
+}`,
+	},
+	ExpectedStructName: {
+		Text: Message{
+			EN: `The keyword 'struct' must be followed by the name of the new type. Struct
+names start with an uppercase letter and have at least two characters,
+since single uppercase letters are reserved for generic parameters.`,
+			PT: `A palavra-chave 'struct' deve ser seguida do nome do novo tipo. Nomes de
+structs começam com letra maiúscula e têm pelo menos dois caracteres, pois
+letras maiúsculas isoladas são reservadas para parâmetros genéricos.`,
+		},
+		Wrong: `struct {
+    int x
+}`,
+		Right: `struct Point {
+    int x
+}`,
+	},
+	ExpectedTypeAfterPrivate: {
+		Text: Message{
+			EN: `'private' marks a field as visible only inside the struct's implement
+block. It must be followed by a normal field declaration: type and name.`,
+			PT: `'private' marca um campo como visível apenas dentro do bloco implement da
+struct. Ele deve ser seguido de uma declaração de campo normal: tipo e nome.`,
+		},
+		Wrong: `struct Account {
+    private
+}`,
+		Right: `struct Account {
+    private string password
+}`,
+	},
+	ExpectedFieldType: {
+		Text: Message{
+			EN: `Each field of a struct is declared as a type followed by a name. This line
+of the struct body does not start with a type.`,
+			PT: `Cada campo de uma struct é declarado como um tipo seguido de um nome. Esta
+linha do corpo da struct não começa com um tipo.`,
+		},
+		Wrong: `struct Point {
+    x int
+}`,
+		Right: `struct Point {
+    int x
+}`,
+	},
+	ExpectedFieldName: {
+		Text: Message{
+			EN: `The type of a struct field must be followed by the name of the field.`,
+			PT: `O tipo de um campo de struct deve ser seguido do nome do campo.`,
+		},
+		Wrong: `struct Point {
+    int 1
+}`,
+		Right: `struct Point {
+    int x
+}`,
+	},
+	ExpectedImplTarget: {
+		Text: Message{
+			EN: `'implement' adds methods to an existing struct, so it must be followed by
+the name of that struct.`,
+			PT: `'implement' adiciona métodos a uma struct existente, então deve ser
+seguido do nome dessa struct.`,
+		},
+		Wrong: `struct Counter {
+    int value
+}
+implement {
+    int get() {
+        return self.value
+    }
+}`,
+		Right: `struct Counter {
+    int value
+}
+implement Counter {
+    int get() {
+        return self.value
+    }
+}`,
+	},
+	MultipleInit: {
+		Text: Message{
+			EN: `A struct has at most one 'init' constructor. To support several ways of
+building a value, give the parameters default values or use named
+arguments.`,
+			PT: `Uma struct tem no máximo um construtor 'init'. Para permitir várias formas
+de criar um valor, dê valores padrão aos parâmetros ou use argumentos
+nomeados.`,
+		},
+		Wrong: `struct Counter {
+    int value
+}
+implement Counter {
+    init() {
+        self.value = 0
+    }
+    init(int start) {
+        self.value = start
+    }
+}`,
+		Right: `struct Counter {
+    int value
+}
+implement Counter {
+    init(int start = 0) {
+        self.value = start
+    }
+}`,
+	},
+	ExpectedAfterGenerics: {
+		Text: Message{
+			EN: `A generic<...> prefix declares type parameters for what comes next, which
+must be a struct or a function (starting with its return type).`,
+			PT: `Um prefixo generic<...> declara parâmetros de tipo para o que vem a
+seguir, que deve ser uma struct ou uma função (começando pelo tipo de
+retorno).`,
+		},
+		Wrong: `generic<T> 1`,
+		Right: `generic<T> T function same(T value) {
+    return value
+}`,
+	},
+	ExpectedFunctionKeyword: {
+		Text: Message{
+			EN: `After the return type of a generic function comes the keyword 'function'
+and then the name.`,
+			PT: `Depois do tipo de retorno de uma função genérica vem a palavra-chave
+'function' e então o nome.`,
+		},
+		Wrong: `generic<T> T same(T value) {
+    return value
+}`,
+		Right: `generic<T> T function same(T value) {
+    return value
+}`,
+	},
+	ExpectedTypeName: {
+		Text: Message{
+			EN: `A 'type' declaration gives a new name to a type: type Name int | string.
+The keyword must be followed by the new name.`,
+			PT: `Uma declaração 'type' dá um novo nome a um tipo: type Nome int | string.
+A palavra-chave deve ser seguida do novo nome.`,
+		},
+		Wrong: `type int | string`,
+		Right: `type Value int | string`,
+	},
+
+	// Módulos
+	ExpectedPackageName: {
+		Text: Message{
+			EN: `'package' declares the package of the file and must be followed by its
+name, for example package main.`,
+			PT: `'package' declara o pacote do arquivo e deve ser seguido do nome dele, por
+exemplo package main.`,
+		},
+		Wrong: `package 1`,
+		Right: `package main`,
+	},
+	UnknownLanguage: {
+		Text: Message{
+			EN: `The 'lang' directive in the package header selects the language of the
+keywords in the file. Alpha understands 'en' (English, the default) and
+'pt' (Portuguese: se, enquanto, funcao...).`,
+			PT: `A diretiva 'lang' no cabeçalho do pacote escolhe o idioma das
+palavras-chave do arquivo. O Alpha entende 'en' (inglês, o padrão) e 'pt'
+(português: se, enquanto, funcao...).`,
+		},
+		Wrong: `package main lang fr`,
+		Right: `package main lang pt`,
+	},
+	ExpectedPackageSegment: {
+		Text: Message{
+			EN: `Package names may have several parts separated by dots (app.models), but
+each dot must be followed by another name.`,
+			PT: `Nomes de pacote podem ter várias partes separadas por pontos
+(app.models), mas cada ponto deve ser seguido de outro nome.`,
+		},
+		Wrong: `package app.`,
+		Right: `package app.models`,
+	},
+	ExpectedImportForm: {
+		Text: Message{
+			EN: `An import either loads a whole module (import math) or picks names from it
+(import sqrt, pow from math). A list of names without 'from' is not valid.`,
+			PT: `Um import carrega um módulo inteiro (import math) ou escolhe nomes dele
+(import sqrt, pow from math). Uma lista de nomes sem 'from' não é válida.`,
+		},
+		Wrong: `import sqrt, pow`,
+		Right: `import sqrt, pow from math`,
+	},
+	ModuleImportAlias: {
+		Text: Message{
+			EN: `Only names picked from a module can be renamed with 'as'. A whole module
+is always used by its own name.`,
+			PT: `Apenas nomes escolhidos de um módulo podem ser renomeados com 'as'. Um
+módulo inteiro é sempre usado pelo próprio nome.`,
+		},
+		Wrong: `import math as m`,
+		Right: `import sqrt as root from math`,
+	},
+	ExpectedModuleAfterFrom: {
+		Text: Message{
+			EN: `'from' must be followed by the module to import from: a name (math) or a
+path between quotes ("./utils").`,
+			PT: `'from' deve ser seguido do módulo de onde importar: um nome (math) ou um
+caminho entre aspas ("./utils").`,
+		},
+		Wrong: `import sqrt from 1`,
+		Right: `import sqrt from math`,
+	},
+	ExpectedFrom: {
+		Text: Message{
+			EN: `A list of names between braces must say which module it comes from:
+import { sqrt } from math.`,
+			PT: `Uma lista de nomes entre chaves deve dizer de qual módulo ela vem:
+import { sqrt } from math.`,
+		},
+		Wrong: `import { sqrt } math`,
+		Right: `import { sqrt } from math`,
+	},
+	ExpectedImportName: {
+		Text: Message{
+			EN: `Each item of an import list must be the name of something exported by the
+module.`,
+			PT: `Cada item de uma lista de importação deve ser o nome de algo exportado
+pelo módulo.`,
+		},
+		Wrong: `import 1`,
+		Right: `import math`,
+	},
+	ExpectedAlias: {
+		Text: Message{
+			EN: `'as' renames an imported or exported name and must be followed by the new
+name.`,
+			PT: `'as' renomeia um nome importado ou exportado e deve ser seguido do novo
+nome.`,
+		},
+		Wrong: `import sqrt as 1 from math`,
+		Right: `import sqrt as root from math`,
+	},
+	ExpectedExportName: {
+		Text: Message{
+			EN: `'export' must be followed by the names of the functions, types or
+variables that other packages may use.`,
+			PT: `'export' deve ser seguido dos nomes das funções, tipos ou variáveis que
+outros pacotes podem usar.`,
+		},
+		Wrong: `int total = 1
+export 1`,
+		Right: `int total = 1
+export total`,
+	},
+
+	// Instruções
+	ExpectedSwitchBody: {
+		Text: Message{
+			EN: `The cases of a switch are written inside braces after the value being
+tested.`,
+			PT: `Os casos de um switch são escritos entre chaves após o valor testado.`,
+		},
+		Wrong: `int x = 1
+switch (x) case 1: x = 2`,
+		Right: `int x = 1
+switch (x) {
+    case 1:
+        x = 2
+}`,
+	},
+	ExpectedCase: {
+		Text: Message{
+			EN: `Inside a switch or select, every statement belongs to a 'case' or to the
+'default' branch. Code directly inside the braces is not allowed.`,
+			PT: `Dentro de um switch ou select, toda instrução pertence a um 'case' ou ao
+ramo 'default'. Código diretamente dentro das chaves não é permitido.`,
+		},
+		Wrong: `int x = 1
+switch (x) {
+    x = 2
+}`,
+		Right: `int x = 1
+switch (x) {
+    default:
+        x = 2
+}`,
+	},
+	ExpectedCaseType: {
+		Text: Message{
+			EN: `In a type switch each case lists complete types separated by commas.`,
+			PT: `Em um switch de tipos, cada caso lista tipos completos separados por
+vírgulas.`,
+		},
+		Wrong: `int | string v = 1
+switch (v) {
+    case int<:
+        v = 2
+}`,
+		Right: `int | string v = 1
+switch (v) {
+    case int:
+        v = 2
+}`,
+	},
+	ExpectedCaseExpr: {
+		Text: Message{
+			EN: `'case' must be followed by the value (or the condition, in a switch
+without subject) that selects the branch.`,
+			PT: `'case' deve ser seguido do valor (ou da condição, em um switch sem valor)
+que seleciona o ramo.`,
+		},
+		Wrong: `int x = 1
+switch (x) {
+    case :
+        x = 2
+}`,
+		Right: `int x = 1
+switch (x) {
+    case 1:
+        x = 2
+}`,
+	},
+	ExpectedDoBlock: {
+		Text: Message{
+			EN: `'do' must be followed by the body of the loop, which runs at least once
+before the 'while' condition is tested.`,
+			PT: `'do' deve ser seguido do corpo do loop, que executa pelo menos uma vez
+antes de a condição do 'while' ser testada.`,
+		},
+		Wrong: `do`,
+		Right: `int x = 0
+do {
+    x++
+} while (x < 3)`,
+	},
+	ExpectedDoWhile: {
+		Text: Message{
+			EN: `A do loop ends with 'while' and the condition that decides whether the
+body runs again.`,
+			PT: `Um loop do termina com 'while' e a condição que decide se o corpo executa
+de novo.`,
+		},
+		Wrong: `int x = 0
+do {
+    x++
+} (x < 3)`,
+		Right: `int x = 0
+do {
+    x++
+} while (x < 3)`,
+	},
+	ExpectedForInName: {
+		Text: Message{
+			EN: `A for-in loop starts with the name of the variable that receives each
+item: for (item in list). To also get the position use for (i, item in
+list).
+
+No program triggers this error today: the parser only treats the loop as a
+for-in when a name follows the '(' directly; anything else is read as a
+traditional for loop and reported as one.`,
+			PT: `Um loop for-in começa com o nome da variável que recebe cada item:
+for (item in lista). Para obter também a posição use for (i, item in
+lista).
+
+Nenhum programa provoca este erro hoje: o parser só trata o loop como for-in
+quando um nome vem logo após o '('; qualquer outra coisa é lida como um for
+tradicional e reportada como tal.`,
+		},
+		Unreachable: true,
+	},
+	ExpectedForInSecond: {
+		Text: Message{
+			EN: `In for (i, item in list) the comma after the index must be followed by
+the name of the variable that receives each item.`,
+			PT: `Em for (i, item in lista) a vírgula após o índice deve ser seguida do
+nome da variável que recebe cada item.`,
+		},
+		Wrong: `int[] xs = [1, 2]
+for (i, 1 in xs) {
+}`,
+		Right: `int[] xs = [1, 2]
+for (i, x in xs) {
+}`,
+	},
+	ExpectedSpawnCall: {
+		Text: Message{
+			EN: `'spawn' starts a function call in a new task, so it must be followed by a
+call with its parentheses: spawn work(1).`,
+			PT: `'spawn' inicia a chamada de uma função em uma nova tarefa, então deve ser
+seguido de uma chamada com parênteses: spawn trabalho(1).`,
+		},
+		Wrong: `void function work() {}
+void function run() {
+    spawn work
+}`,
+		Right: `void function work() {}
+void function run() {
+    spawn work()
+}`,
+	},
+	ExpectedSelectCase: {
+		Text: Message{
+			EN: `Each 'case' of a select waits for a channel operation, which must follow
+the keyword: a send (ch <- v) or a receive (<-ch, var v = <-ch).`,
+			PT: `Cada 'case' de um select espera uma operação de canal, que deve vir após
+a palavra-chave: um envio (ch <- v) ou um recebimento (<-ch, var v = <-ch).`,
+		},
+		Wrong: `void function run(channel<int> ch) {
+    select {
+    case :
+        ch <- 1
+    }
+}`,
+		Right: `void function run(channel<int> ch) {
+    select {
+    case ch <- 1:
+        close(ch)
+    }
+}`,
+	},
+	InvalidSelectCase: {
+		Text: Message{
+			EN: `select chooses between channel operations, so each case must send to or
+receive from a channel. Other statements go inside the body of the case.`,
+			PT: `select escolhe entre operações de canal, então cada caso deve enviar ou
+receber de um canal. Outras instruções vão dentro do corpo do caso.`,
+		},
+		Wrong: `void function run(channel<int> ch) {
+    int y = 0
+    select {
+    case y = 1:
+        y = 2
+    }
+}`,
+		Right: `void function run(channel<int> ch) {
+    int y = 0
+    select {
+    case y = <-ch:
+        y = 2
+    }
+}`,
+	},
+	ExpectedReturnExpr: {
+		Text: Message{
+			EN: `When a return statement lists several values, each comma must be
+followed by another value.`,
+			PT: `Quando um return lista vários valores, cada vírgula deve ser seguida de
+outro valor.`,
+		},
+		Wrong: `int, int function pair() {
+    return 1,
+}`,
+		Right: `int, int function pair() {
+    return 1, 2
+}`,
+	},
+
+	// Expressões
+	IntOverflow: {
+		Text: Message{
+			EN: `Integer literals must fit in an int, a 64-bit signed integer that goes up
+to 9223372036854775807. Use a float for larger magnitudes.`,
+			PT: `Literais inteiros devem caber em um int, um inteiro de 64 bits com sinal
+que vai até 9223372036854775807. Use um float para valores maiores.`,
+		},
+		Wrong: `int big = 9223372036854775808`,
+		Right: `float big = 9223372036854775808.0`,
+	},
+	InvalidInt: {
+		Text: Message{
+			EN: `The text of the integer literal could not be converted to a number. Check
+the base prefix (0x, 0o, 0b) and the digits that follow it.
+
+No program triggers this error today: the lexer already rejects malformed
+digits, prefixes and underscores (A0009, A0011, A0012), so the only failure
+left for the parser is an overflow (A1401).`,
+			PT: `O texto do literal inteiro não pôde ser convertido em número. Verifique o
+prefixo da base (0x, 0o, 0b) e os dígitos que vêm depois dele.
+
+Nenhum programa provoca este erro hoje: o lexer já rejeita dígitos, prefixos
+e sublinhados inválidos (A0009, A0011, A0012), e a única falha que sobra para
+o parser é o overflow (A1401).`,
+		},
+		Unreachable: true,
+	},
+	FloatOverflow: {
+		Text: Message{
+			EN: `The float literal is larger than the largest value a float can hold
+(about 1.8e308).`,
+			PT: `O literal float é maior que o maior valor que um float pode guardar
+(cerca de 1.8e308).`,
+		},
+		Wrong: `float huge = 1e400`,
+		Right: `float huge = 1e300`,
+	},
+	InvalidFloat: {
+		Text: Message{
+			EN: `The text of the float literal could not be converted to a number. Check
+the decimal point and the exponent.
+
+No program triggers this error today: the lexer already rejects malformed
+exponents and underscores (A0010, A0011), so the only failure left for the
+parser is an overflow (A1403).`,
+			PT: `O texto do literal float não pôde ser convertido em número. Verifique o
+ponto decimal e o expoente.
+
+Nenhum programa provoca este erro hoje: o lexer já rejeita expoentes e
+sublinhados inválidos (A0010, A0011), e a única falha que sobra para o parser
+é o overflow (A1403).`,
+		},
+		Unreachable: true,
+	},
+	EmptyInterpolation: {
+		Text: Message{
+			EN: `"${}" inserts the value of an expression into the string, but there is
+no expression between the braces.`,
+			PT: `"${}" insere o valor de uma expressão na string, mas não há nenhuma
+expressão entre as chaves.`,
+		},
+		Wrong: `int total = 3
+string msg = "total: ${}"`,
+		Right: `int total = 3
+string msg = "total: ${total}"`,
+	},
+	InvalidInterpolation: {
+		Text: Message{
+			EN: `The text between "${" and "}" is not a valid expression.`,
+			PT: `O texto entre "${" e "}" não é uma expressão válida.`,
+		},
+		Wrong: `string msg = "valor: ${+}"`,
+		Right: `int value = 1
+string msg = "valor: ${+value}"`,
+	},
+	UnexpectedInInterpolation: {
+		Text: Message{
+			EN: `An interpolation holds a single expression. Something follows that
+expression before the closing '}', often a missing operator.`,
+			PT: `Uma interpolação contém uma única expressão. Algo vem depois dessa
+expressão antes do '}' final, geralmente um operador que falta.`,
+		},
+		Wrong: `int a = 1
+int b = 2
+string msg = "soma: ${a b}"`,
+		Right: `int a = 1
+int b = 2
+string msg = "soma: ${a + b}"`,
+	},
+	ExpectedTernaryTrue: {
+		Text: Message{
+			EN: `A conditional expression is written as condition ? valueIfTrue :
+valueIfFalse. The value after '?' is missing.`,
+			PT: `Uma expressão condicional é escrita como condição ? valorSeVerdadeiro :
+valorSeFalso. Falta o valor após o '?'.`,
+		},
+		Wrong: `int a = 1
+int b = a > 0 ? : 2`,
+		Right: `int a = 1
+int b = a > 0 ? 1 : 2`,
+	},
+	ExpectedTernaryColon: {
+		Text: Message{
+			EN: `The two values of a conditional expression are separated by ':'.`,
+			PT: `Os dois valores de uma expressão condicional são separados por ':'.`,
+		},
+		Wrong: `int a = 1
+int b = a > 0 ? 1 2`,
+		Right: `int a = 1
+int b = a > 0 ? 1 : 2`,
+	},
+	ExpectedTernaryFalse: {
+		Text: Message{
+			EN: `A conditional expression always has two values: the one used when the
+condition is false is missing after ':'.`,
+			PT: `Uma expressão condicional sempre tem dois valores: falta, após o ':', o
+valor usado quando a condição é falsa.`,
+		},
+		Wrong: `int a = 1
+int b = a > 0 ? 1 :`,
+		Right: `int a = 1
+int b = a > 0 ? 1 : 2`,
+	},
+	ExpectedMemberName: {
+		Text: Message{
+			EN: `A '.' accesses a field or method, so it must be followed by its name.`,
+			PT: `Um '.' acessa um campo ou método, então deve ser seguido do nome dele.`,
+		},
+		Wrong: `struct Point {
+    int x
+}
+Point pt = Point { x: 1 }
+int v = pt.`,
+		Right: `struct Point {
+    int x
+}
+Point pt = Point { x: 1 }
+int v = pt.x`,
+	},
+	ExpectedStructLitField: {
+		Text: Message{
+			EN: `Values of a struct literal are given by field name: Point { x: 1, y: 2 }.
+Positional values are not allowed; use the init constructor for that.`,
+			PT: `Os valores de um literal de struct são dados pelo nome do campo:
+Point { x: 1, y: 2 }. Valores posicionais não são permitidos; use o
+construtor init para isso.`,
+		},
+		Wrong: `struct Point {
+    int x
+    int y
+}
+Point pt = Point { x: 1, 2 }`,
+		Right: `struct Point {
+    int x
+    int y
+}
+Point pt = Point { x: 1, y: 2 }`,
+	},
+	ExpectedLiteralBody: {
+		Text: Message{
+			EN: `Sets and maps written with an explicit type use braces for their items:
+set<int> {1, 2} and map<string, int> {"a": 1}.`,
+			PT: `Sets e maps escritos com tipo explícito usam chaves para os itens:
+set<int> {1, 2} e map<string, int> {"a": 1}.`,
+		},
+		Wrong: `var s = set<int> [1, 2]`,
+		Right: `var s = set<int> {1, 2}`,
+	},
+	ExpectedChannelAfterRecv: {
+		Text: Message{
+			EN: `'<-' receives a value from a channel, so it must be followed by the
+channel.`,
+			PT: `'<-' recebe um valor de um canal, então deve ser seguido do canal.`,
+		},
+		Wrong: `channel<int> ch = channel<int>(1)
+var v = <-`,
+		Right: `channel<int> ch = channel<int>(1)
+var v = <-ch`,
+	},
+	ExpectedChannelCap: {
+		Text: Message{
+			EN: `The parentheses of channel<T>(...) hold the buffer capacity. Leave them
+empty for an unbuffered channel.`,
+			PT: `Os parênteses de channel<T>(...) contêm a capacidade do buffer. Deixe-os
+vazios para um canal sem buffer.`,
+		},
+		Wrong: `channel<int> ch = channel<int>(,)`,
+		Right: `channel<int> ch = channel<int>(10)`,
+	},
+	ExpectedArraySep: {
+		Text: Message{
+			EN: `The items of an array literal are separated by commas and the list ends
+with ']'.`,
+			PT: `Os itens de um literal de array são separados por vírgulas e a lista
+termina com ']'.`,
+		},
+		Wrong: `int[] xs = [1, 2 3]`,
+		Right: `int[] xs = [1, 2, 3]`,
+	},
+	UnterminatedSetLit: {
+		Text: Message{
+			EN: `The file ended inside a set literal; the closing '}' is missing.`,
+			PT: `O arquivo terminou dentro de um literal de set; falta o '}' final.`,
+		},
+		Wrong: `var s = set<int> {1, 2`,
+		Right: `var s = set<int> {1, 2}`,
+	},
+	ExpectedSetSep: {
+		Text: Message{
+			EN: `The items of a set literal are separated by commas and the list ends with
+'}'.`,
+			PT: `Os itens de um literal de set são separados por vírgulas e a lista
+termina com '}'.`,
+		},
+		Wrong: `var s = set<int> {1, 2 3}`,
+		Right: `var s = set<int> {1, 2, 3}`,
+	},
+	UnterminatedMapLit: {
+		Text: Message{
+			EN: `The file ended inside a map literal; the closing '}' is missing.`,
+			PT: `O arquivo terminou dentro de um literal de map; falta o '}' final.`,
+		},
+		Wrong: `var m = map<string, int> {"a": 1`,
+		Right: `var m = map<string, int> {"a": 1}`,
+	},
+	ExpectedMapSep: {
+		Text: Message{
+			EN: `The entries of a map literal (key: value) are separated by commas and the
+list ends with '}'.`,
+			PT: `As entradas de um literal de map (chave: valor) são separadas por
+vírgulas e a lista termina com '}'.`,
+		},
+		Wrong: `var m = map<string, int> {"a": 1 "b": 2}`,
+		Right: `var m = map<string, int> {"a": 1, "b": 2}`,
+	},
+	ExpectedAfterTypeArgs: {
+		Text: Message{
+			EN: `Explicit type arguments (generic<int>) must be followed by the generic
+function or struct they apply to, or by an array literal.`,
+			PT: `Argumentos de tipo explícitos (generic<int>) devem ser seguidos da função
+ou struct genérica a que se aplicam, ou de um literal de array.`,
+		},
+		Wrong: `var x = generic<int> 5`,
+		Right: `generic<T> T function same(T value) {
+    return value
+}
+var x = generic<int> same(5)`,
+	},
+	ExpectedCastExpr: {
+		Text: Message{
+			EN: `A conversion such as float(x) or int[](xs) needs the value to convert
+between the parentheses.`,
+			PT: `Uma conversão como float(x) ou int[](xs) precisa do valor a converter
+entre os parênteses.`,
+		},
+		Wrong: `float f = float()`,
+		Right: `float f = float(1)`,
+	},
+	ExpectedArrayCastType: {
+		Text: Message{
+			EN: `An array conversion names the target array type before the parentheses:
+int[](xs) for a dynamic array or int[3](xs) for a fixed-size one.`,
+			PT: `Uma conversão de array indica o tipo de array de destino antes dos
+parênteses: int[](xs) para um array dinâmico ou int[3](xs) para um de
+tamanho fixo.`,
+		},
+		Wrong: `int[3] xs = [1, 2, 3]
+int[] ys = int[(xs)`,
+		Right: `int[3] xs = [1, 2, 3]
+int[] ys = int[](xs)`,
+	},
+
+	// Tipos e genéricos
+	ExpectedGenericOpen: {
+		Text: Message{
+			EN: `'generic' introduces type parameters, which are listed between angle
+brackets: generic<T>.`,
+			PT: `'generic' introduz parâmetros de tipo, que são listados entre sinais de
+menor e maior: generic<T>.`,
+		},
+		Wrong: `generic T string function show(T value) {
+    return "ok"
+}`,
+		Right: `generic<T> string function show(T value) {
+    return "ok"
+}`,
+	},
+	ExpectedGenericParam: {
+		Text: Message{
+			EN: `Type parameters are single uppercase letters, such as T, K or V.`,
+			PT: `Parâmetros de tipo são letras maiúsculas isoladas, como T, K ou V.`,
+		},
+		Wrong: `generic<1> string function show(int value) {
+    return "ok"
+}`,
+		Right: `generic<T> string function show(T value) {
+    return "ok"
+}`,
+	},
+	ExpectedGenericParamAfter: {
+		Text: Message{
+			EN: `Each comma in a list of type parameters must be followed by another
+parameter name (a single uppercase letter).`,
+			PT: `Cada vírgula de uma lista de parâmetros de tipo deve ser seguida de outro
+nome de parâmetro (uma letra maiúscula isolada).`,
+		},
+		Wrong: `generic<K, 1> string function show(K key) {
+    return "ok"
+}`,
+		Right: `generic<K, V> string function show(K key, V value) {
+    return "ok"
+}`,
+	},
+	ExpectedTypeArg: {
+		Text: Message{
+			EN: `The angle brackets of generic<...> must contain the types to use for the
+type parameters.`,
+			PT: `Os sinais de menor e maior de generic<...> devem conter os tipos usados
+nos parâmetros de tipo.`,
+		},
+		Wrong: `generic<T> T function same(T value) {
+    return value
+}
+var x = generic<> same(1)`,
+		Right: `generic<T> T function same(T value) {
+    return value
+}
+var x = generic<int> same(1)`,
+	},
+	ExpectedTypeArgAfterComma: {
+		Text: Message{
+			EN: `Each comma in a list of type arguments must be followed by another type.`,
+			PT: `Cada vírgula de uma lista de argumentos de tipo deve ser seguida de outro
+tipo.`,
+		},
+		Wrong: `generic<K, V> K function first(K key, V value) {
+    return key
+}
+var x = generic<int, > first(1, 2)`,
+		Right: `generic<K, V> K function first(K key, V value) {
+    return key
+}
+var x = generic<int, int> first(1, 2)`,
+	},
+	ExpectedReturnListType: {
+		Text: Message{
+			EN: `A function that returns several values lists their types separated by
+commas before 'function'. Each comma must be followed by another type.`,
+			PT: `Uma função que retorna vários valores lista os tipos deles separados por
+vírgulas antes de 'function'. Cada vírgula deve ser seguida de outro tipo.`,
+		},
+		Wrong: `int, function pair() {
+    return 1, 2
+}`,
+		Right: `int, int function pair() {
+    return 1, 2
+}`,
+	},
+}
@@ -0,0 +1,1810 @@
+package diag
+
+// ============================
+// EXPLICAÇÕES DA ANÁLISE SEMÂNTICA (A01xx-A07xx)
+// ============================
+
+var semanticExplanations = map[Code]Explanation{
+	// Declarações e escopos (A01xx)
+	UndeclaredIdentifier: {
+		Text: Message{
+			EN: `A name is used before being declared, or outside the block where it was
+declared. Variables exist from their declaration to the end of the enclosing
+block. Check the spelling: names are case-sensitive.`,
+			PT: `Um nome é usado antes de ser declarado, ou fora do bloco onde foi
+declarado. Variáveis existem da declaração até o fim do bloco que as
+contém. Confira a grafia: maiúsculas e minúsculas são diferentes.`,
+		},
+		Wrong: `int total = count + 1`,
+		Right: `int count = 0
+int total = count + 1`,
+	},
+	UndeclaredFunction: {
+		Text: Message{
+			EN: `The called function is not declared in this package nor imported. Check
+the spelling of the name, declare the function or import it from its module.`,
+			PT: `A função chamada não foi declarada neste pacote nem importada. Confira a
+grafia do nome, declare a função ou importe-a do módulo dela.`,
+		},
+		Wrong: `int x = twice(4)`,
+		Right: `int function twice(int n) {
+    return n * 2
+}
+int x = twice(4)`,
+	},
+	NotAFunction: {
+		Text: Message{
+			EN: `Parentheses after a name call it as a function, but this name refers to a
+variable or constant. A local variable may be hiding a function with the
+same name.`,
+			PT: `Parênteses após um nome chamam-no como função, mas este nome se refere a
+uma variável ou constante. Uma variável local pode estar escondendo uma
+função de mesmo nome.`,
+		},
+		Wrong: `int size = 3
+int x = size(2)`,
+		Right: `int size = 3
+int x = size * 2`,
+	},
+	VarRedeclared: {
+		Text: Message{
+			EN: `Each name can be declared only once in the same block. To change the
+value of an existing variable, assign to it without repeating the type.`,
+			PT: `Cada nome só pode ser declarado uma vez no mesmo bloco. Para mudar o
+valor de uma variável existente, atribua sem repetir o tipo.`,
+		},
+		Wrong: `int count = 1
+int count = 2`,
+		Right: `int count = 1
+count = 2`,
+	},
+	ConstRedeclared: {
+		Text: Message{
+			EN: `A constant with this name already exists in the same block. Constants
+cannot be redefined; choose a different name.`,
+			PT: `Já existe uma constante com este nome no mesmo bloco. Constantes não
+podem ser redefinidas; escolha outro nome.`,
+		},
+		Wrong: `const MAX = 10
+const MAX = 20`,
+		Right: `const MAX = 10
+const LIMIT = 20`,
+	},
+	ConstAlreadyDeclared: {
+		Text: Message{
+			EN: `One of the names in a multiple constant declaration is already declared
+in the same block.`,
+			PT: `Um dos nomes de uma declaração múltipla de constantes já foi declarado no
+mesmo bloco.`,
+		},
+		Wrong: `const LO, HI = 0
+const LO, MID = 1`,
+		Right: `const LO, HI = 0
+const MID, TOP = 1`,
+	},
+	FuncRedeclared: {
+		Text: Message{
+			EN: `Two functions with the same name were declared. Alpha has no overloading:
+give each function its own name, or use default parameters to accept
+different numbers of arguments.`,
+			PT: `Duas funções com o mesmo nome foram declaradas. Alpha não tem
+sobrecarga: dê a cada função seu próprio nome, ou use parâmetros com valor
+padrão para aceitar quantidades diferentes de argumentos.`,
+		},
+		Wrong: `int function area(int side) {
+    return side * side
+}
+int function area(int w, int h) {
+    return w * h
+}`,
+		Right: `int function area(int w, int h = -1) {
+    return h < 0 ? w * w : w * h
+}`,
+	},
+	StructRedeclared: {
+		Text: Message{
+			EN: `A struct with this name is already defined. Add the missing fields to
+the existing struct or rename one of them.`,
+			PT: `Já existe uma struct com este nome. Adicione os campos que faltam à
+struct existente ou renomeie uma delas.`,
+		},
+		Wrong: `struct Point {
+    int x
+}
+struct Point {
+    int y
+}`,
+		Right: `struct Point {
+    int x
+    int y
+}`,
+	},
+	TypeRedeclared: {
+		Text: Message{
+			EN: `A type alias with this name is already defined. Each type name must be
+unique in the package.`,
+			PT: `Já existe um tipo com este nome. Cada nome de tipo deve ser único no
+pacote.`,
+		},
+		Wrong: `type Value int | string
+type Value int | float`,
+		Right: `type Value int | string
+type Number int | float`,
+	},
+	UnknownType: {
+		Text: Message{
+			EN: `The type used in the declaration does not exist. It may be misspelled or
+be a struct or alias that was never declared.`,
+			PT: `O tipo usado na declaração não existe. Ele pode estar escrito errado ou
+ser uma struct ou alias que nunca foi declarado.`,
+		},
+		Wrong: `Celsius temp = 1`,
+		Right: `type Celsius int | float
+Celsius temp = 1`,
+	},
+	ImportRedeclared: {
+		Text: Message{
+			EN: `The same name was imported twice. Remove the repeated import or give one
+of them another name with 'as'.`,
+			PT: `O mesmo nome foi importado duas vezes. Remova o import repetido ou dê a
+um deles outro nome com 'as'.`,
+		},
+		Wrong: `import sqrt from math
+import sqrt from math`,
+		Right: `import sqrt from math`,
+	},
+	ModuleRedeclared: {
+		Text: Message{
+			EN: `The same module was imported twice. One import is enough for the whole
+file.`,
+			PT: `O mesmo módulo foi importado duas vezes. Um único import vale para o
+arquivo inteiro.`,
+		},
+		Wrong: `import math
+import math`,
+		Right: `import math`,
+	},
+	ExportUndeclared: {
+		Text: Message{
+			EN: `'export' can only publish names declared in this package. Check the
+spelling or declare the symbol before exporting it.`,
+			PT: `'export' só pode publicar nomes declarados neste pacote. Confira a grafia
+ou declare o símbolo antes de exportá-lo.`,
+		},
+		Wrong: `int function area(int side) {
+    return side * side
+}
+export aera`,
+		Right: `int function area(int side) {
+    return side * side
+}
+export area`,
+	},
+	MultiVarInit: {
+		Text: Message{
+			EN: `A declaration of several variables must be initialized by a function
+that returns that many values.
+
+No program triggers this error today: the parser already requires the '='
+(A1104), so the analyzer never sees the declaration without it.`,
+			PT: `Uma declaração de várias variáveis deve ser inicializada por uma função
+que retorne essa quantidade de valores.
+
+Nenhum programa provoca este erro hoje: o parser já exige o '=' (A1104), e o
+analisador nunca recebe a declaração sem ele.`,
+		},
+		Unreachable: true,
+	},
+	InvalidMultiVarInit: {
+		Text: Message{
+			EN: `The initializer of a declaration of several variables could not be
+analyzed. Fix the errors in the expression first.
+
+No program triggers this error today: an expression with errors reports them
+itself and still gets a type, so the analyzer always has one to check.`,
+			PT: `O inicializador de uma declaração de várias variáveis não pôde ser
+analisado. Corrija primeiro os erros da expressão.
+
+Nenhum programa provoca este erro hoje: uma expressão com erros os reporta ela
+mesma e ainda recebe um tipo, então o analisador sempre tem um para verificar.`,
+		},
+		Unreachable: true,
+	},
+	VarCountMismatch: {
+		Text: Message{
+			EN: `The number of declared variables differs from the number of values
+returned by the initializer. Declare one variable per returned value.`,
+			PT: `A quantidade de variáveis declaradas é diferente da quantidade de
+valores retornados pelo inicializador. Declare uma variável para cada valor
+retornado.`,
+		},
+		Wrong: `int, int function pair() {
+    return 1, 2
+}
+var a, b, c = pair()`,
+		Right: `int, int function pair() {
+    return 1, 2
+}
+var a, b = pair()`,
+	},
+	MultiConstInit: {
+		Text: Message{
+			EN: `A declaration of several constants must give them a value.
+
+No program triggers this error today: the parser already requires the '='
+(A1107), so the analyzer never sees the declaration without it.`,
+			PT: `Uma declaração de várias constantes deve dar um valor a elas.
+
+Nenhum programa provoca este erro hoje: o parser já exige o '=' (A1107), e o
+analisador nunca recebe a declaração sem ele.`,
+		},
+		Unreachable: true,
+	},
+	InvalidMultiConstInit: {
+		Text: Message{
+			EN: `The initializer of a declaration of several constants could not be
+analyzed. Fix the errors in the expression first.
+
+No program triggers this error today: an expression with errors reports them
+itself and still gets a type, so the analyzer always has one to check.`,
+			PT: `O inicializador de uma declaração de várias constantes não pôde ser
+analisado. Corrija primeiro os erros da expressão.
+
+Nenhum programa provoca este erro hoje: uma expressão com erros os reporta ela
+mesma e ainda recebe um tipo, então o analisador sempre tem um para verificar.`,
+		},
+		Unreachable: true,
+	},
+	ConstCountMismatch: {
+		Text: Message{
+			EN: `The number of declared constants differs from the number of values of
+the initializer.`,
+			PT: `A quantidade de constantes declaradas é diferente da quantidade de
+valores do inicializador.`,
+		},
+		Wrong: `int, int function pair() {
+    return 1, 2
+}
+const LO, HI, MID = pair()`,
+		Right: `const LO, HI = 0`,
+	},
+	UnhandledExpr: {
+		Text: Message{
+			EN: `The analyzer does not know how to check this kind of expression. This is
+a limitation of the compiler rather than a mistake in the program; please
+report it with the code that triggered it.
+
+No program triggers this error today: the analyzer handles every kind of
+expression the parser produces.`,
+			PT: `O analisador não sabe verificar este tipo de expressão. Isso é uma
+limitação do compilador, e não um erro do programa; por favor, reporte-o
+junto com o código que o provocou.
+
+Nenhum programa provoca este erro hoje: o analisador trata todo tipo de
+expressão que o parser produz.`,
+		},
+		Unreachable: true,
+	},
+
+	// Tipos, operadores e arrays (A02xx)
+	AssignMismatch: {
+		Text: Message{
+			EN: `The value has a different type from the variable. Alpha does not convert
+between types on its own: use an explicit conversion such as int(x) or
+string(x), or change the type of the variable.`,
+			PT: `O valor tem um tipo diferente do da variável. Alpha não converte entre
+tipos sozinho: use uma conversão explícita como int(x) ou string(x), ou
+mude o tipo da variável.`,
+		},
+		Wrong: `int age = "30"`,
+		Right: `int age = 30`,
+	},
+	ConcatNonString: {
+		Text: Message{
+			EN: `'+' joins a string only with another string. Convert the other value with
+string(...) or use interpolation: "total: ${n}".`,
+			PT: `'+' junta uma string apenas com outra string. Converta o outro valor com
+string(...) ou use interpolação: "total: ${n}".`,
+		},
+		Wrong: `int[] xs = [1, 2]
+string s = "itens: " + xs`,
+		Right: `int[] xs = [1, 2]
+string s = "itens: " + string(length(xs))`,
+	},
+	PlusUnsupported: {
+		Text: Message{
+			EN: `'+' adds numbers and joins strings; it is not defined for these types. For
+booleans use the logical operators && and ||.`,
+			PT: `'+' soma números e junta strings; ele não é definido para estes tipos.
+Para booleanos use os operadores lógicos && e ||.`,
+		},
+		Wrong: `bool a = true
+bool b = a + a`,
+		Right: `bool a = true
+bool b = a || a`,
+	},
+	MismatchedTypes: {
+		Text: Message{
+			EN: `The two sides of the operator have different types. Alpha does not mix
+char, byte and int implicitly; convert one side so both have the same type.`,
+			PT: `Os dois lados do operador têm tipos diferentes. Alpha não mistura char,
+byte e int implicitamente; converta um dos lados para que ambos tenham o
+mesmo tipo.`,
+		},
+		Wrong: `char c = 'a'
+int offset = 2
+int code = c + offset`,
+		Right: `char c = 'a'
+int offset = 2
+int code = int(c) + offset`,
+	},
+	CannotConvert: {
+		Text: Message{
+			EN: `There is no conversion between these two types. Numbers convert to each
+other and to strings, and arrays convert only between sizes of the same
+element type.`,
+			PT: `Não existe conversão entre estes dois tipos. Números convertem entre si e
+para strings, e arrays convertem apenas entre tamanhos com o mesmo tipo de
+elemento.`,
+		},
+		Wrong: `string s = "a"
+char c = char(s)`,
+		Right: `string s = "a"
+char c = s[0]`,
+	},
+	ConvertSizeMismatch: {
+		Text: Message{
+			EN: `Converting between fixed-size arrays requires both to have the same size.
+Convert to a dynamic array (T[]) to change the number of elements.`,
+			PT: `Converter entre arrays de tamanho fixo exige que ambos tenham o mesmo
+tamanho. Converta para um array dinâmico (T[]) para mudar a quantidade de
+elementos.`,
+		},
+		Wrong: `int[3] xs = [1, 2, 3]
+int[2] ys = int[2](xs)`,
+		Right: `int[3] xs = [1, 2, 3]
+int[] ys = int[](xs)`,
+	},
+	IntegerOperands: {
+		Text: Message{
+			EN: `Bitwise and shift operators (&, |, ^, <<, >>) work on the bits of integer
+values (int, byte, char). Convert floats to int first.`,
+			PT: `Operadores bit a bit e de deslocamento (&, |, ^, <<, >>) trabalham sobre
+os bits de valores inteiros (int, byte, char). Converta floats para int
+antes.`,
+		},
+		Wrong: `float a = 1.5
+int b = a & 1`,
+		Right: `float a = 1.5
+int b = int(a) & 1`,
+	},
+	IntegerOperand: {
+		Text: Message{
+			EN: `'~' inverts the bits of an integer value; it is not defined for other
+types.`,
+			PT: `'~' inverte os bits de um valor inteiro; ele não é definido para outros
+tipos.`,
+		},
+		Wrong: `float a = 1.5
+float b = ~a`,
+		Right: `int a = 1
+int b = ~a`,
+	},
+	NegativeShift: {
+		Text: Message{
+			EN: `The number of positions of a shift cannot be negative. To shift in the
+other direction use the opposite operator.`,
+			PT: `A quantidade de posições de um deslocamento não pode ser negativa. Para
+deslocar no outro sentido use o operador oposto.`,
+		},
+		Wrong: `int a = 8
+int b = a << -1`,
+		Right: `int a = 8
+int b = a >> 1`,
+	},
+	CannotInterpolate: {
+		Text: Message{
+			EN: `Only simple values (strings, numbers, bools and characters) can be
+inserted into a string with "${...}". Insert an element or a property of
+the value instead.`,
+			PT: `Apenas valores simples (strings, números, bools e caracteres) podem ser
+inseridos em uma string com "${...}". Insira um elemento ou uma
+propriedade do valor.`,
+		},
+		Wrong: `int[] xs = [1, 2]
+string s = "xs = ${xs}"`,
+		Right: `int[] xs = [1, 2]
+string s = "primeiro = ${xs[0]}"`,
+	},
+	ArrayIndexType: {
+		Text: Message{
+			EN: `Array positions are integers, starting at 0.`,
+			PT: `Posições de array são inteiros, começando em 0.`,
+		},
+		Wrong: `int[] xs = [1, 2]
+int v = xs["0"]`,
+		Right: `int[] xs = [1, 2]
+int v = xs[0]`,
+	},
+	StringIndexType: {
+		Text: Message{
+			EN: `Indexing a string returns the character at a position, which must be an
+integer starting at 0.`,
+			PT: `Indexar uma string retorna o caractere de uma posição, que deve ser um
+inteiro a partir de 0.`,
+		},
+		Wrong: `string s = "abc"
+char c = s[1.5]`,
+		Right: `string s = "abc"
+char c = s[1]`,
+	},
+	MapKeyMismatch: {
+		Text: Message{
+			EN: `The key used to read a map has a different type from the keys of the map.`,
+			PT: `A chave usada para ler um map tem um tipo diferente do das chaves do map.`,
+		},
+		Wrong: `int function get(map<string, int> ages) {
+    return ages[1]
+}`,
+		Right: `int function get(map<string, int> ages) {
+    return ages["ana"]
+}`,
+	},
+	IndexSet: {
+		Text: Message{
+			EN: `Sets have no order, so their items have no positions. Use has() to test
+whether an item belongs to the set.`,
+			PT: `Sets não têm ordem, então seus itens não têm posições. Use has() para
+testar se um item pertence ao set.`,
+		},
+		Wrong: `var s = set<int> {1, 2}
+var first = s[0]`,
+		Right: `var s = set<int> {1, 2}
+var found = has(s, 1)`,
+	},
+	CannotIndex: {
+		Text: Message{
+			EN: `Only arrays, strings and maps can be indexed with [...].`,
+			PT: `Apenas arrays, strings e maps podem ser indexados com [...].`,
+		},
+		Wrong: `int x = 5
+int y = x[0]`,
+		Right: `int[] x = [5]
+int y = x[0]`,
+	},
+	CannotSpread: {
+		Text: Message{
+			EN: `'...' spreads the items of an array into an array literal or a variadic
+call. Other values have no items to spread.`,
+			PT: `'...' espalha os itens de um array em um literal de array ou em uma
+chamada variádica. Outros valores não têm itens para espalhar.`,
+		},
+		Wrong: `int x = 5
+int[] xs = [...x]`,
+		Right: `int x = 5
+int[] xs = [x]`,
+	},
+	InconsistentArrayElems: {
+		Text: Message{
+			EN: `All elements of an array have the same type, given by the first element.
+To mix types declare the array with a union or any element type.`,
+			PT: `Todos os elementos de um array têm o mesmo tipo, dado pelo primeiro
+elemento. Para misturar tipos declare o array com um tipo de elemento união
+ou any.`,
+		},
+		Wrong: `var xs = [1, "a"]`,
+		Right: `var xs = ["1", "a"]`,
+	},
+	ArrayLiteralSize: {
+		Text: Message{
+			EN: `A fixed-size array must be initialized with exactly as many elements as
+its size. Adjust the size or use a dynamic array (T[]).`,
+			PT: `Um array de tamanho fixo deve ser inicializado com exatamente tantos
+elementos quanto o seu tamanho. Ajuste o tamanho ou use um array dinâmico
+(T[]).`,
+		},
+		Wrong: `int[2] xs = [1, 2, 3]`,
+		Right: `int[3] xs = [1, 2, 3]`,
+	},
+	IndexOutOfBounds: {
+		Text: Message{
+			EN: `The constant index is outside the array. An array of size N has positions
+0 to N-1.`,
+			PT: `O índice constante está fora do array. Um array de tamanho N tem as
+posições de 0 a N-1.`,
+		},
+		Wrong: `int[3] xs = [1, 2, 3]
+int last = xs[3]`,
+		Right: `int[3] xs = [1, 2, 3]
+int last = xs[2]`,
+	},
+	FixedArrayResize: {
+		Text: Message{
+			EN: `Fixed-size arrays cannot grow or shrink. Convert the array to a dynamic
+array before using append or remove.`,
+			PT: `Arrays de tamanho fixo não podem crescer nem diminuir. Converta o array
+para um array dinâmico antes de usar append ou remove.`,
+		},
+		Wrong: `int[3] xs = [1, 2, 3]
+int[] ys = append(xs, 4)`,
+		Right: `int[3] xs = [1, 2, 3]
+int[] ys = append(int[](xs), 4)`,
+	},
+	ArraySizeNotConst: {
+		Text: Message{
+			EN: `The size of a fixed array must be known at compile time: a literal or a
+constant. For a size known only while running use a dynamic array.`,
+			PT: `O tamanho de um array fixo deve ser conhecido em tempo de compilação: um
+literal ou uma constante. Para um tamanho conhecido apenas na execução use
+um array dinâmico.`,
+		},
+		Wrong: `int n = 3
+int[n] xs`,
+		Right: `const SIZE = 3
+int[SIZE] xs`,
+	},
+	ArraySizeNegative: {
+		Text: Message{
+			EN: `An array cannot have a negative number of elements.`,
+			PT: `Um array não pode ter uma quantidade negativa de elementos.`,
+		},
+		Wrong: `int[-1] xs`,
+		Right: `int[1] xs`,
+	},
+
+	// Funções e chamadas (A03xx)
+	ReturnOutsideFunc: {
+		Text: Message{
+			EN: `'return' ends a function and gives its result, so it can only appear
+inside the body of a function or method.`,
+			PT: `'return' encerra uma função e entrega o resultado dela, então só pode
+aparecer dentro do corpo de uma função ou método.`,
+		},
+		Wrong: `int x = 1
+return x`,
+		Right: `int function one() {
+    int x = 1
+    return x
+}`,
+	},
+	MissingReturnValue: {
+		Text: Message{
+			EN: `The function declares a return type, so every 'return' must give a value
+of that type. Use void as the return type for functions without a result.`,
+			PT: `A função declara um tipo de retorno, então todo 'return' deve entregar um
+valor desse tipo. Use void como tipo de retorno em funções sem resultado.`,
+		},
+		Wrong: `int function answer() {
+    return
+}`,
+		Right: `int function answer() {
+    return 42
+}`,
+	},
+	ReturnCountMismatch: {
+		Text: Message{
+			EN: `The 'return' gives a different number of values from the list of return
+types of the function.`,
+			PT: `O 'return' entrega uma quantidade de valores diferente da lista de tipos
+de retorno da função.`,
+		},
+		Wrong: `int, int function pair() {
+    return 1
+}`,
+		Right: `int, int function pair() {
+    return 1, 2
+}`,
+	},
+	ReturnValueMismatch: {
+		Text: Message{
+			EN: `One of the values returned does not match the type declared at the same
+position of the return list.`,
+			PT: `Um dos valores retornados não corresponde ao tipo declarado na mesma
+posição da lista de retorno.`,
+		},
+		Wrong: `int, string function pair() {
+    return 1, 2
+}`,
+		Right: `int, string function pair() {
+    return 1, "2"
+}`,
+	},
+	ReturnSingleValue: {
+		Text: Message{
+			EN: `The function declares a single return type but the 'return' gives several
+values. List all return types before 'function' to return more than one.`,
+			PT: `A função declara um único tipo de retorno, mas o 'return' entrega vários
+valores. Liste todos os tipos de retorno antes de 'function' para retornar
+mais de um.`,
+		},
+		Wrong: `int function pair() {
+    return 1, 2
+}`,
+		Right: `int, int function pair() {
+    return 1, 2
+}`,
+	},
+	ReturnTypeMismatch: {
+		Text: Message{
+			EN: `The returned value has a different type from the return type of the
+function.`,
+			PT: `O valor retornado tem um tipo diferente do tipo de retorno da função.`,
+		},
+		Wrong: `int function answer() {
+    return "42"
+}`,
+		Right: `int function answer() {
+    return 42
+}`,
+	},
+	PositionalAfterNamed: {
+		Text: Message{
+			EN: `Once a call uses a named argument (name: value), the following arguments
+must also be named, because their positions are no longer clear.`,
+			PT: `Depois que uma chamada usa um argumento nomeado (nome: valor), os
+argumentos seguintes também devem ser nomeados, porque suas posições
+deixam de ser claras.`,
+		},
+		Wrong: `int function sum(int a, int b) {
+    return a + b
+}
+int x = sum(a: 1, 2)`,
+		Right: `int function sum(int a, int b) {
+    return a + b
+}
+int x = sum(a: 1, b: 2)`,
+	},
+	SpreadNonVariadic: {
+		Text: Message{
+			EN: `'...' can only spread an array into a variadic function, one whose last
+parameter is declared as T... name.`,
+			PT: `'...' só pode espalhar um array em uma função variádica, cujo último
+parâmetro é declarado como T... nome.`,
+		},
+		Wrong: `int function sum(int a, int b) {
+    return a + b
+}
+int[] xs = [1, 2]
+int x = sum(...xs)`,
+		Right: `int function sum(int... nums) {
+    return 0
+}
+int[] xs = [1, 2]
+int x = sum(...xs)`,
+	},
+	SpreadFixedParam: {
+		Text: Message{
+			EN: `A spread argument fills only the variadic parameter. The parameters
+before it must receive ordinary arguments.`,
+			PT: `Um argumento espalhado preenche apenas o parâmetro variádico. Os
+parâmetros antes dele devem receber argumentos comuns.`,
+		},
+		Wrong: `int function sum(int first, int... rest) {
+    return first
+}
+int[] xs = [1, 2]
+int x = sum(...xs)`,
+		Right: `int function sum(int first, int... rest) {
+    return first
+}
+int[] xs = [1, 2]
+int x = sum(0, ...xs)`,
+	},
+	ArgCount: {
+		Text: Message{
+			EN: `The call passes a different number of arguments from the number of
+parameters of the function.`,
+			PT: `A chamada passa uma quantidade de argumentos diferente da quantidade de
+parâmetros da função.`,
+		},
+		Wrong: `int function sum(int a, int b) {
+    return a + b
+}
+int x = sum(1)`,
+		Right: `int function sum(int a, int b) {
+    return a + b
+}
+int x = sum(1, 2)`,
+	},
+	ArgCountMax: {
+		Text: Message{
+			EN: `The call passes more arguments than the function accepts, counting the
+parameters with default values.`,
+			PT: `A chamada passa mais argumentos do que a função aceita, contando os
+parâmetros com valor padrão.`,
+		},
+		Wrong: `int function plus(int a, int b = 1) {
+    return a + b
+}
+int x = plus(1, 2, 3)`,
+		Right: `int function plus(int a, int b = 1) {
+    return a + b
+}
+int x = plus(1, 2)`,
+	},
+	ArgCountMin: {
+		Text: Message{
+			EN: `The call passes fewer arguments than the number of required parameters of
+the function. Parameters without default values must always receive an
+argument.`,
+			PT: `A chamada passa menos argumentos do que a quantidade de parâmetros
+obrigatórios da função. Parâmetros sem valor padrão sempre devem receber
+um argumento.`,
+		},
+		Wrong: `int function sum(int a, int b, int... rest) {
+    return a + b
+}
+int x = sum(1)`,
+		Right: `int function sum(int a, int b, int... rest) {
+    return a + b
+}
+int x = sum(1, 2)`,
+	},
+	MissingArgument: {
+		Text: Message{
+			EN: `A parameter without a default value received no argument, neither by
+position nor by name.`,
+			PT: `Um parâmetro sem valor padrão não recebeu nenhum argumento, nem por
+posição nem por nome.`,
+		},
+		Wrong: `int function sum(int a, int b) {
+    return a + b
+}
+int x = sum(b: 1)`,
+		Right: `int function sum(int a, int b) {
+    return a + b
+}
+int x = sum(a: 2, b: 1)`,
+	},
+	UnknownNamedParam: {
+		Text: Message{
+			EN: `A named argument must use the name of one of the parameters of the
+function.`,
+			PT: `Um argumento nomeado deve usar o nome de um dos parâmetros da função.`,
+		},
+		Wrong: `string function greet(string name, bool loud = false) {
+    return name
+}
+string s = greet("Ana", shout: true)`,
+		Right: `string function greet(string name, bool loud = false) {
+    return name
+}
+string s = greet("Ana", loud: true)`,
+	},
+	VariadicByName: {
+		Text: Message{
+			EN: `A variadic parameter receives the remaining positional arguments (or a
+spread array) and cannot be given by name.`,
+			PT: `Um parâmetro variádico recebe os argumentos posicionais restantes (ou um
+array espalhado) e não pode ser passado por nome.`,
+		},
+		Wrong: `int function sum(int... nums) {
+    return 0
+}
+int x = sum(nums: 1)`,
+		Right: `int function sum(int... nums) {
+    return 0
+}
+int x = sum(1)`,
+	},
+	ParamSetTwice: {
+		Text: Message{
+			EN: `The same parameter received a value twice: once by position and once by
+name, or twice by name.`,
+			PT: `O mesmo parâmetro recebeu valor duas vezes: uma por posição e outra por
+nome, ou duas vezes por nome.`,
+		},
+		Wrong: `int function sum(int a, int b) {
+    return a + b
+}
+int x = sum(1, a: 2)`,
+		Right: `int function sum(int a, int b) {
+    return a + b
+}
+int x = sum(1, b: 2)`,
+	},
+	ArgTypeMismatch: {
+		Text: Message{
+			EN: `The argument has a different type from the parameter that receives it.`,
+			PT: `O argumento tem um tipo diferente do parâmetro que o recebe.`,
+		},
+		Wrong: `int function sum(int a, int b) {
+    return a + b
+}
+int x = sum(1, "2")`,
+		Right: `int function sum(int a, int b) {
+    return a + b
+}
+int x = sum(1, 2)`,
+	},
+	DefaultOrder: {
+		Text: Message{
+			EN: `Parameters with default values must come after all required parameters,
+so that positional arguments always fill the required ones first.`,
+			PT: `Parâmetros com valor padrão devem vir depois de todos os parâmetros
+obrigatórios, para que argumentos posicionais sempre preencham primeiro os
+obrigatórios.`,
+		},
+		Wrong: `int function plus(int a = 1, int b) {
+    return a + b
+}`,
+		Right: `int function plus(int b, int a = 1) {
+    return a + b
+}`,
+	},
+	VariadicDefault: {
+		Text: Message{
+			EN: `A variadic parameter is an empty array when no arguments are given, so it
+cannot have a default value.`,
+			PT: `Um parâmetro variádico é um array vazio quando nenhum argumento é
+passado, então ele não pode ter valor padrão.`,
+		},
+		Wrong: `int function sum(int... nums = 1) {
+    return 0
+}`,
+		Right: `int function sum(int... nums) {
+    return 0
+}`,
+	},
+	DefaultNotConst: {
+		Text: Message{
+			EN: `Default values are computed at compile time, so they must be literals,
+constants or expressions made only of them.`,
+			PT: `Valores padrão são calculados em tempo de compilação, então devem ser
+literais, constantes ou expressões feitas apenas deles.`,
+		},
+		Wrong: `int step = 1
+int function next(int n, int by = step) {
+    return n + by
+}`,
+		Right: `const STEP = 1
+int function next(int n, int by = STEP) {
+    return n + by
+}`,
+	},
+	DefaultTypeMismatch: {
+		Text: Message{
+			EN: `The default value has a different type from its parameter.`,
+			PT: `O valor padrão tem um tipo diferente do seu parâmetro.`,
+		},
+		Wrong: `int function next(int n, int by = "1") {
+    return n + by
+}`,
+		Right: `int function next(int n, int by = 1) {
+    return n + by
+}`,
+	},
+
+	// Structs e membros (A04xx)
+	PrivateFieldAccess: {
+		Text: Message{
+			EN: `Private fields can only be used by the methods in the implement block of
+their struct. Expose the information through a public method.`,
+			PT: `Campos privados só podem ser usados pelos métodos do bloco implement da
+sua struct. Exponha a informação por meio de um método público.`,
+		},
+		Wrong: `struct Account {
+    private string password
+}
+implement Account {
+    init(string password) {
+        self.password = password
+    }
+}
+var acc = Account("segredo")
+string p = acc.password`,
+		Right: `struct Account {
+    private string password
+}
+implement Account {
+    init(string password) {
+        self.password = password
+    }
+    bool matches(string attempt) {
+        return self.password == attempt
+    }
+}
+var acc = Account("segredo")
+bool ok = acc.matches("segredo")`,
+	},
+	PrivateMethodCall: {
+		Text: Message{
+			EN: `Private methods are helpers for the other methods of the same implement
+block and cannot be called from outside it.`,
+			PT: `Métodos privados são auxiliares dos outros métodos do mesmo bloco
+implement e não podem ser chamados de fora dele.`,
+		},
+		Wrong: `struct Account {
+    string name
+}
+implement Account {
+    private string secret() {
+        return "x"
+    }
+}
+var acc = Account { name: "a" }
+string s = acc.secret()`,
+		Right: `struct Account {
+    string name
+}
+implement Account {
+    private string secret() {
+        return "x"
+    }
+    string hint() {
+        return self.secret()
+    }
+}
+var acc = Account { name: "a" }
+string s = acc.hint()`,
+	},
+	NoMember: {
+		Text: Message{
+			EN: `The struct has no field or method with this name. Check the spelling and
+the struct declaration.`,
+			PT: `A struct não tem campo nem método com este nome. Confira a grafia e a
+declaração da struct.`,
+		},
+		Wrong: `struct Point {
+    int x
+}
+Point pt = Point { x: 1 }
+int v = pt.y`,
+		Right: `struct Point {
+    int x
+}
+Point pt = Point { x: 1 }
+int v = pt.x`,
+	},
+	NoField: {
+		Text: Message{
+			EN: `A struct literal sets a field that the struct does not declare.`,
+			PT: `Um literal de struct define um campo que a struct não declara.`,
+		},
+		Wrong: `struct Point {
+    int x
+}
+Point pt = Point { x: 1, y: 2 }`,
+		Right: `struct Point {
+    int x
+    int y
+}
+Point pt = Point { x: 1, y: 2 }`,
+	},
+	PrivateFieldInit: {
+		Text: Message{
+			EN: `Private fields cannot be set by a struct literal outside the implement
+block. Give the struct an init constructor and create values by calling it.`,
+			PT: `Campos privados não podem ser definidos por um literal de struct fora do
+bloco implement. Dê à struct um construtor init e crie valores chamando-o.`,
+		},
+		Wrong: `struct Account {
+    private string password
+}
+var acc = Account { password: "x" }`,
+		Right: `struct Account {
+    private string password
+}
+implement Account {
+    init(string password) {
+        self.password = password
+    }
+}
+var acc = Account("x")`,
+	},
+	NoInit: {
+		Text: Message{
+			EN: `Calling a struct like a function (Point(1)) uses its init constructor,
+but this struct has none. Use a struct literal or declare an init in its
+implement block.`,
+			PT: `Chamar uma struct como função (Point(1)) usa o construtor init dela, mas
+esta struct não tem um. Use um literal de struct ou declare um init no
+bloco implement.`,
+		},
+		Wrong: `struct Point {
+    int x
+}
+var pt = Point(1)`,
+		Right: `struct Point {
+    int x
+}
+var pt = Point { x: 1 }`,
+	},
+	SelfOutsideImpl: {
+		Text: Message{
+			EN: `'self' is the value whose method is running, so it only exists inside
+the methods of an implement block.`,
+			PT: `'self' é o valor cujo método está executando, então só existe dentro dos
+métodos de um bloco implement.`,
+		},
+		Wrong: `int function get() {
+    return self.x
+}`,
+		Right: `struct Point {
+    int x
+}
+implement Point {
+    int get() {
+        return self.x
+    }
+}`,
+	},
+	MethodFieldConflict: {
+		Text: Message{
+			EN: `A method cannot have the same name as a field of its struct, because
+pt.name would be ambiguous.`,
+			PT: `Um método não pode ter o mesmo nome de um campo da sua struct, porque
+pt.nome seria ambíguo.`,
+		},
+		Wrong: `struct Point {
+    int x
+}
+implement Point {
+    int x() {
+        return 1
+    }
+}`,
+		Right: `struct Point {
+    int x
+}
+implement Point {
+    int getX() {
+        return self.x
+    }
+}`,
+	},
+	MethodRedeclared: {
+		Text: Message{
+			EN: `Each method name can be defined only once per struct.`,
+			PT: `Cada nome de método só pode ser definido uma vez por struct.`,
+		},
+		Wrong: `struct Point {
+    int x
+}
+implement Point {
+    int get() {
+        return 1
+    }
+    int get() {
+        return 2
+    }
+}`,
+		Right: `struct Point {
+    int x
+}
+implement Point {
+    int get() {
+        return 1
+    }
+}`,
+	},
+	DuplicateField: {
+		Text: Message{
+			EN: `Each field name can appear only once in a struct.`,
+			PT: `Cada nome de campo só pode aparecer uma vez em uma struct.`,
+		},
+		Wrong: `struct Point {
+    int x
+    int x
+}`,
+		Right: `struct Point {
+    int x
+    int y
+}`,
+	},
+	ImplUnknownStruct: {
+		Text: Message{
+			EN: `'implement' adds methods to a struct declared in the same package; no
+struct has this name.`,
+			PT: `'implement' adiciona métodos a uma struct declarada no mesmo pacote;
+nenhuma struct tem este nome.`,
+		},
+		Wrong: `implement Ghost {
+    int get() {
+        return 1
+    }
+}`,
+		Right: `struct Ghost {
+    int x
+}
+implement Ghost {
+    int get() {
+        return 1
+    }
+}`,
+	},
+
+	// Controle de fluxo e switch (A05xx)
+	IfCondition: {
+		Text: Message{
+			EN: `The condition of an 'if' must be a bool, a number (true when not zero) or
+a nullable value (true when present). Other values, such as strings, need
+an explicit comparison.`,
+			PT: `A condição de um 'if' deve ser um bool, um número (verdadeiro quando não
+é zero) ou um valor anulável (verdadeiro quando existe). Outros valores,
+como strings, precisam de uma comparação explícita.`,
+		},
+		Wrong: `string name = "Ana"
+if (name) {
+    name = "Bia"
+}`,
+		Right: `string name = "Ana"
+if (name != "") {
+    name = "Bia"
+}`,
+	},
+	WhileCondition: {
+		Text: Message{
+			EN: `The condition of a 'while' must be a bool, a number or a nullable value,
+as in an 'if'. Other values, such as strings, need an explicit comparison.`,
+			PT: `A condição de um 'while' deve ser um bool, um número ou um valor
+anulável, como em um 'if'. Outros valores, como strings, precisam de uma
+comparação explícita.`,
+		},
+		Wrong: `string line = "a"
+while (line) {
+    line = ""
+}`,
+		Right: `string line = "a"
+while (line != "") {
+    line = ""
+}`,
+	},
+	DoWhileCondition: {
+		Text: Message{
+			EN: `The condition after the body of a do loop must be a bool.`,
+			PT: `A condição após o corpo de um loop do deve ser um bool.`,
+		},
+		Wrong: `int n = 3
+do {
+    n--
+} while (n)`,
+		Right: `int n = 3
+do {
+    n--
+} while (n > 0)`,
+	},
+	ForCondition: {
+		Text: Message{
+			EN: `The middle part of a for loop decides whether the loop continues and must
+be a bool.`,
+			PT: `A parte do meio de um loop for decide se o loop continua e deve ser um
+bool.`,
+		},
+		Wrong: `for (int i = 0; 10; i++) {
+}`,
+		Right: `for (int i = 0; i < 10; i++) {
+}`,
+	},
+	BreakOutside: {
+		Text: Message{
+			EN: `'break' leaves the innermost loop or switch, so it cannot appear outside
+of one.`,
+			PT: `'break' sai do loop ou switch mais interno, então não pode aparecer fora
+de um.`,
+		},
+		Wrong: `int n = 0
+if (n == 0) {
+    break
+}`,
+		Right: `int n = 0
+while (true) {
+    if (n == 0) {
+        break
+    }
+}`,
+	},
+	ContinueOutside: {
+		Text: Message{
+			EN: `'continue' skips to the next iteration of a loop, so it can only appear
+inside one.`,
+			PT: `'continue' pula para a próxima iteração de um loop, então só pode
+aparecer dentro de um.`,
+		},
+		Wrong: `int n = 0
+if (n == 0) {
+    continue
+}`,
+		Right: `for (int i = 0; i < 3; i++) {
+    if (i == 1) {
+        continue
+    }
+}`,
+	},
+	FallthroughNotLast: {
+		Text: Message{
+			EN: `'fallthrough' passes control to the next case, so any statement after it
+would never run. It must be the last statement of its case.`,
+			PT: `'fallthrough' passa o controle para o próximo caso, então qualquer
+instrução depois dele nunca executaria. Ele deve ser a última instrução do
+caso.`,
+		},
+		Wrong: `int x = 1
+switch (x) {
+    case 1:
+        fallthrough
+        x = 2
+    case 2:
+        x = 3
+}`,
+		Right: `int x = 1
+switch (x) {
+    case 1:
+        x = 2
+        fallthrough
+    case 2:
+        x = 3
+}`,
+	},
+	SwitchNoValue: {
+		Text: Message{
+			EN: `Cases that list types test the type of the switch value, so the switch
+needs a value between parentheses.`,
+			PT: `Casos que listam tipos testam o tipo do valor do switch, então o switch
+precisa de um valor entre parênteses.`,
+		},
+		Wrong: `int | string v = 1
+switch {
+    case int:
+        v = 2
+}`,
+		Right: `int | string v = 1
+switch (v) {
+    case int:
+        v = 2
+}`,
+	},
+	DuplicateDefault: {
+		Text: Message{
+			EN: `A switch has at most one 'default' branch.`,
+			PT: `Um switch tem no máximo um ramo 'default'.`,
+		},
+		Wrong: `int x = 1
+switch (x) {
+    default:
+        x = 1
+    default:
+        x = 2
+}`,
+		Right: `int x = 1
+switch (x) {
+    case 1:
+        x = 1
+    default:
+        x = 2
+}`,
+	},
+	MixedSwitchCases: {
+		Text: Message{
+			EN: `A switch either tests the type of its value (case int:) or compares its
+value (case 3:), not both.`,
+			PT: `Um switch ou testa o tipo do seu valor (case int:) ou compara o valor
+(case 3:), mas não os dois.`,
+		},
+		Wrong: `int | string v = 1
+switch (v) {
+    case int:
+        v = 2
+    case 3:
+        v = 4
+}`,
+		Right: `int | string v = 1
+switch (v) {
+    case int:
+        v = 2
+    case string:
+        v = 4
+}`,
+	},
+	TypeSwitchSubject: {
+		Text: Message{
+			EN: `Only values whose type may vary (any or a union such as int | string) can
+be tested with type cases. The type of this value is always the same.`,
+			PT: `Apenas valores cujo tipo pode variar (any ou uma união como
+int | string) podem ser testados com casos de tipo. O tipo deste valor é
+sempre o mesmo.`,
+		},
+		Wrong: `int x = 1
+switch (x) {
+    case int:
+        x = 2
+}`,
+		Right: `int | string x = 1
+switch (x) {
+    case int:
+        x = 2
+}`,
+	},
+	DuplicateTypeCase: {
+		Text: Message{
+			EN: `The same type appears in two cases; the second one could never run.`,
+			PT: `O mesmo tipo aparece em dois casos; o segundo nunca executaria.`,
+		},
+		Wrong: `int | string v = 1
+switch (v) {
+    case int:
+        v = 2
+    case int:
+        v = 3
+}`,
+		Right: `int | string v = 1
+switch (v) {
+    case int:
+        v = 2
+    case string:
+        v = 3
+}`,
+	},
+	TypeNotInUnion: {
+		Text: Message{
+			EN: `The case tests a type that the union value can never have, so it could
+never run.`,
+			PT: `O caso testa um tipo que o valor união nunca pode ter, então ele nunca
+executaria.`,
+		},
+		Wrong: `int | string v = 1
+switch (v) {
+    case float:
+        v = 2
+}`,
+		Right: `int | string v = 1
+switch (v) {
+    case string:
+        v = 2
+}`,
+	},
+	SwitchCaseNotBool: {
+		Text: Message{
+			EN: `In a switch without a value, each case is a condition and must be a bool.`,
+			PT: `Em um switch sem valor, cada caso é uma condição e deve ser um bool.`,
+		},
+		Wrong: `int x = 1
+switch {
+    case x:
+        x = 2
+}`,
+		Right: `int x = 1
+switch {
+    case x > 0:
+        x = 2
+}`,
+	},
+	CaseTypeMismatch: {
+		Text: Message{
+			EN: `The case value has a different type from the value being switched on, so
+they could never be equal.`,
+			PT: `O valor do caso tem um tipo diferente do valor testado pelo switch, então
+eles nunca seriam iguais.`,
+		},
+		Wrong: `int x = 1
+switch (x) {
+    case "1":
+        x = 2
+}`,
+		Right: `int x = 1
+switch (x) {
+    case 1:
+        x = 2
+}`,
+	},
+	DuplicateCase: {
+		Text: Message{
+			EN: `The same value appears in two cases; only the first one would ever run.`,
+			PT: `O mesmo valor aparece em dois casos; apenas o primeiro executaria.`,
+		},
+		Wrong: `int x = 1
+switch (x) {
+    case 1, 1:
+        x = 2
+}`,
+		Right: `int x = 1
+switch (x) {
+    case 1, 2:
+        x = 2
+}`,
+	},
+	FallthroughTypeSwitch: {
+		Text: Message{
+			EN: `In a type switch each case works with a value of a different type, so
+control cannot fall from one case into the next.`,
+			PT: `Em um switch de tipos, cada caso trabalha com um valor de tipo diferente,
+então o controle não pode passar de um caso para o próximo.`,
+		},
+		Wrong: `int | string v = 1
+switch (v) {
+    case int:
+        fallthrough
+    case string:
+        v = 3
+}`,
+		Right: `int | string v = 1
+switch (v) {
+    case int, string:
+        v = 3
+}`,
+	},
+	FallthroughFinal: {
+		Text: Message{
+			EN: `'fallthrough' continues into the next case, but the last case has none.`,
+			PT: `'fallthrough' continua no próximo caso, mas o último caso não tem um
+próximo.`,
+		},
+		Wrong: `int x = 1
+switch (x) {
+    case 1:
+        x = 2
+        fallthrough
+}`,
+		Right: `int x = 1
+switch (x) {
+    case 1:
+        x = 2
+}`,
+	},
+
+	// Concorrência (A06xx)
+	SharedTwice: {
+		Text: Message{
+			EN: `A variable passed by reference (&x) to a spawned task may be changed by it
+at any moment. Sharing it with a second task before waitAll() would let the
+two tasks change it at the same time.`,
+			PT: `Uma variável passada por referência (&x) para uma tarefa disparada pode
+ser alterada por ela a qualquer momento. Compartilhá-la com uma segunda
+tarefa antes do waitAll() deixaria as duas tarefas alterá-la ao mesmo tempo.`,
+		},
+		Wrong: `void function work(int* counter) {}
+void function run() {
+    int counter
+    spawn work(&counter)
+    spawn work(&counter)
+}`,
+		Right: `void function work(int* counter) {}
+void function run() {
+    int counter
+    spawn work(&counter)
+    waitAll()
+    spawn work(&counter)
+}`,
+	},
+	DataRaceWrite: {
+		Text: Message{
+			EN: `The variable is written while a spawned task may still be using it. Call
+waitAll() to wait for the tasks before changing the variable.`,
+			PT: `A variável é escrita enquanto uma tarefa disparada ainda pode estar
+usando-a. Chame waitAll() para esperar as tarefas antes de alterar a
+variável.`,
+		},
+		Wrong: `void function work(int* counter) {}
+void function run() {
+    int counter
+    spawn work(&counter)
+    counter = 10
+}`,
+		Right: `void function work(int* counter) {}
+void function run() {
+    int counter
+    spawn work(&counter)
+    waitAll()
+    counter = 10
+}`,
+	},
+	DataRaceLoop: {
+		Text: Message{
+			EN: `Every iteration of the loop spawns a task sharing the same variable, so
+several tasks would use it at once. Wait for each task inside the loop, or
+give each task its own variable.`,
+			PT: `Cada iteração do loop dispara uma tarefa que compartilha a mesma
+variável, então várias tarefas a usariam ao mesmo tempo. Espere cada tarefa
+dentro do loop, ou dê a cada tarefa sua própria variável.`,
+		},
+		Wrong: `void function work(int* counter) {}
+void function run() {
+    int counter
+    for (int i = 0; i < 3; i++) {
+        spawn work(&counter)
+    }
+}`,
+		Right: `void function work(int* counter) {}
+void function run() {
+    int counter
+    for (int i = 0; i < 3; i++) {
+        spawn work(&counter)
+        waitAll()
+    }
+}`,
+	},
+	SelectDuplicateDefault: {
+		Text: Message{
+			EN: `A select has at most one 'default' branch, which runs when no channel is
+ready.`,
+			PT: `Um select tem no máximo um ramo 'default', que executa quando nenhum
+canal está pronto.`,
+		},
+		Wrong: `void function run(channel<int> ch) {
+    select {
+    default:
+        close(ch)
+    default:
+        close(ch)
+    }
+}`,
+		Right: `void function run(channel<int> ch) {
+    select {
+    case ch <- 1:
+        close(ch)
+    default:
+        close(ch)
+    }
+}`,
+	},
+	ChannelCapacity: {
+		Text: Message{
+			EN: `The capacity of a channel is the number of values it can hold without a
+receiver, so it must be an integer.`,
+			PT: `A capacidade de um canal é a quantidade de valores que ele guarda sem um
+receptor, então deve ser um inteiro.`,
+		},
+		Wrong: `channel<int> ch = channel<int>("10")`,
+		Right: `channel<int> ch = channel<int>(10)`,
+	},
+	CannotSend: {
+		Text: Message{
+			EN: `A channel only carries values of its element type.`,
+			PT: `Um canal só transporta valores do seu tipo de elemento.`,
+		},
+		Wrong: `channel<int> ch = channel<int>(1)
+ch <- "a"`,
+		Right: `channel<int> ch = channel<int>(1)
+ch <- 1`,
+	},
+	ExpectedChannel: {
+		Text: Message{
+			EN: `'<-' and close() work only on channels.`,
+			PT: `'<-' e close() funcionam apenas com canais.`,
+		},
+		Wrong: `int x = 1
+var v = <-x`,
+		Right: `channel<int> x = channel<int>(1)
+var v = <-x`,
+	},
+	CloseArgs: {
+		Text: Message{
+			EN: `close() receives exactly one argument: the channel to close.`,
+			PT: `close() recebe exatamente um argumento: o canal a ser fechado.`,
+		},
+		Wrong: `void function run(channel<int> ch) {
+    close()
+}`,
+		Right: `void function run(channel<int> ch) {
+    close(ch)
+}`,
+	},
+	WaitAllArgs: {
+		Text: Message{
+			EN: `waitAll() waits for every task spawned so far and takes no arguments.`,
+			PT: `waitAll() espera todas as tarefas disparadas até o momento e não recebe
+argumentos.`,
+		},
+		Wrong: `void function run() {
+    waitAll(1)
+}`,
+		Right: `void function run() {
+    waitAll()
+}`,
+	},
+	ConcurrencyOutsideFunc: {
+		Text: Message{
+			EN: `Code at the top level only declares things: the statements there run
+nowhere. spawn, select, close() and waitAll() control tasks, so they must be
+inside a function.`,
+			PT: `O nível de cima do arquivo apenas declara: os comandos ali não executam
+em lugar nenhum. spawn, select, close() e waitAll() controlam tarefas, então
+precisam estar dentro de uma função.`,
+		},
+		Wrong: `void function work() {}
+spawn work()`,
+		Right: `void function work() {}
+void function run() {
+    spawn work()
+    waitAll()
+}`,
+	},
+	DataRaceRead: {
+		Text: Message{
+			EN: `The variable is read while a spawned task may still be changing it, so the
+value read could be old or half written. Call waitAll() to wait for the
+tasks before reading the variable.`,
+			PT: `A variável é lida enquanto uma tarefa disparada ainda pode estar
+alterando-a, então o valor lido pode estar velho ou incompleto. Chame
+waitAll() para esperar as tarefas antes de ler a variável.`,
+		},
+		Wrong: `void function work(int* counter) {}
+int function run() {
+    int counter
+    spawn work(&counter)
+    int copy = counter
+    waitAll()
+    return copy
+}`,
+		Right: `void function work(int* counter) {}
+int function run() {
+    int counter
+    spawn work(&counter)
+    waitAll()
+    int copy = counter
+    return copy
+}`,
+	},
+
+	// Constantes (A07xx)
+	ConstNotConstant: {
+		Text: Message{
+			EN: `A constant is computed at compile time, so its value can only use
+literals, other constants and operators. Use a variable for values known
+only while running.`,
+			PT: `Uma constante é calculada em tempo de compilação, então seu valor só pode
+usar literais, outras constantes e operadores. Use uma variável para
+valores conhecidos apenas na execução.`,
+		},
+		Wrong: `int base = 10
+const LIMIT = base * 2`,
+		Right: `const BASE = 10
+const LIMIT = BASE * 2`,
+	},
+	ConstsNotConstant: {
+		Text: Message{
+			EN: `Function results are only known while running, so they cannot initialize
+constants. Declare variables instead.`,
+			PT: `Resultados de funções só são conhecidos na execução, então não podem
+inicializar constantes. Declare variáveis.`,
+		},
+		Wrong: `int, int function pair() {
+    return 1, 2
+}
+const LO, HI = pair()`,
+		Right: `int, int function pair() {
+    return 1, 2
+}
+var lo, hi = pair()`,
+	},
+	AssignToConst: {
+		Text: Message{
+			EN: `The value of a constant is fixed. Declare a variable if the value needs to
+change.`,
+			PT: `O valor de uma constante é fixo. Declare uma variável se o valor precisar
+mudar.`,
+		},
+		Wrong: `const limit = 10
+limit = 20`,
+		Right: `int limit = 10
+limit = 20`,
+	},
+	ConstOverflowsType: {
+		Text: Message{
+			EN: `The constant does not fit in the type it is assigned to. A byte goes from
+0 to 255 and a char holds Unicode code points from 0 to 2147483647.`,
+			PT: `A constante não cabe no tipo ao qual é atribuída. Um byte vai de 0 a 255
+e um char guarda códigos Unicode de 0 a 2147483647.`,
+		},
+		Wrong: `byte b = 300`,
+		Right: `int b = 300`,
+	},
+	TernaryCondNotBool: {
+		Text: Message{
+			EN: `The condition before '?' in a constant expression must be a bool.`,
+			PT: `A condição antes do '?' em uma expressão constante deve ser um bool.`,
+		},
+		Wrong: `const SIZE = 1 ? 2 : 3`,
+		Right: `const SIZE = 1 > 0 ? 2 : 3`,
+	},
+	NegateOverflow: {
+		Text: Message{
+			EN: `The smallest int (-9223372036854775808) has no positive counterpart, so
+negating it does not fit in an int.`,
+			PT: `O menor int (-9223372036854775808) não tem um positivo correspondente,
+então negá-lo não cabe em um int.`,
+		},
+		Wrong: `const MIN = -9223372036854775807 - 1
+const VALUE = -MIN`,
+		Right: `const MIN = -9223372036854775807 - 1
+const VALUE = -(MIN + 1)`,
+	},
+	ConstUnaryUnsupported: {
+		Text: Message{
+			EN: `The unary operator does not apply to this type: '!' needs a bool, '-' a
+number and '~' an integer.`,
+			PT: `O operador unário não se aplica a este tipo: '!' exige um bool, '-' um
+número e '~' um inteiro.`,
+		},
+		Wrong: `const VALUE = !5`,
+		Right: `const VALUE = !true`,
+	},
+	ConstNegativeShift: {
+		Text: Message{
+			EN: `The number of positions of a shift cannot be negative. To shift in the
+other direction use the opposite operator.`,
+			PT: `A quantidade de posições de um deslocamento não pode ser negativa. Para
+deslocar no outro sentido use o operador oposto.`,
+		},
+		Wrong: `const VALUE = 16 << -2`,
+		Right: `const VALUE = 16 >> 2`,
+	},
+	ConstOverflow: {
+		Text: Message{
+			EN: `The result of the constant expression does not fit in its type. Integer
+constants are checked at compile time so that they never wrap around while
+running.`,
+			PT: `O resultado da expressão constante não cabe no seu tipo. Constantes
+inteiras são verificadas em tempo de compilação para que nunca "deem a
+volta" durante a execução.`,
+		},
+		Wrong: `const VALUE = 9223372036854775807 + 1`,
+		Right: `const VALUE = 9223372036854775807.0 + 1`,
+	},
+	ConstBinaryUnsupported: {
+		Text: Message{
+			EN: `The operator does not apply to constants of these types. Strings support
+only '+' and comparisons.`,
+			PT: `O operador não se aplica a constantes destes tipos. Strings aceitam apenas
+'+' e comparações.`,
+		},
+		Wrong: `const VALUE = "a" - 1`,
+		Right: `const VALUE = "a" + "1"`,
+	},
+	ConstDivByZero: {
+		Text: Message{
+			EN: `Integer division or remainder by zero has no result. When both sides are
+constants the compiler detects it before the program runs.`,
+			PT: `Divisão ou resto inteiro por zero não tem resultado. Quando os dois lados
+são constantes o compilador detecta isso antes da execução.`,
+		},
+		Wrong: `const VALUE = 10 / 0`,
+		Right: `const VALUE = 10 / 2`,
+	},
+	ConstFloatDivByZero: {
+		Text: Message{
+			EN: `Division of a constant float by zero has no finite result.`,
+			PT: `A divisão de um float constante por zero não tem resultado finito.`,
+		},
+		Wrong: `const VALUE = 1.5 / 0`,
+		Right: `const VALUE = 1.5 / 2`,
+	},
+	ConstFloatOverflow: {
+		Text: Message{
+			EN: `The result of the constant float expression is larger than the largest
+float (about 1.8e308).`,
+			PT: `O resultado da expressão constante float é maior que o maior float
+(cerca de 1.8e308).`,
+		},
+		Wrong: `const VALUE = 1e308 * 10.0`,
+		Right: `const VALUE = 1e307 * 10.0`,
+	},
+	ConstFloatToInt: {
+		Text: Message{
+			EN: `The float constant is too large to be converted to an int.`,
+			PT: `A constante float é grande demais para ser convertida em int.`,
+		},
+		Wrong: `const VALUE = int(1e30)`,
+		Right: `const VALUE = int(1e15)`,
+	},
+	ConstConvertOverflow: {
+		Text: Message{
+			EN: `The integer constant does not fit in the target type of the conversion.
+A byte goes from 0 to 255 and a char from 0 to 2147483647.`,
+			PT: `A constante inteira não cabe no tipo de destino da conversão. Um byte vai
+de 0 a 255 e um char de 0 a 2147483647.`,
+		},
+		Wrong: `const VALUE = byte(256)`,
+		Right: `const VALUE = byte(255)`,
+	},
+	ConstCannotConvert: {
+		Text: Message{
+			EN: `There is no conversion from this constant to the target type. For
+example, numbers do not convert to bool: compare them instead.`,
+			PT: `Não existe conversão desta constante para o tipo de destino. Por exemplo,
+números não convertem para bool: compare-os.`,
+		},
+		Wrong: `const VALUE = bool(1)`,
+		Right: `const VALUE = 1 != 0`,
+	},
+}
@@ -0,0 +1,76 @@
+package diag_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/lexer"
+	"github.com/alpha/internal/parser"
+	"github.com/alpha/internal/semantic"
+)
+
+// label encontra o código nas mensagens do parser ("line 1:col 5: [A1001] ...")
+var label = regexp.MustCompile(`\[(A\d{4})\]`)
+
+// codesOf verifica o exemplo como o 'alpha check' e retorna os códigos dos
+// erros encontrados
+func codesOf(src string) []diag.Code {
+	var codes []diag.Code
+	sc := lexer.NewScanner(src)
+	for tok := sc.NextToken(); tok.Type != lexer.EOF; tok = sc.NextToken() {
+		if tok.Type == lexer.ERROR {
+			codes = append(codes, tok.Code)
+		}
+	}
+	if len(codes) > 0 {
+		return codes
+	}
+
+	p := parser.New(lexer.NewScanner(src))
+	prog := p.ParseProgram()
+	for _, err := range p.Errors {
+		if m := label.FindStringSubmatch(err); m != nil {
+			codes = append(codes, diag.Code(m[1]))
+		}
+	}
+	c := semantic.NewChecker()
+	c.CheckProgram(prog)
+	for _, err := range c.Errors {
+		codes = append(codes, err.Code)
+	}
+	return codes
+}
+
+// Todo código tem um exemplo errado que o provoca e a versão corrigida, que
+// passa sem erros; só os marcados Unreachable ficam sem exemplos
+func TestExplainExamples(t *testing.T) {
+	for _, code := range diag.Codes() {
+		exp, ok := diag.Explain(code)
+		if !ok {
+			t.Errorf("%s has no explanation", code)
+			continue
+		}
+		if exp.Unreachable {
+			if exp.Wrong != "" || exp.Right != "" {
+				t.Errorf("%s is unreachable but has examples", code)
+			}
+			continue
+		}
+		if exp.Wrong == "" || exp.Right == "" {
+			t.Errorf("%s has no wrong or corrected example", code)
+			continue
+		}
+
+		found := false
+		for _, got := range codesOf(exp.Wrong) {
+			found = found || got == code
+		}
+		if !found {
+			t.Errorf("%s: wrong example reports %v\n%s", code, codesOf(exp.Wrong), exp.Wrong)
+		}
+		if got := codesOf(exp.Right); len(got) > 0 {
+			t.Errorf("%s: corrected example reports %v\n%s", code, got, exp.Right)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package diag
+
+import (
+	"sort"
+	"strings"
+)
+
+// ============================
+// SUGESTÕES ("VOCÊ QUIS DIZER")
+// ============================
+
+// Suggest procura entre os candidatos o nome mais parecido com name. Só conta
+// como sugestão um candidato a até um terço do tamanho do nome em edições
+// (inserir, remover ou trocar uma letra, ou inverter duas vizinhas), então
+// nomes com menos de três letras nunca recebem sugestões. Os candidatos vêm
+// em grupos por preferência: na mesma distância ganha o do primeiro grupo e,
+// dentro do grupo, a ordem alfabética
+func Suggest(name string, groups ...[]string) (string, bool) {
+	limit := len([]rune(name)) / 3
+	best, bestDist := "", limit+1
+
+	for _, candidates := range groups {
+		groupBest := ""
+		for _, candidate := range candidates {
+			if candidate == name {
+				continue
+			}
+			// Diferenças só de maiúsculas contam como a menor distância possível
+			dist := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+			if dist < bestDist || (dist == bestDist && groupBest != "" && candidate < groupBest) {
+				best, bestDist, groupBest = candidate, dist, candidate
+			}
+		}
+	}
+
+	return best, best != ""
+}
+
+// editDistance calcula a distância de edição entre a e b contando a inversão
+// de duas letras vizinhas ("lenght" -> "length") como uma única edição
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(ra)][len(rb)]
+}
+
+// ============================
+// CORREÇÕES AUTOMÁTICAS (FIX-ITS)
+// ============================
+
+// Fix é uma correção que pode ser aplicada sem intervenção: troca o texto Old,
+// que começa no byte Offset do código, por New. Line e Col são a mesma posição
+// para exibição
+type Fix struct {
+	Line   int
+	Col    int
+	Offset int
+	Old    string
+	New    string
+}
+
+// ApplyFixes aplica as correções ao código e retorna o resultado com a
+// quantidade aplicada. Correções cujo trecho não contém mais o texto Old, ou
+// que se sobrepõem a uma anterior, são ignoradas
+func ApplyFixes(src string, fixes []Fix) (string, int) {
+	sorted := append([]Fix(nil), fixes...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Offset < sorted[j].Offset })
+
+	var out strings.Builder
+	applied, last := 0, 0
+	for _, fix := range sorted {
+		end := fix.Offset + len(fix.Old)
+		if fix.Offset < last || end > len(src) || src[fix.Offset:end] != fix.Old {
+			continue
+		}
+		out.WriteString(src[last:fix.Offset])
+		out.WriteString(fix.New)
+		last = end
+		applied++
+	}
+	out.WriteString(src[last:])
+
+	return out.String(), applied
+}
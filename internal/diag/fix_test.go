@@ -0,0 +1,21 @@
+package diag
+
+import "testing"
+
+func TestSuggestPrefersEarlierGroups(t *testing.T) {
+	tests := []struct {
+		name   string
+		groups [][]string
+		want   string
+	}{
+		{"cont", [][]string{{"count"}, {"const"}}, "count"},
+		{"cont", [][]string{{"const", "count"}}, "const"},
+		{"retrun", [][]string{{"total"}, {"return"}}, "return"},
+		{"ab", [][]string{{"abc"}}, ""},
+	}
+	for _, tt := range tests {
+		if got, _ := Suggest(tt.name, tt.groups...); got != tt.want {
+			t.Errorf("Suggest(%q, %v) = %q, want %q", tt.name, tt.groups, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package diag
+
+// ============================
+// LEXER (A00xx)
+// ============================
+
+const (
+	UnrecognizedOperator  Code = "A0001"
+	UnterminatedString    Code = "A0002"
+	UnterminatedInterp    Code = "A0003"
+	UnterminatedRawString Code = "A0004"
+	UnterminatedChar      Code = "A0005"
+	EmptyChar             Code = "A0006"
+	CharTooLong           Code = "A0007"
+	UnknownEscape         Code = "A0008"
+	NoDigits              Code = "A0009"
+	MalformedExponent     Code = "A0010"
+	MisplacedDigitSep     Code = "A0011"
+	InvalidDigit          Code = "A0012"
+	InvalidCharInLiteral  Code = "A0013"
+)
+
+var lexerMessages = map[Code]Message{
+	UnrecognizedOperator: {
+		EN: "unrecognized operator: %s",
+		PT: "operador não reconhecido: %s",
+	},
+	UnterminatedString: {
+		EN: "unterminated string",
+		PT: "string não terminada",
+	},
+	UnterminatedInterp: {
+		EN: "unterminated interpolation in string",
+		PT: "interpolação não terminada na string",
+	},
+	UnterminatedRawString: {
+		EN: "unterminated raw string",
+		PT: "string bruta não terminada",
+	},
+	UnterminatedChar: {
+		EN: "unterminated character literal",
+		PT: "literal de caractere não terminado",
+	},
+	EmptyChar: {
+		EN: "empty character literal",
+		PT: "literal de caractere vazio",
+	},
+	CharTooLong: {
+		EN: "character literal must contain exactly one character",
+		PT: "literal de caractere deve conter exatamente um caractere",
+	},
+	UnknownEscape: {
+		EN: "unknown escape sequence: \\%c",
+		PT: "sequência de escape desconhecida: \\%c",
+	},
+	NoDigits: {
+		EN: "%s literal has no digits",
+		PT: "literal %s sem dígitos",
+	},
+	MalformedExponent: {
+		EN: "malformed exponent",
+		PT: "expoente malformado",
+	},
+	MisplacedDigitSep: {
+		EN: "'_' must separate successive digits",
+		PT: "'_' deve separar dígitos consecutivos",
+	},
+	InvalidDigit: {
+		EN: "invalid digit '%c' in %s literal",
+		PT: "dígito inválido '%c' no literal %s",
+	},
+	InvalidCharInLiteral: {
+		EN: "invalid character '%c' in %s literal",
+		PT: "caractere inválido '%c' no literal %s",
+	},
+}
@@ -0,0 +1,458 @@
+package diag
+
+// ============================
+// PARSER (A1xxx)
+// ============================
+
+// Gerais (A10xx)
+const (
+	ExpectedToken          Code = "A1001"
+	UnexpectedKeyword      Code = "A1002"
+	UnexpectedOperator     Code = "A1003"
+	ExpectedParenAfter     Code = "A1004"
+	ExpectedCondition      Code = "A1005"
+	ExpectedCloseParenCond Code = "A1006"
+	TooManyErrors          Code = "A1007"
+)
+
+// Declarações (A11xx)
+const (
+	ExpectedVarName             Code = "A1101"
+	ExpectedVarNameAfterComma   Code = "A1102"
+	ExpectedVarInit             Code = "A1103"
+	MultiVarNeedsInit           Code = "A1104"
+	ExpectedConstName           Code = "A1105"
+	ExpectedConstNameAfterComma Code = "A1106"
+	ExpectedConstAssign         Code = "A1107"
+	ExpectedConstValue          Code = "A1108"
+	ExpectedExprAfterAssign     Code = "A1109"
+	ExpectedFuncName            Code = "A1110"
+	ExpectedParamType           Code = "A1111"
+	ExpectedParamName           Code = "A1112"
+	ExpectedDefaultValue        Code = "A1113"
+	VariadicNotLast             Code = "A1114"
+	ExpectedParamSep            Code = "A1115"
+	ExpectedFuncBody            Code = "A1116"
+	ExpectedStructName          Code = "A1117"
+	ExpectedTypeAfterPrivate    Code = "A1118"
+	ExpectedFieldType           Code = "A1119"
+	ExpectedFieldName           Code = "A1120"
+	ExpectedImplTarget          Code = "A1121"
+	MultipleInit                Code = "A1122"
+	ExpectedAfterGenerics       Code = "A1123"
+	ExpectedFunctionKeyword     Code = "A1124"
+	ExpectedTypeName            Code = "A1125"
+)
+
+// Módulos (A12xx)
+const (
+	ExpectedPackageName     Code = "A1201"
+	UnknownLanguage         Code = "A1202"
+	ExpectedPackageSegment  Code = "A1203"
+	ExpectedImportForm      Code = "A1204"
+	ModuleImportAlias       Code = "A1205"
+	ExpectedModuleAfterFrom Code = "A1206"
+	ExpectedFrom            Code = "A1207"
+	ExpectedImportName      Code = "A1208"
+	ExpectedAlias           Code = "A1209"
+	ExpectedExportName      Code = "A1210"
+)
+
+// Comandos (A13xx)
+const (
+	ExpectedSwitchBody  Code = "A1301"
+	ExpectedCase        Code = "A1302"
+	ExpectedCaseType    Code = "A1303"
+	ExpectedCaseExpr    Code = "A1304"
+	ExpectedDoBlock     Code = "A1305"
+	ExpectedDoWhile     Code = "A1306"
+	ExpectedForInName   Code = "A1307"
+	ExpectedForInSecond Code = "A1308"
+	ExpectedSpawnCall   Code = "A1309"
+	ExpectedSelectCase  Code = "A1310"
+	InvalidSelectCase   Code = "A1311"
+	ExpectedReturnExpr  Code = "A1312"
+)
+
+// Expressões e literais (A14xx)
+const (
+	IntOverflow               Code = "A1401"
+	InvalidInt                Code = "A1402"
+	FloatOverflow             Code = "A1403"
+	InvalidFloat              Code = "A1404"
+	EmptyInterpolation        Code = "A1405"
+	InvalidInterpolation      Code = "A1406"
+	UnexpectedInInterpolation Code = "A1407"
+	ExpectedTernaryTrue       Code = "A1408"
+	ExpectedTernaryColon      Code = "A1409"
+	ExpectedTernaryFalse      Code = "A1410"
+	ExpectedMemberName        Code = "A1411"
+	ExpectedStructLitField    Code = "A1412"
+	ExpectedLiteralBody       Code = "A1413"
+	ExpectedChannelAfterRecv  Code = "A1414"
+	ExpectedChannelCap        Code = "A1415"
+	ExpectedArraySep          Code = "A1416"
+	UnterminatedSetLit        Code = "A1417"
+	ExpectedSetSep            Code = "A1418"
+	UnterminatedMapLit        Code = "A1419"
+	ExpectedMapSep            Code = "A1420"
+	ExpectedAfterTypeArgs     Code = "A1421"
+	ExpectedCastExpr          Code = "A1422"
+	ExpectedArrayCastType     Code = "A1423"
+)
+
+// Tipos e genéricos (A15xx)
+const (
+	ExpectedGenericOpen       Code = "A1501"
+	ExpectedGenericParam      Code = "A1502"
+	ExpectedGenericParamAfter Code = "A1503"
+	ExpectedTypeArg           Code = "A1504"
+	ExpectedTypeArgAfterComma Code = "A1505"
+	ExpectedReturnListType    Code = "A1506"
+)
+
+var parserMessages = map[Code]Message{
+	// Gerais
+	ExpectedToken: {
+		EN: "expected '%s', got '%s'",
+		PT: "esperado '%s', encontrado '%s'",
+	},
+	UnexpectedKeyword: {
+		EN: "unexpected keyword: %s",
+		PT: "palavra-chave inesperada: %s",
+	},
+	UnexpectedOperator: {
+		EN: "unexpected operator: %s",
+		PT: "operador inesperado: %s",
+	},
+	ExpectedParenAfter: {
+		EN: "expected '(' after %s",
+		PT: "esperado '(' após %s",
+	},
+	ExpectedCondition: {
+		EN: "expected condition expression",
+		PT: "esperada uma expressão de condição",
+	},
+	ExpectedCloseParenCond: {
+		EN: "expected ')' after condition",
+		PT: "esperado ')' após a condição",
+	},
+	TooManyErrors: {
+		EN: "too many errors; only the first %d are shown",
+		PT: "erros demais; apenas os %d primeiros são mostrados",
+	},
+
+	// Declarações
+	ExpectedVarName: {
+		EN: "expected identifier after 'var'",
+		PT: "esperado um identificador após 'var'",
+	},
+	ExpectedVarNameAfterComma: {
+		EN: "expected identifier after ',' in variable declaration",
+		PT: "esperado um identificador após ',' na declaração de variáveis",
+	},
+	ExpectedVarInit: {
+		EN: "expected expression after '=' in variable declaration",
+		PT: "esperada uma expressão após '=' na declaração de variável",
+	},
+	MultiVarNeedsInit: {
+		EN: "multiple variables declaration must have initializer",
+		PT: "declaração de múltiplas variáveis precisa de um inicializador",
+	},
+	ExpectedConstName: {
+		EN: "expected identifier after 'const'",
+		PT: "esperado um identificador após 'const'",
+	},
+	ExpectedConstNameAfterComma: {
+		EN: "expected identifier after ',' in constant declaration",
+		PT: "esperado um identificador após ',' na declaração de constantes",
+	},
+	ExpectedConstAssign: {
+		EN: "expected '=' in const declaration",
+		PT: "esperado '=' na declaração de constante",
+	},
+	ExpectedConstValue: {
+		EN: "expected expression for constant value",
+		PT: "esperada uma expressão para o valor da constante",
+	},
+	ExpectedExprAfterAssign: {
+		EN: "expected expression after '='",
+		PT: "esperada uma expressão após '='",
+	},
+	ExpectedFuncName: {
+		EN: "expected function name",
+		PT: "esperado o nome da função",
+	},
+	ExpectedParamType: {
+		EN: "expected parameter type",
+		PT: "esperado o tipo do parâmetro",
+	},
+	ExpectedParamName: {
+		EN: "expected parameter name",
+		PT: "esperado o nome do parâmetro",
+	},
+	ExpectedDefaultValue: {
+		EN: "expected default value for parameter '%s'",
+		PT: "esperado o valor padrão do parâmetro '%s'",
+	},
+	VariadicNotLast: {
+		EN: "variadic parameter '%s' must be the last parameter",
+		PT: "o parâmetro variádico '%s' deve ser o último parâmetro",
+	},
+	ExpectedParamSep: {
+		EN: "expected ',' or ')' in parameter list",
+		PT: "esperado ',' ou ')' na lista de parâmetros",
+	},
+	ExpectedFuncBody: {
+		EN: "expected '{' to start function/method body",
+		PT: "esperado '{' para iniciar o corpo da função/método",
+	},
+	ExpectedStructName: {
+		EN: "expected struct name",
+		PT: "esperado o nome da struct",
+	},
+	ExpectedTypeAfterPrivate: {
+		EN: "expected type after 'private'",
+		PT: "esperado um tipo após 'private'",
+	},
+	ExpectedFieldType: {
+		EN: "expected field type in struct, got '%s'",
+		PT: "esperado o tipo do campo na struct, encontrado '%s'",
+	},
+	ExpectedFieldName: {
+		EN: "expected field name after type, got '%s'",
+		PT: "esperado o nome do campo após o tipo, encontrado '%s'",
+	},
+	ExpectedImplTarget: {
+		EN: "expected struct name to implement",
+		PT: "esperado o nome da struct a implementar",
+	},
+	MultipleInit: {
+		EN: "multiple init blocks defined",
+		PT: "mais de um bloco init definido",
+	},
+	ExpectedAfterGenerics: {
+		EN: "expected struct or return type after generics",
+		PT: "esperada uma struct ou um tipo de retorno após os genéricos",
+	},
+	ExpectedFunctionKeyword: {
+		EN: "expected 'function' keyword",
+		PT: "esperada a palavra-chave 'function'",
+	},
+	ExpectedTypeName: {
+		EN: "expected type name",
+		PT: "esperado o nome de um tipo",
+	},
+
+	// Módulos
+	ExpectedPackageName: {
+		EN: "expected package name after 'package'",
+		PT: "esperado o nome do pacote após 'package'",
+	},
+	UnknownLanguage: {
+		EN: "unknown language '%s' after 'lang'; expected 'en' or 'pt'",
+		PT: "idioma desconhecido '%s' após 'lang'; use 'en' ou 'pt'",
+	},
+	ExpectedPackageSegment: {
+		EN: "expected identifier after '.' in package name",
+		PT: "esperado um identificador após '.' no nome do pacote",
+	},
+	ExpectedImportForm: {
+		EN: "expected single module import or list with 'from'",
+		PT: "esperada a importação de um módulo ou uma lista com 'from'",
+	},
+	ModuleImportAlias: {
+		EN: "module import cannot have alias",
+		PT: "a importação de um módulo não pode ter apelido",
+	},
+	ExpectedModuleAfterFrom: {
+		EN: "expected module name or path after 'from'",
+		PT: "esperado o nome ou caminho do módulo após 'from'",
+	},
+	ExpectedFrom: {
+		EN: "expected 'from' after import list",
+		PT: "esperado 'from' após a lista de importação",
+	},
+	ExpectedImportName: {
+		EN: "expected identifier in import list, got %s",
+		PT: "esperado um identificador na lista de importação, encontrado %s",
+	},
+	ExpectedAlias: {
+		EN: "expected alias name after 'as'",
+		PT: "esperado o apelido após 'as'",
+	},
+	ExpectedExportName: {
+		EN: "expected identifier in export list, got %s",
+		PT: "esperado um identificador na lista de exportação, encontrado %s",
+	},
+
+	// Comandos
+	ExpectedSwitchBody: {
+		EN: "expected '{' after switch condition",
+		PT: "esperado '{' após a condição do switch",
+	},
+	ExpectedCase: {
+		EN: "expected 'case' or 'default', got '%s'",
+		PT: "esperado 'case' ou 'default', encontrado '%s'",
+	},
+	ExpectedCaseType: {
+		EN: "expected type after 'case'",
+		PT: "esperado um tipo após 'case'",
+	},
+	ExpectedCaseExpr: {
+		EN: "expected expression after 'case'",
+		PT: "esperada uma expressão após 'case'",
+	},
+	ExpectedDoBlock: {
+		EN: "expected block after 'do'",
+		PT: "esperado um bloco após 'do'",
+	},
+	ExpectedDoWhile: {
+		EN: "expected 'while' after do block",
+		PT: "esperado 'while' após o bloco do 'do'",
+	},
+	ExpectedForInName: {
+		EN: "expected identifier in for-in loop",
+		PT: "esperado um identificador no laço for-in",
+	},
+	ExpectedForInSecond: {
+		EN: "expected second identifier in for-in loop",
+		PT: "esperado o segundo identificador no laço for-in",
+	},
+	ExpectedSpawnCall: {
+		EN: "expected function call after 'spawn'",
+		PT: "esperada uma chamada de função após 'spawn'",
+	},
+	ExpectedSelectCase: {
+		EN: "expected channel operation after 'case'",
+		PT: "esperada uma operação de canal após 'case'",
+	},
+	InvalidSelectCase: {
+		EN: "select case must be a channel send or receive",
+		PT: "o case de um select deve enviar ou receber de um canal",
+	},
+	ExpectedReturnExpr: {
+		EN: "expected expression after ',' in return statement",
+		PT: "esperada uma expressão após ',' no return",
+	},
+
+	// Expressões e literais
+	IntOverflow: {
+		EN: "integer literal %s overflows int",
+		PT: "o literal inteiro %s excede o limite de int",
+	},
+	InvalidInt: {
+		EN: "invalid integer literal: %s",
+		PT: "literal inteiro inválido: %s",
+	},
+	FloatOverflow: {
+		EN: "float literal %s overflows float",
+		PT: "o literal float %s excede o limite de float",
+	},
+	InvalidFloat: {
+		EN: "invalid float literal: %s",
+		PT: "literal float inválido: %s",
+	},
+	EmptyInterpolation: {
+		EN: "empty interpolation in string",
+		PT: "interpolação vazia na string",
+	},
+	InvalidInterpolation: {
+		EN: "invalid expression in string interpolation: %s",
+		PT: "expressão inválida na interpolação da string: %s",
+	},
+	UnexpectedInInterpolation: {
+		EN: "unexpected '%s' in string interpolation",
+		PT: "'%s' inesperado na interpolação da string",
+	},
+	ExpectedTernaryTrue: {
+		EN: "expected expression after '?'",
+		PT: "esperada uma expressão após '?'",
+	},
+	ExpectedTernaryColon: {
+		EN: "expected ':' in ternary expression, got '%s'",
+		PT: "esperado ':' na expressão ternária, encontrado '%s'",
+	},
+	ExpectedTernaryFalse: {
+		EN: "expected expression after ':' in ternary",
+		PT: "esperada uma expressão após ':' no ternário",
+	},
+	ExpectedMemberName: {
+		EN: "expected member name after '.'",
+		PT: "esperado o nome de um membro após '.'",
+	},
+	ExpectedStructLitField: {
+		EN: "expected field name in struct literal, got %s",
+		PT: "esperado o nome de um campo no literal de struct, encontrado %s",
+	},
+	ExpectedLiteralBody: {
+		EN: "expected '{' after %s type definition",
+		PT: "esperado '{' após a definição do tipo %s",
+	},
+	ExpectedChannelAfterRecv: {
+		EN: "expected channel after '<-'",
+		PT: "esperado um canal após '<-'",
+	},
+	ExpectedChannelCap: {
+		EN: "expected channel capacity",
+		PT: "esperada a capacidade do canal",
+	},
+	ExpectedArraySep: {
+		EN: "expected ',' or ']'",
+		PT: "esperado ',' ou ']'",
+	},
+	UnterminatedSetLit: {
+		EN: "unexpected end of file in set literal",
+		PT: "fim de arquivo inesperado no literal de set",
+	},
+	ExpectedSetSep: {
+		EN: "expected ',' or '}' in set literal, got '%s'",
+		PT: "esperado ',' ou '}' no literal de set, encontrado '%s'",
+	},
+	UnterminatedMapLit: {
+		EN: "unexpected end of file in map literal",
+		PT: "fim de arquivo inesperado no literal de map",
+	},
+	ExpectedMapSep: {
+		EN: "expected ',' or '}' in map literal, got '%s'",
+		PT: "esperado ',' ou '}' no literal de map, encontrado '%s'",
+	},
+	ExpectedAfterTypeArgs: {
+		EN: "expected identifier or array literal after generic type arguments, got %s",
+		PT: "esperado um identificador ou literal de array após os argumentos genéricos, encontrado %s",
+	},
+	ExpectedCastExpr: {
+		EN: "expected expression inside type cast",
+		PT: "esperada uma expressão dentro da conversão de tipo",
+	},
+	ExpectedArrayCastType: {
+		EN: "expected array type in conversion",
+		PT: "esperado um tipo de array na conversão",
+	},
+
+	// Tipos e genéricos
+	ExpectedGenericOpen: {
+		EN: "expected '<' after 'generic'",
+		PT: "esperado '<' após 'generic'",
+	},
+	ExpectedGenericParam: {
+		EN: "expected generic parameter name, got %s",
+		PT: "esperado o nome de um parâmetro genérico, encontrado %s",
+	},
+	ExpectedGenericParamAfter: {
+		EN: "expected generic parameter name after ',', got %s",
+		PT: "esperado o nome de um parâmetro genérico após ',', encontrado %s",
+	},
+	ExpectedTypeArg: {
+		EN: "expected type in generic arguments",
+		PT: "esperado um tipo nos argumentos genéricos",
+	},
+	ExpectedTypeArgAfterComma: {
+		EN: "expected type after ',' in generic arguments",
+		PT: "esperado um tipo após ',' nos argumentos genéricos",
+	},
+	ExpectedReturnListType: {
+		EN: "expected type after ',' in return list",
+		PT: "esperado um tipo após ',' na lista de retorno",
+	},
+}
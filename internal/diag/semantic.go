@@ -0,0 +1,644 @@
+package diag
+
+// ============================
+// ANÁLISE SEMÂNTICA (A01xx-A07xx)
+// ============================
+
+// Declarações e escopos (A01xx)
+const (
+	UndeclaredIdentifier  Code = "A0101"
+	UndeclaredFunction    Code = "A0102"
+	NotAFunction          Code = "A0103"
+	VarRedeclared         Code = "A0104"
+	ConstRedeclared       Code = "A0105"
+	ConstAlreadyDeclared  Code = "A0106"
+	FuncRedeclared        Code = "A0107"
+	StructRedeclared      Code = "A0108"
+	TypeRedeclared        Code = "A0109"
+	UnknownType           Code = "A0110"
+	ImportRedeclared      Code = "A0111"
+	ModuleRedeclared      Code = "A0112"
+	ExportUndeclared      Code = "A0113"
+	MultiVarInit          Code = "A0114"
+	InvalidMultiVarInit   Code = "A0115"
+	VarCountMismatch      Code = "A0116"
+	MultiConstInit        Code = "A0117"
+	InvalidMultiConstInit Code = "A0118"
+	ConstCountMismatch    Code = "A0119"
+	UnhandledExpr         Code = "A0120"
+)
+
+// Tipos, operadores e arrays (A02xx)
+const (
+	AssignMismatch         Code = "A0201"
+	ConcatNonString        Code = "A0202"
+	PlusUnsupported        Code = "A0203"
+	MismatchedTypes        Code = "A0204"
+	CannotConvert          Code = "A0205"
+	ConvertSizeMismatch    Code = "A0206"
+	IntegerOperands        Code = "A0207"
+	IntegerOperand         Code = "A0208"
+	NegativeShift          Code = "A0209"
+	CannotInterpolate      Code = "A0210"
+	ArrayIndexType         Code = "A0211"
+	StringIndexType        Code = "A0212"
+	MapKeyMismatch         Code = "A0213"
+	IndexSet               Code = "A0214"
+	CannotIndex            Code = "A0215"
+	CannotSpread           Code = "A0216"
+	InconsistentArrayElems Code = "A0217"
+	ArrayLiteralSize       Code = "A0218"
+	IndexOutOfBounds       Code = "A0219"
+	FixedArrayResize       Code = "A0220"
+	ArraySizeNotConst      Code = "A0221"
+	ArraySizeNegative      Code = "A0222"
+)
+
+// Funções e chamadas (A03xx)
+const (
+	ReturnOutsideFunc    Code = "A0301"
+	MissingReturnValue   Code = "A0302"
+	ReturnCountMismatch  Code = "A0303"
+	ReturnValueMismatch  Code = "A0304"
+	ReturnSingleValue    Code = "A0305"
+	ReturnTypeMismatch   Code = "A0306"
+	PositionalAfterNamed Code = "A0307"
+	SpreadNonVariadic    Code = "A0308"
+	SpreadFixedParam     Code = "A0309"
+	ArgCount             Code = "A0310"
+	ArgCountMax          Code = "A0311"
+	ArgCountMin          Code = "A0312"
+	MissingArgument      Code = "A0313"
+	UnknownNamedParam    Code = "A0314"
+	VariadicByName       Code = "A0315"
+	ParamSetTwice        Code = "A0316"
+	ArgTypeMismatch      Code = "A0317"
+	DefaultOrder         Code = "A0318"
+	VariadicDefault      Code = "A0319"
+	DefaultNotConst      Code = "A0320"
+	DefaultTypeMismatch  Code = "A0321"
+)
+
+// Structs e membros (A04xx)
+const (
+	PrivateFieldAccess  Code = "A0401"
+	PrivateMethodCall   Code = "A0402"
+	NoMember            Code = "A0403"
+	NoField             Code = "A0404"
+	PrivateFieldInit    Code = "A0405"
+	NoInit              Code = "A0406"
+	SelfOutsideImpl     Code = "A0407"
+	MethodFieldConflict Code = "A0408"
+	MethodRedeclared    Code = "A0409"
+	DuplicateField      Code = "A0410"
+	ImplUnknownStruct   Code = "A0411"
+)
+
+// Controle de fluxo e switch (A05xx)
+const (
+	IfCondition           Code = "A0501"
+	WhileCondition        Code = "A0502"
+	DoWhileCondition      Code = "A0503"
+	ForCondition          Code = "A0504"
+	BreakOutside          Code = "A0505"
+	ContinueOutside       Code = "A0506"
+	FallthroughNotLast    Code = "A0507"
+	SwitchNoValue         Code = "A0508"
+	DuplicateDefault      Code = "A0509"
+	MixedSwitchCases      Code = "A0510"
+	TypeSwitchSubject     Code = "A0511"
+	DuplicateTypeCase     Code = "A0512"
+	TypeNotInUnion        Code = "A0513"
+	SwitchCaseNotBool     Code = "A0514"
+	CaseTypeMismatch      Code = "A0515"
+	DuplicateCase         Code = "A0516"
+	FallthroughTypeSwitch Code = "A0517"
+	FallthroughFinal      Code = "A0518"
+)
+
+// Concorrência (A06xx)
+const (
+	SharedTwice            Code = "A0601"
+	DataRaceWrite          Code = "A0602"
+	DataRaceLoop           Code = "A0603"
+	SelectDuplicateDefault Code = "A0604"
+	ChannelCapacity        Code = "A0605"
+	CannotSend             Code = "A0606"
+	ExpectedChannel        Code = "A0607"
+	CloseArgs              Code = "A0608"
+	WaitAllArgs            Code = "A0609"
+	ConcurrencyOutsideFunc Code = "A0610"
+	DataRaceRead           Code = "A0611"
+)
+
+// Constantes (A07xx)
+const (
+	ConstNotConstant       Code = "A0701"
+	ConstsNotConstant      Code = "A0702"
+	AssignToConst          Code = "A0703"
+	ConstOverflowsType     Code = "A0704"
+	TernaryCondNotBool     Code = "A0705"
+	NegateOverflow         Code = "A0706"
+	ConstUnaryUnsupported  Code = "A0707"
+	ConstNegativeShift     Code = "A0708"
+	ConstOverflow          Code = "A0709"
+	ConstBinaryUnsupported Code = "A0710"
+	ConstDivByZero         Code = "A0711"
+	ConstFloatDivByZero    Code = "A0712"
+	ConstFloatOverflow     Code = "A0713"
+	ConstFloatToInt        Code = "A0714"
+	ConstConvertOverflow   Code = "A0715"
+	ConstCannotConvert     Code = "A0716"
+)
+
+var semanticMessages = map[Code]Message{
+	// Declarações e escopos
+	UndeclaredIdentifier: {
+		EN: "Undeclared identifier '%s'",
+		PT: "Identificador '%s' não declarado",
+	},
+	UndeclaredFunction: {
+		EN: "Undeclared function '%s'",
+		PT: "Função '%s' não declarada",
+	},
+	NotAFunction: {
+		EN: "'%s' is not a function",
+		PT: "'%s' não é uma função",
+	},
+	VarRedeclared: {
+		EN: "Variable '%s' already declared in this scope",
+		PT: "Variável '%s' já declarada neste escopo",
+	},
+	ConstRedeclared: {
+		EN: "Constant '%s' redeclared in this scope",
+		PT: "Constante '%s' redeclarada neste escopo",
+	},
+	ConstAlreadyDeclared: {
+		EN: "Constant '%s' already declared in this scope",
+		PT: "Constante '%s' já declarada neste escopo",
+	},
+	FuncRedeclared: {
+		EN: "Function '%s' redeclared",
+		PT: "Função '%s' redeclarada",
+	},
+	StructRedeclared: {
+		EN: "Struct '%s' already defined",
+		PT: "Struct '%s' já definida",
+	},
+	TypeRedeclared: {
+		EN: "Type '%s' already defined",
+		PT: "Tipo '%s' já definido",
+	},
+	UnknownType: {
+		EN: "Unknown type '%s'",
+		PT: "Tipo desconhecido '%s'",
+	},
+	ImportRedeclared: {
+		EN: "Import '%s' already declared",
+		PT: "Importação '%s' já declarada",
+	},
+	ModuleRedeclared: {
+		EN: "Module '%s' already declared",
+		PT: "Módulo '%s' já declarado",
+	},
+	ExportUndeclared: {
+		EN: "Cannot export undeclared symbol '%s'",
+		PT: "Não é possível exportar o símbolo não declarado '%s'",
+	},
+	MultiVarInit: {
+		EN: "Multiple variables declaration must have initializer",
+		PT: "Declaração de múltiplas variáveis precisa de um inicializador",
+	},
+	InvalidMultiVarInit: {
+		EN: "Invalid initializer in multi-variable declaration",
+		PT: "Inicializador inválido na declaração de múltiplas variáveis",
+	},
+	VarCountMismatch: {
+		EN: "Mismatch in variable count: declared %d, but initializer provides %d values",
+		PT: "Quantidade de variáveis incompatível: %d declaradas, mas o inicializador fornece %d valores",
+	},
+	MultiConstInit: {
+		EN: "Multiple constants declaration must have initializer",
+		PT: "Declaração de múltiplas constantes precisa de um inicializador",
+	},
+	InvalidMultiConstInit: {
+		EN: "Invalid initializer in multi-constant declaration",
+		PT: "Inicializador inválido na declaração de múltiplas constantes",
+	},
+	ConstCountMismatch: {
+		EN: "Mismatch in constant count: declared %d, but initializer provides %d values",
+		PT: "Quantidade de constantes incompatível: %d declaradas, mas o inicializador fornece %d valores",
+	},
+	UnhandledExpr: {
+		EN: "Unhandled expression type: %T",
+		PT: "Tipo de expressão não tratado: %T",
+	},
+
+	// Tipos, operadores e arrays
+	AssignMismatch: {
+		EN: "Cannot assign type %s to variable '%s' of type %s%s",
+		PT: "Não é possível atribuir o tipo %s à variável '%s' do tipo %s%s",
+	},
+	ConcatNonString: {
+		EN: "Cannot concatenate string with non-string type %s",
+		PT: "Não é possível concatenar string com o tipo %s",
+	},
+	PlusUnsupported: {
+		EN: "Operator '+' not supported for types %s and %s",
+		PT: "Operador '+' não suportado para os tipos %s e %s",
+	},
+	MismatchedTypes: {
+		EN: "Mismatched types %s and %s in '%s'; use an explicit conversion",
+		PT: "Tipos incompatíveis %s e %s em '%s'; use uma conversão explícita",
+	},
+	CannotConvert: {
+		EN: "Cannot convert %s to %s",
+		PT: "Não é possível converter %s para %s",
+	},
+	ConvertSizeMismatch: {
+		EN: "Cannot convert %s to %s: sizes differ",
+		PT: "Não é possível converter %s para %s: os tamanhos são diferentes",
+	},
+	IntegerOperands: {
+		EN: "Operator '%s' requires integer operands, got %s",
+		PT: "O operador '%s' exige operandos inteiros, encontrado %s",
+	},
+	IntegerOperand: {
+		EN: "Operator '~' requires an integer operand, got %s",
+		PT: "O operador '~' exige um operando inteiro, encontrado %s",
+	},
+	NegativeShift: {
+		EN: "Negative shift count %d in '%s'",
+		PT: "Deslocamento negativo %d em '%s'",
+	},
+	CannotInterpolate: {
+		EN: "Cannot interpolate value of type %s; only string, numeric, bool and character values can be interpolated",
+		PT: "Não é possível interpolar um valor do tipo %s; apenas strings, números, bools e caracteres podem ser interpolados",
+	},
+	ArrayIndexType: {
+		EN: "Array index must be integer, got %s",
+		PT: "O índice do array deve ser inteiro, encontrado %s",
+	},
+	StringIndexType: {
+		EN: "String index must be integer, got %s",
+		PT: "O índice da string deve ser inteiro, encontrado %s",
+	},
+	MapKeyMismatch: {
+		EN: "Map key type mismatch: expected %s, got %s",
+		PT: "Tipo de chave do map incompatível: esperado %s, encontrado %s",
+	},
+	IndexSet: {
+		EN: "Cannot index a set directly. Use 'has()' to check membership.",
+		PT: "Não é possível indexar um set diretamente. Use 'has()' para verificar se contém um valor.",
+	},
+	CannotIndex: {
+		EN: "Cannot index type %s",
+		PT: "Não é possível indexar o tipo %s",
+	},
+	CannotSpread: {
+		EN: "Cannot spread value of type %s; only arrays can be spread",
+		PT: "Não é possível espalhar um valor do tipo %s; apenas arrays podem ser espalhados",
+	},
+	InconsistentArrayElems: {
+		EN: "Inconsistent array element types: %s vs %s",
+		PT: "Tipos de elementos do array inconsistentes: %s e %s",
+	},
+	ArrayLiteralSize: {
+		EN: "Array literal has %d elements, but %s has size %d",
+		PT: "O literal de array tem %d elementos, mas %s tem tamanho %d",
+	},
+	IndexOutOfBounds: {
+		EN: "Index %d out of bounds for array of size %d",
+		PT: "Índice %d fora dos limites de um array de tamanho %d",
+	},
+	FixedArrayResize: {
+		EN: "Cannot use '%s' on fixed-size array %s; convert it to %s[] first",
+		PT: "Não é possível usar '%s' no array de tamanho fixo %s; converta-o para %s[] antes",
+	},
+	ArraySizeNotConst: {
+		EN: "Array size must be an integer constant",
+		PT: "O tamanho do array deve ser uma constante inteira",
+	},
+	ArraySizeNegative: {
+		EN: "Array size must be non-negative, got %d",
+		PT: "O tamanho do array não pode ser negativo, encontrado %d",
+	},
+
+	// Funções e chamadas
+	ReturnOutsideFunc: {
+		EN: "Return statement outside of function",
+		PT: "Comando return fora de uma função",
+	},
+	MissingReturnValue: {
+		EN: "Non-void function must return a value",
+		PT: "Uma função não void deve retornar um valor",
+	},
+	ReturnCountMismatch: {
+		EN: "Function returns %d values, but return statement has %d",
+		PT: "A função retorna %d valores, mas o return tem %d",
+	},
+	ReturnValueMismatch: {
+		EN: "Type mismatch in return value %d. Expected %s, got %s",
+		PT: "Tipo incompatível no valor de retorno %d. Esperado %s, encontrado %s",
+	},
+	ReturnSingleValue: {
+		EN: "Function returns single value, but return statement has multiple values",
+		PT: "A função retorna um único valor, mas o return tem vários valores",
+	},
+	ReturnTypeMismatch: {
+		EN: "Type mismatch in return value. Expected %s, got %s",
+		PT: "Tipo incompatível no valor de retorno. Esperado %s, encontrado %s",
+	},
+	PositionalAfterNamed: {
+		EN: "Positional argument cannot follow named arguments in call to '%s'",
+		PT: "Um argumento posicional não pode vir depois de argumentos nomeados na chamada a '%s'",
+	},
+	SpreadNonVariadic: {
+		EN: "Cannot spread arguments into non-variadic function '%s'",
+		PT: "Não é possível espalhar argumentos na função não variádica '%s'",
+	},
+	SpreadFixedParam: {
+		EN: "Cannot use spread argument for non-variadic parameter '%s' of '%s'",
+		PT: "Não é possível usar um argumento espalhado no parâmetro não variádico '%s' de '%s'",
+	},
+	ArgCount: {
+		EN: "%s expects %d arguments, got %d",
+		PT: "%s espera %d argumentos, recebeu %d",
+	},
+	ArgCountMax: {
+		EN: "%s expects at most %d arguments, got %d",
+		PT: "%s espera no máximo %d argumentos, recebeu %d",
+	},
+	ArgCountMin: {
+		EN: "%s expects at least %d arguments, got %d",
+		PT: "%s espera pelo menos %d argumentos, recebeu %d",
+	},
+	MissingArgument: {
+		EN: "Missing argument for parameter '%s' of '%s'",
+		PT: "Falta o argumento do parâmetro '%s' de '%s'",
+	},
+	UnknownNamedParam: {
+		EN: "%s has no parameter named '%s'",
+		PT: "%s não tem um parâmetro chamado '%s'",
+	},
+	VariadicByName: {
+		EN: "Variadic parameter '%s' cannot be passed by name",
+		PT: "O parâmetro variádico '%s' não pode ser passado pelo nome",
+	},
+	ParamSetTwice: {
+		EN: "Parameter '%s' of '%s' is set more than once",
+		PT: "O parâmetro '%s' de '%s' foi informado mais de uma vez",
+	},
+	ArgTypeMismatch: {
+		EN: "Argument %d of '%s' must be %s, got %s",
+		PT: "O argumento %d de '%s' deve ser %s, encontrado %s",
+	},
+	DefaultOrder: {
+		EN: "Parameter '%s' without a default value cannot follow parameters with defaults",
+		PT: "O parâmetro '%s' sem valor padrão não pode vir depois de parâmetros com valor padrão",
+	},
+	VariadicDefault: {
+		EN: "Variadic parameter '%s' cannot have a default value",
+		PT: "O parâmetro variádico '%s' não pode ter valor padrão",
+	},
+	DefaultNotConst: {
+		EN: "Default value of parameter '%s' must be a constant expression",
+		PT: "O valor padrão do parâmetro '%s' deve ser uma expressão constante",
+	},
+	DefaultTypeMismatch: {
+		EN: "Default value of parameter '%s' must be %s, got %s",
+		PT: "O valor padrão do parâmetro '%s' deve ser %s, encontrado %s",
+	},
+
+	// Structs e membros
+	PrivateFieldAccess: {
+		EN: "Cannot access private field '%s' of struct '%s' outside its implement block",
+		PT: "Não é possível acessar o campo privado '%s' da struct '%s' fora do seu bloco implement",
+	},
+	PrivateMethodCall: {
+		EN: "Cannot call private method '%s' of struct '%s' outside its implement block",
+		PT: "Não é possível chamar o método privado '%s' da struct '%s' fora do seu bloco implement",
+	},
+	NoMember: {
+		EN: "Struct '%s' has no field or method '%s'",
+		PT: "A struct '%s' não tem o campo ou método '%s'",
+	},
+	NoField: {
+		EN: "Struct '%s' has no field '%s'",
+		PT: "A struct '%s' não tem o campo '%s'",
+	},
+	PrivateFieldInit: {
+		EN: "Cannot set private field '%s' of struct '%s' outside its implement block; use its init constructor",
+		PT: "Não é possível definir o campo privado '%s' da struct '%s' fora do seu bloco implement; use o construtor init",
+	},
+	NoInit: {
+		EN: "Struct '%s' has no init constructor",
+		PT: "A struct '%s' não tem um construtor init",
+	},
+	SelfOutsideImpl: {
+		EN: "'self' used outside of an implement block",
+		PT: "'self' usado fora de um bloco implement",
+	},
+	MethodFieldConflict: {
+		EN: "Method '%s' conflicts with a field of struct '%s'",
+		PT: "O método '%s' conflita com um campo da struct '%s'",
+	},
+	MethodRedeclared: {
+		EN: "Method '%s' already defined for struct '%s'",
+		PT: "O método '%s' já foi definido para a struct '%s'",
+	},
+	DuplicateField: {
+		EN: "Duplicate field '%s' in struct '%s'",
+		PT: "Campo '%s' duplicado na struct '%s'",
+	},
+	ImplUnknownStruct: {
+		EN: "Cannot implement methods for unknown struct '%s'",
+		PT: "Não é possível implementar métodos para a struct desconhecida '%s'",
+	},
+
+	// Controle de fluxo e switch
+	IfCondition: {
+		EN: "Condition in 'if' must be boolean or nullable, got %s",
+		PT: "A condição do 'if' deve ser booleana ou anulável, encontrado %s",
+	},
+	WhileCondition: {
+		EN: "Condition in 'while' must be boolean or nullable",
+		PT: "A condição do 'while' deve ser booleana ou anulável",
+	},
+	DoWhileCondition: {
+		EN: "Condition in 'do-while' must be boolean",
+		PT: "A condição do 'do-while' deve ser booleana",
+	},
+	ForCondition: {
+		EN: "Condition in 'for' must be boolean",
+		PT: "A condição do 'for' deve ser booleana",
+	},
+	BreakOutside: {
+		EN: "'break' is only allowed inside loops and switches",
+		PT: "'break' só é permitido dentro de laços e switches",
+	},
+	ContinueOutside: {
+		EN: "'continue' is only allowed inside loops",
+		PT: "'continue' só é permitido dentro de laços",
+	},
+	FallthroughNotLast: {
+		EN: "'fallthrough' must be the last statement of a switch case",
+		PT: "'fallthrough' deve ser o último comando de um case",
+	},
+	SwitchNoValue: {
+		EN: "Type switch requires a value to switch on",
+		PT: "Um switch de tipos precisa de um valor",
+	},
+	DuplicateDefault: {
+		EN: "Multiple 'default' cases in switch",
+		PT: "Mais de um 'default' no switch",
+	},
+	MixedSwitchCases: {
+		EN: "Cannot mix type and value cases in switch",
+		PT: "Não é possível misturar cases de tipo e de valor no switch",
+	},
+	TypeSwitchSubject: {
+		EN: "Type switch requires an 'any' or union value, got %s",
+		PT: "Um switch de tipos exige um valor 'any' ou união, encontrado %s",
+	},
+	DuplicateTypeCase: {
+		EN: "Duplicate type %s in switch",
+		PT: "Tipo %s duplicado no switch",
+	},
+	TypeNotInUnion: {
+		EN: "Type %s is not part of %s",
+		PT: "O tipo %s não faz parte de %s",
+	},
+	SwitchCaseNotBool: {
+		EN: "Switch case must be a boolean condition, got %s",
+		PT: "O case do switch deve ser uma condição booleana, encontrado %s",
+	},
+	CaseTypeMismatch: {
+		EN: "Case type mismatch. Switch on %s, but case is %s",
+		PT: "Tipo do case incompatível. O switch é sobre %s, mas o case é %s",
+	},
+	DuplicateCase: {
+		EN: "Duplicate case %s in switch",
+		PT: "Case %s duplicado no switch",
+	},
+	FallthroughTypeSwitch: {
+		EN: "Cannot fallthrough in type switch",
+		PT: "Não é possível usar fallthrough em um switch de tipos",
+	},
+	FallthroughFinal: {
+		EN: "Cannot fallthrough the final case in switch",
+		PT: "Não é possível usar fallthrough no último case do switch",
+	},
+
+	// Concorrência
+	SharedTwice: {
+		EN: "Variable '%s' is shared with more than one spawned task; call waitAll() before spawning again",
+		PT: "A variável '%s' é compartilhada com mais de uma tarefa; chame waitAll() antes de disparar outra",
+	},
+	DataRaceWrite: {
+		EN: "Data race: '%s' is written while shared with a spawned task; call waitAll() first",
+		PT: "Condição de corrida: '%s' é alterada enquanto é compartilhada com uma tarefa; chame waitAll() antes",
+	},
+	DataRaceLoop: {
+		EN: "Data race: '%s' is shared with tasks spawned in every loop iteration; call waitAll() inside the loop",
+		PT: "Condição de corrida: '%s' é compartilhada com tarefas disparadas a cada iteração; chame waitAll() dentro do laço",
+	},
+	SelectDuplicateDefault: {
+		EN: "Multiple 'default' cases in select",
+		PT: "Mais de um 'default' no select",
+	},
+	ChannelCapacity: {
+		EN: "Channel capacity must be integer, got %s",
+		PT: "A capacidade do canal deve ser inteira, encontrado %s",
+	},
+	CannotSend: {
+		EN: "Cannot send %s to %s",
+		PT: "Não é possível enviar %s para %s",
+	},
+	ExpectedChannel: {
+		EN: "Expected channel, got %s",
+		PT: "Esperado um canal, encontrado %s",
+	},
+	CloseArgs: {
+		EN: "'close' expects 1 argument, got %d",
+		PT: "'close' espera 1 argumento, recebeu %d",
+	},
+	WaitAllArgs: {
+		EN: "'waitAll' expects no arguments, got %d",
+		PT: "'waitAll' não recebe argumentos, recebeu %d",
+	},
+	ConcurrencyOutsideFunc: {
+		EN: "'%s' is only allowed inside a function",
+		PT: "'%s' só é permitido dentro de uma função",
+	},
+	DataRaceRead: {
+		EN: "Data race: '%s' is read while shared with a spawned task; call waitAll() first",
+		PT: "Condição de corrida: '%s' é lida enquanto é compartilhada com uma tarefa; chame waitAll() antes",
+	},
+
+	// Constantes
+	ConstNotConstant: {
+		EN: "Constant '%s' must be initialized with a constant expression",
+		PT: "A constante '%s' deve ser inicializada com uma expressão constante",
+	},
+	ConstsNotConstant: {
+		EN: "Constants %s must be initialized with a constant expression",
+		PT: "As constantes %s devem ser inicializadas com uma expressão constante",
+	},
+	AssignToConst: {
+		EN: "Cannot assign to constant '%s'",
+		PT: "Não é possível atribuir à constante '%s'",
+	},
+	ConstOverflowsType: {
+		EN: "Constant %s overflows %s",
+		PT: "A constante %s excede o limite de %s",
+	},
+	TernaryCondNotBool: {
+		EN: "Ternary condition must be bool, got %s",
+		PT: "A condição do ternário deve ser bool, encontrado %s",
+	},
+	NegateOverflow: {
+		EN: "Constant overflow: -(%d) overflows %s",
+		PT: "Overflow de constante: -(%d) excede o limite de %s",
+	},
+	ConstUnaryUnsupported: {
+		EN: "Operator '%s' not supported for constant of type %s",
+		PT: "Operador '%s' não suportado para constante do tipo %s",
+	},
+	ConstNegativeShift: {
+		EN: "Negative shift count in constant expression: %d %s %d",
+		PT: "Deslocamento negativo em expressão constante: %d %s %d",
+	},
+	ConstOverflow: {
+		EN: "Constant overflow: %d %s %d overflows %s",
+		PT: "Overflow de constante: %d %s %d excede o limite de %s",
+	},
+	ConstBinaryUnsupported: {
+		EN: "Operator '%s' not supported for constants of types %s and %s",
+		PT: "Operador '%s' não suportado para constantes dos tipos %s e %s",
+	},
+	ConstDivByZero: {
+		EN: "Division by zero in constant expression: %d %s 0",
+		PT: "Divisão por zero em expressão constante: %d %s 0",
+	},
+	ConstFloatDivByZero: {
+		EN: "Division by zero in constant expression: %g / 0",
+		PT: "Divisão por zero em expressão constante: %g / 0",
+	},
+	ConstFloatOverflow: {
+		EN: "Constant overflow: %g %s %g overflows float",
+		PT: "Overflow de constante: %g %s %g excede o limite de float",
+	},
+	ConstFloatToInt: {
+		EN: "Constant overflow: %g overflows int",
+		PT: "Overflow de constante: %g excede o limite de int",
+	},
+	ConstConvertOverflow: {
+		EN: "Constant overflow: %d overflows %s",
+		PT: "Overflow de constante: %d excede o limite de %s",
+	},
+	ConstCannotConvert: {
+		EN: "Cannot convert constant of type %s to %s",
+		PT: "Não é possível converter a constante do tipo %s para %s",
+	},
+}
@@ -2,6 +2,7 @@ package ir
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/alpha/internal/parser"
 	"github.com/alpha/internal/semantic"
@@ -88,6 +89,35 @@ func IntLiteral(val int64) *Operand {
 	}
 }
 
+func FloatLiteral(val float64) *Operand {
+	return &Operand{
+		Kind:  OpLiteral,
+		Value: strconv.FormatFloat(val, 'g', -1, 64),
+		Type:  &semantic.ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "float"}},
+	}
+}
+
+func StringLiteral(val string) *Operand {
+	return &Operand{
+		Kind:  OpLiteral,
+		Value: val,
+		Type:  &semantic.ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "string"}},
+	}
+}
+
+func CharLiteral(val rune) *Operand {
+	return &Operand{
+		Kind:  OpLiteral,
+		Value: string(val),
+		Type:  &semantic.ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "char"}},
+	}
+}
+
+// ConstLiteral converte um valor constante do checker em operando literal
+func ConstLiteral(val *semantic.ConstValue) *Operand {
+	return Literal(val.String(), val.Type())
+}
+
 func BoolLiteral(val bool) *Operand {
 	strVal := "false"
 	if val {
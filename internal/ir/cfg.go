@@ -0,0 +1,499 @@
+package ir
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ============================
+// GRAFO DE FLUXO DE CONTROLE (CFG)
+// ============================
+
+// CFG é o grafo de fluxo de controle de uma função: as instruções lineares
+// divididas em blocos básicos, com dominadores, pós-dominadores e loops
+// naturais já calculados
+type CFG struct {
+	Function *Function
+	Blocks   []*BasicBlock // Em ordem de aparição; Blocks[0] é a entrada
+	Entry    *BasicBlock
+	Exit     *BasicBlock // Bloco virtual, sem instruções, sucessor de todo RET
+	Loops    []*Loop     // Loops externos antes dos internos
+
+	byLabel map[string]*BasicBlock
+	idom    map[*BasicBlock]*BasicBlock // Dominador imediato (nil na entrada e em blocos inalcançáveis)
+	ipdom   map[*BasicBlock]*BasicBlock // Pós-dominador imediato (nil na saída e em blocos sem saída)
+}
+
+// Loop é um loop natural: um cabeçalho que domina todos os blocos do corpo e
+// pelo menos uma aresta de volta (latch -> cabeçalho)
+type Loop struct {
+	Header  *BasicBlock
+	Blocks  []*BasicBlock // Inclui o cabeçalho, em ordem de aparição
+	Latches []*BasicBlock // Origens das arestas de volta
+	Parent  *Loop         // Loop imediatamente externo (nil no nível mais alto)
+	Depth   int           // 1 para loops de nível mais alto
+}
+
+// Contains indica se o bloco pertence ao corpo do loop
+func (l *Loop) Contains(b *BasicBlock) bool {
+	return containsBlock(l.Blocks, b)
+}
+
+// isTerminator indica se a instrução encerra um bloco básico
+func isTerminator(op OpCode) bool {
+	switch op {
+	case JMP, JMP_TRUE, JMP_FALSE, RET, SWITCH, SELECT:
+		return true
+	}
+	return false
+}
+
+// BuildCFG divide as instruções da função em blocos básicos, liga os blocos
+// pelos saltos e calcula dominadores, pós-dominadores e loops naturais.
+// As instruções da função não são alteradas: cada bloco aponta para as
+// mesmas instruções, e os LABEL continuam como primeira instrução do bloco
+func BuildCFG(fn *Function) *CFG {
+	cfg := &CFG{Function: fn}
+	cfg.splitBlocks()
+	cfg.connectBlocks()
+	cfg.idom = computeDominators(cfg.Entry, func(b *BasicBlock) []*BasicBlock { return b.Successors }, func(b *BasicBlock) []*BasicBlock { return b.Predecessors })
+	cfg.ipdom = computeDominators(cfg.Exit, func(b *BasicBlock) []*BasicBlock { return b.Predecessors }, func(b *BasicBlock) []*BasicBlock { return b.Successors })
+	cfg.findLoops()
+	return cfg
+}
+
+// splitBlocks inicia um bloco novo em cada LABEL e após cada salto ou RET
+func (c *CFG) splitBlocks() {
+	var current *BasicBlock
+	for _, instr := range c.Function.Instructions {
+		if current == nil || instr.Op == LABEL {
+			current = &BasicBlock{}
+			switch {
+			case instr.Op == LABEL && instr.Arg1 != nil:
+				current.Label = instr.Arg1.Value
+			case len(c.Blocks) == 0:
+				current.Label = "entry"
+			default:
+				current.Label = fmt.Sprintf("bb%d", len(c.Blocks))
+			}
+			c.Blocks = append(c.Blocks, current)
+		}
+
+		current.Instructions = append(current.Instructions, instr)
+		if isTerminator(instr.Op) {
+			current = nil
+		}
+	}
+
+	// Uma função sem instruções ainda tem um bloco de entrada (vazio)
+	if len(c.Blocks) == 0 {
+		c.Blocks = append(c.Blocks, &BasicBlock{Label: "entry"})
+	}
+	c.Entry = c.Blocks[0]
+	c.Exit = &BasicBlock{Label: "exit"}
+}
+
+// connectBlocks cria as arestas a partir da última instrução de cada bloco
+func (c *CFG) connectBlocks() {
+	c.byLabel = make(map[string]*BasicBlock, len(c.Blocks))
+	for _, b := range c.Blocks {
+		if first := b.first(); first != nil && first.Op == LABEL && first.Arg1 != nil {
+			c.byLabel[first.Arg1.Value] = b
+		}
+	}
+
+	for i, b := range c.Blocks {
+		// Sem salto no fim, o fluxo segue para o próximo bloco ou sai da função
+		next := c.Exit
+		if i+1 < len(c.Blocks) {
+			next = c.Blocks[i+1]
+		}
+		jump := func(label *Operand) {
+			if label == nil {
+				return
+			}
+			if target, ok := c.byLabel[label.Value]; ok {
+				link(b, target)
+			}
+		}
+
+		last := b.last()
+		if last == nil {
+			link(b, next)
+			continue
+		}
+
+		switch last.Op {
+		case JMP:
+			jump(last.Arg1)
+		case JMP_TRUE, JMP_FALSE:
+			jump(last.Arg2)
+			link(b, next)
+		case RET:
+			link(b, c.Exit)
+		case SWITCH:
+			hasDefault := false
+			for _, sc := range last.Switch {
+				jump(sc.Label)
+				hasDefault = hasDefault || sc.IsDefault()
+			}
+			if !hasDefault {
+				link(b, next)
+			}
+		case SELECT:
+			for _, sc := range last.Select {
+				jump(sc.Label)
+			}
+		default:
+			link(b, next)
+		}
+	}
+}
+
+// Block retorna o bloco que começa com o label (nil se nenhum)
+func (c *CFG) Block(label string) *BasicBlock {
+	return c.byLabel[label]
+}
+
+// link adiciona a aresta from -> to, sem duplicar arestas
+func link(from, to *BasicBlock) {
+	for _, s := range from.Successors {
+		if s == to {
+			return
+		}
+	}
+	from.Successors = append(from.Successors, to)
+	to.Predecessors = append(to.Predecessors, from)
+}
+
+func (b *BasicBlock) first() *Instruction {
+	if len(b.Instructions) == 0 {
+		return nil
+	}
+	return b.Instructions[0]
+}
+
+func (b *BasicBlock) last() *Instruction {
+	if len(b.Instructions) == 0 {
+		return nil
+	}
+	return b.Instructions[len(b.Instructions)-1]
+}
+
+// ============================
+// DOMINADORES
+// ============================
+
+// computeDominators calcula o dominador imediato de cada bloco alcançável a
+// partir de root com o algoritmo iterativo de Cooper, Harvey e Kennedy.
+// Com succs e preds invertidos (partindo da saída) o resultado são os
+// pós-dominadores
+func computeDominators(root *BasicBlock, succs, preds func(*BasicBlock) []*BasicBlock) map[*BasicBlock]*BasicBlock {
+	// Numeração em pós-ordem a partir da raiz
+	order := make(map[*BasicBlock]int)
+	var postorder []*BasicBlock
+	var visit func(b *BasicBlock)
+	visit = func(b *BasicBlock) {
+		order[b] = -1
+		for _, s := range succs(b) {
+			if _, seen := order[s]; !seen {
+				visit(s)
+			}
+		}
+		order[b] = len(postorder)
+		postorder = append(postorder, b)
+	}
+	visit(root)
+
+	idom := map[*BasicBlock]*BasicBlock{root: root}
+	intersect := func(a, b *BasicBlock) *BasicBlock {
+		for a != b {
+			for order[a] < order[b] {
+				a = idom[a]
+			}
+			for order[b] < order[a] {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+
+	for changed := true; changed; {
+		changed = false
+		// Pós-ordem reversa, pulando a raiz
+		for i := len(postorder) - 2; i >= 0; i-- {
+			b := postorder[i]
+			var newIdom *BasicBlock
+			for _, p := range preds(b) {
+				if _, done := idom[p]; !done {
+					continue
+				}
+				if newIdom == nil {
+					newIdom = p
+				} else {
+					newIdom = intersect(p, newIdom)
+				}
+			}
+			if newIdom != nil && idom[b] != newIdom {
+				idom[b] = newIdom
+				changed = true
+			}
+		}
+	}
+
+	// A raiz não tem dominador imediato
+	delete(idom, root)
+	return idom
+}
+
+// Idom retorna o dominador imediato do bloco (nil na entrada e em blocos
+// inalcançáveis)
+func (c *CFG) Idom(b *BasicBlock) *BasicBlock {
+	return c.idom[b]
+}
+
+// IPdom retorna o pós-dominador imediato do bloco (nil na saída e em blocos
+// que nunca chegam à saída, como o corpo de um loop infinito)
+func (c *CFG) IPdom(b *BasicBlock) *BasicBlock {
+	return c.ipdom[b]
+}
+
+// Reachable indica se o bloco pode ser executado a partir da entrada
+func (c *CFG) Reachable(b *BasicBlock) bool {
+	return b == c.Entry || c.idom[b] != nil
+}
+
+// Dominates indica se todo caminho da entrada até b passa por a
+// (todo bloco domina a si mesmo)
+func (c *CFG) Dominates(a, b *BasicBlock) bool {
+	if !c.Reachable(b) {
+		return false
+	}
+	for ; b != nil; b = c.idom[b] {
+		if b == a {
+			return true
+		}
+	}
+	return false
+}
+
+// PostDominates indica se todo caminho de b até a saída passa por a
+func (c *CFG) PostDominates(a, b *BasicBlock) bool {
+	if b != c.Exit && c.ipdom[b] == nil {
+		return false
+	}
+	for ; b != nil; b = c.ipdom[b] {
+		if b == a {
+			return true
+		}
+	}
+	return false
+}
+
+// DomChildren retorna os filhos de cada bloco na árvore de dominadores, na
+// ordem dos blocos
+func (c *CFG) DomChildren() map[*BasicBlock][]*BasicBlock {
+	children := make(map[*BasicBlock][]*BasicBlock)
+	for _, b := range c.Blocks {
+		if idom := c.idom[b]; idom != nil {
+			children[idom] = append(children[idom], b)
+		}
+	}
+	return children
+}
+
+// DominanceFrontier calcula a fronteira de dominância de cada bloco: os
+// blocos onde a dominância dele termina (ele domina um predecessor, mas não
+// o próprio bloco). É onde caminhos com definições diferentes se encontram
+func (c *CFG) DominanceFrontier() map[*BasicBlock][]*BasicBlock {
+	frontier := make(map[*BasicBlock][]*BasicBlock)
+	for _, b := range c.Blocks {
+		if len(b.Predecessors) < 2 || !c.Reachable(b) {
+			continue
+		}
+		for _, p := range b.Predecessors {
+			for runner := p; runner != nil && runner != c.idom[b] && c.Reachable(runner); runner = c.idom[runner] {
+				if !containsBlock(frontier[runner], b) {
+					frontier[runner] = append(frontier[runner], b)
+				}
+			}
+		}
+	}
+	return frontier
+}
+
+func containsBlock(blocks []*BasicBlock, b *BasicBlock) bool {
+	for _, block := range blocks {
+		if block == b {
+			return true
+		}
+	}
+	return false
+}
+
+// ============================
+// LOOPS NATURAIS
+// ============================
+
+// findLoops encontra as arestas de volta (b -> h com h dominando b) e monta o
+// corpo de cada loop. Arestas de volta para o mesmo cabeçalho formam um só loop
+func (c *CFG) findLoops() {
+	byHeader := make(map[*BasicBlock]*Loop)
+	for _, b := range c.Blocks {
+		for _, h := range b.Successors {
+			if !c.Dominates(h, b) {
+				continue
+			}
+			loop, ok := byHeader[h]
+			if !ok {
+				loop = &Loop{Header: h}
+				byHeader[h] = loop
+				c.Loops = append(c.Loops, loop)
+			}
+			loop.Latches = append(loop.Latches, b)
+		}
+	}
+
+	index := make(map[*BasicBlock]int, len(c.Blocks))
+	for i, b := range c.Blocks {
+		index[b] = i
+	}
+
+	for _, loop := range c.Loops {
+		// Corpo: o cabeçalho mais tudo que chega a um latch sem passar por ele
+		body := map[*BasicBlock]bool{loop.Header: true}
+		work := append([]*BasicBlock(nil), loop.Latches...)
+		for len(work) > 0 {
+			b := work[len(work)-1]
+			work = work[:len(work)-1]
+			if body[b] {
+				continue
+			}
+			body[b] = true
+			for _, p := range b.Predecessors {
+				if c.Reachable(p) {
+					work = append(work, p)
+				}
+			}
+		}
+		for b := range body {
+			loop.Blocks = append(loop.Blocks, b)
+		}
+		sort.Slice(loop.Blocks, func(i, j int) bool { return index[loop.Blocks[i]] < index[loop.Blocks[j]] })
+	}
+
+	// Externos primeiro: um loop externo tem mais blocos que os internos
+	sort.SliceStable(c.Loops, func(i, j int) bool { return len(c.Loops[i].Blocks) > len(c.Loops[j].Blocks) })
+	for i, loop := range c.Loops {
+		loop.Depth = 1
+		for j := i - 1; j >= 0; j-- {
+			if c.Loops[j].Contains(loop.Header) {
+				loop.Parent = c.Loops[j]
+				loop.Depth = c.Loops[j].Depth + 1
+				break
+			}
+		}
+	}
+}
+
+// LoopOf retorna o loop mais interno que contém o bloco (nil fora de loops)
+func (c *CFG) LoopOf(b *BasicBlock) *Loop {
+	var inner *Loop
+	for _, loop := range c.Loops {
+		if loop.Contains(b) && (inner == nil || loop.Depth > inner.Depth) {
+			inner = loop
+		}
+	}
+	return inner
+}
+
+// ============================
+// EXPORTAÇÃO PARA GRAPHVIZ
+// ============================
+
+// DOT gera os grafos das funções no formato DOT do Graphviz, em um único
+// digraph com um cluster por função, para que 'dot -Tpng' desenhe todas em
+// uma imagem. Cabeçalhos de loop ficam destacados e arestas de volta são
+// tracejadas
+func DOT(cfgs ...*CFG) string {
+	var sb strings.Builder
+	sb.WriteString("digraph \"cfg\" {\n")
+	sb.WriteString("\tnode [shape=box, fontname=\"monospace\"];\n")
+	for _, c := range cfgs {
+		c.writeCluster(&sb)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// writeCluster escreve o grafo da função como um subgraph "cluster_<nome>".
+// Os nós levam o nome da função na frente do rótulo do bloco, porque rótulos
+// como "entry" se repetem entre funções
+func (c *CFG) writeCluster(sb *strings.Builder) {
+	name := c.Function.Name
+	if c.Function.Receiver != "" {
+		name = c.Function.Receiver + "." + name
+	}
+	node := func(b *BasicBlock) string {
+		return fmt.Sprintf("%q", name+"."+b.Label)
+	}
+
+	sb.WriteString(fmt.Sprintf("\tsubgraph %q {\n", "cluster_"+name))
+	sb.WriteString(fmt.Sprintf("\t\tlabel=%q;\n", name))
+
+	headers := make(map[*BasicBlock]*Loop)
+	for _, loop := range c.Loops {
+		headers[loop.Header] = loop
+	}
+
+	for _, b := range append(c.Blocks, c.Exit) {
+		var label strings.Builder
+		label.WriteString(b.Label)
+		if idom := c.idom[b]; idom != nil && b != c.Exit {
+			label.WriteString(fmt.Sprintf("  (idom: %s)", idom.Label))
+		}
+		if loop, ok := headers[b]; ok {
+			label.WriteString(fmt.Sprintf("  [loop, nível %d]", loop.Depth))
+		}
+		label.WriteString(`\l`)
+		for _, instr := range b.Instructions {
+			if instr.Op == LABEL {
+				continue
+			}
+			label.WriteString("  " + dotEscape(instr.String()) + `\l`)
+		}
+
+		attrs := fmt.Sprintf("label=\"%s\"", label.String())
+		switch {
+		case b == c.Entry:
+			attrs += ", style=bold"
+		case b == c.Exit:
+			attrs += ", shape=oval"
+		case !c.Reachable(b):
+			attrs += ", style=dashed, color=gray, fontcolor=gray"
+		}
+		if _, ok := headers[b]; ok {
+			attrs += ", color=blue"
+		}
+		sb.WriteString(fmt.Sprintf("\t\t%s [%s];\n", node(b), attrs))
+	}
+
+	for _, b := range c.Blocks {
+		for _, s := range b.Successors {
+			if loop, ok := headers[s]; ok && loop.Contains(b) && c.Dominates(s, b) {
+				sb.WriteString(fmt.Sprintf("\t\t%s -> %s [style=dashed, color=blue];\n", node(b), node(s)))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\t\t%s -> %s;\n", node(b), node(s)))
+		}
+	}
+
+	sb.WriteString("\t}\n")
+}
+
+// dotEscape protege aspas e barras invertidas dentro de um rótulo DOT
+func dotEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
@@ -0,0 +1,219 @@
+package ir
+
+import (
+	"strings"
+	"testing"
+)
+
+// nestedLoops tem dois loops aninhados e um bloco que nada alcança
+const nestedLoops = `module main
+
+func count(n:int) int {
+  i:int = ALLOCA type:int
+  j:int = ALLOCA type:int
+  i:int = MOV 0
+.outer:
+  %t0:bool = LT i:int, n:int
+  JMP_FALSE %t0:bool, .done
+  j:int = MOV 0
+.inner:
+  %t1:bool = LT j:int, i:int
+  JMP_FALSE %t1:bool, .inner_end
+.inner_body:
+  j:int = ADD j:int, 1
+  JMP .inner
+.inner_end:
+  i:int = ADD i:int, 1
+  JMP .outer
+.done:
+  RET i:int
+.dead:
+  RET 0
+}
+`
+
+// parseOnly lê um módulo no formato textual e retorna a única função dele
+func parseOnly(t *testing.T, src string) *Function {
+	t.Helper()
+	m, err := ParseModule(src)
+	if err != nil {
+		t.Fatalf("ParseModule: %v", err)
+	}
+	if len(m.Functions) != 1 {
+		t.Fatalf("got %d functions, want 1", len(m.Functions))
+	}
+	return m.Functions[0]
+}
+
+// blocks busca os blocos pelo label
+func blocks(t *testing.T, cfg *CFG, labels ...string) []*BasicBlock {
+	t.Helper()
+	found := make([]*BasicBlock, len(labels))
+	for i, label := range labels {
+		if found[i] = cfg.Block(label); found[i] == nil {
+			t.Fatalf("no block %s", label)
+		}
+	}
+	return found
+}
+
+func TestCFGEdgesAndReachability(t *testing.T) {
+	cfg := BuildCFG(parseOnly(t, nestedLoops))
+	b := blocks(t, cfg, "outer", "inner", "inner_body", "inner_end", "done", "dead")
+	outer, inner, body, end, done, dead := b[0], b[1], b[2], b[3], b[4], b[5]
+
+	tests := []struct {
+		from *BasicBlock
+		to   []*BasicBlock
+	}{
+		{outer, []*BasicBlock{done}},
+		{inner, []*BasicBlock{body, end}},
+		{body, []*BasicBlock{inner}},
+		{end, []*BasicBlock{outer}},
+		{done, []*BasicBlock{cfg.Exit}},
+	}
+	for _, tt := range tests {
+		for _, to := range tt.to {
+			if !containsBlock(tt.from.Successors, to) {
+				t.Errorf("missing edge %s -> %s", tt.from.Label, to.Label)
+			}
+			if !containsBlock(to.Predecessors, tt.from) {
+				t.Errorf("%s is not a predecessor of %s", tt.from.Label, to.Label)
+			}
+		}
+	}
+
+	for _, blk := range []*BasicBlock{cfg.Entry, outer, inner, body, end, done} {
+		if !cfg.Reachable(blk) {
+			t.Errorf("%s should be reachable", blk.Label)
+		}
+	}
+	if cfg.Reachable(dead) {
+		t.Errorf("dead should be unreachable")
+	}
+}
+
+func TestDominators(t *testing.T) {
+	cfg := BuildCFG(parseOnly(t, nestedLoops))
+	b := blocks(t, cfg, "outer", "inner", "inner_body", "inner_end", "done", "dead")
+	outer, inner, body, end, done, dead := b[0], b[1], b[2], b[3], b[4], b[5]
+
+	idoms := map[*BasicBlock]*BasicBlock{
+		outer: cfg.Entry,
+		body:  inner,
+		end:   inner,
+		done:  outer,
+		dead:  nil,
+	}
+	for blk, want := range idoms {
+		if got := cfg.Idom(blk); got != want {
+			t.Errorf("Idom(%s) = %v, want %v", blk.Label, label(got), label(want))
+		}
+	}
+
+	if !cfg.Dominates(outer, end) || !cfg.Dominates(inner, inner) {
+		t.Errorf("outer should dominate inner_end and inner itself")
+	}
+	if cfg.Dominates(inner, done) || cfg.Dominates(body, end) {
+		t.Errorf("inner must not dominate done, nor inner_body dominate inner_end")
+	}
+	if !cfg.PostDominates(done, outer) || !cfg.PostDominates(end, body) {
+		t.Errorf("done should post-dominate outer and inner_end post-dominate inner_body")
+	}
+
+	df := cfg.DominanceFrontier()
+	if !containsBlock(df[end], outer) || !containsBlock(df[body], inner) {
+		t.Errorf("dominance frontier: DF(inner_end) = %v, DF(inner_body) = %v", labels(df[end]), labels(df[body]))
+	}
+}
+
+func TestNaturalLoops(t *testing.T) {
+	cfg := BuildCFG(parseOnly(t, nestedLoops))
+	b := blocks(t, cfg, "outer", "inner", "inner_body", "inner_end", "done")
+	outer, inner, body, end, done := b[0], b[1], b[2], b[3], b[4]
+
+	if len(cfg.Loops) != 2 {
+		t.Fatalf("got %d loops, want 2", len(cfg.Loops))
+	}
+	outerLoop, innerLoop := cfg.Loops[0], cfg.Loops[1]
+	if outerLoop.Header != outer || outerLoop.Depth != 1 || outerLoop.Parent != nil {
+		t.Errorf("outer loop: header %s, depth %d", outerLoop.Header.Label, outerLoop.Depth)
+	}
+	if innerLoop.Header != inner || innerLoop.Depth != 2 || innerLoop.Parent != outerLoop {
+		t.Errorf("inner loop: header %s, depth %d", innerLoop.Header.Label, innerLoop.Depth)
+	}
+	if len(innerLoop.Latches) != 1 || innerLoop.Latches[0] != body {
+		t.Errorf("inner loop latches = %v, want [inner_body]", labels(innerLoop.Latches))
+	}
+	if len(outerLoop.Latches) != 1 || outerLoop.Latches[0] != end {
+		t.Errorf("outer loop latches = %v, want [inner_end]", labels(outerLoop.Latches))
+	}
+
+	if !outerLoop.Contains(end) || innerLoop.Contains(end) || outerLoop.Contains(done) {
+		t.Errorf("loop bodies: outer %v, inner %v", labels(outerLoop.Blocks), labels(innerLoop.Blocks))
+	}
+	if cfg.LoopOf(body) != innerLoop || cfg.LoopOf(end) != outerLoop || cfg.LoopOf(done) != nil {
+		t.Errorf("LoopOf gives the wrong innermost loop")
+	}
+}
+
+func TestCFGDOT(t *testing.T) {
+	dot := DOT(BuildCFG(parseOnly(t, nestedLoops)))
+	for _, want := range []string{`digraph "cfg" {`, `subgraph "cluster_count" {`, `"count.inner_body" -> "count.inner" [style=dashed`, "style=dashed"} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output lacks %q:\n%s", want, dot)
+		}
+	}
+	if strings.Contains(dot, "\033") {
+		t.Errorf("DOT output has terminal escape codes")
+	}
+}
+
+func TestCFGDOTOneGraph(t *testing.T) {
+	m, err := ParseModule(`module main
+
+func first(n:int) int {
+  JMP .done
+.done:
+  RET n:int
+}
+
+func second(n:int) int {
+  JMP .done
+.done:
+  RET 0
+}
+`)
+	if err != nil {
+		t.Fatalf("ParseModule: %v", err)
+	}
+	var cfgs []*CFG
+	for _, fn := range m.Functions {
+		cfgs = append(cfgs, BuildCFG(fn))
+	}
+
+	dot := DOT(cfgs...)
+	if n := strings.Count(dot, "digraph"); n != 1 {
+		t.Errorf("got %d digraphs, want 1:\n%s", n, dot)
+	}
+	for _, want := range []string{`subgraph "cluster_first" {`, `subgraph "cluster_second" {`, `"first.done" [`, `"second.done" [`} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output lacks %q:\n%s", want, dot)
+		}
+	}
+}
+
+func label(b *BasicBlock) string {
+	if b == nil {
+		return "<nil>"
+	}
+	return b.Label
+}
+
+func labels(bs []*BasicBlock) []string {
+	names := make([]string, len(bs))
+	for i, b := range bs {
+		names[i] = label(b)
+	}
+	return names
+}
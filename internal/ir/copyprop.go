@@ -0,0 +1,224 @@
+package ir
+
+import "github.com/alpha/internal/semantic"
+
+// ============================
+// PROPAGAÇÃO DE CÓPIAS E CONSTANTES
+// ============================
+
+// PropagateCopies troca os usos de d depois de uma cópia ("d = MOV s" ou
+// "STORE d, s") pelo próprio s enquanto a cópia valer: ela foi executada em
+// todos os caminhos até o uso e nem d nem s foram reescritos desde então
+// (cópias disponíveis, um fluxo de dados para frente sobre o CFG). Funciona
+// com e sem SSA; as cópias que ficam sem uso saem no passe dead-code
+func (o *Optimizer) PropagateCopies(fn *Function) {
+	o.propagate(fn, false)
+}
+
+// PropagateConstants é PropagateCopies só para cópias de literais
+func (o *Optimizer) PropagateConstants(fn *Function) {
+	o.propagate(fn, true)
+}
+
+// copySet associa cada destino de cópia (pelo valueKey) à sua origem
+type copySet map[string]*Operand
+
+type propagation struct {
+	fn           *Function
+	vars         map[string]semantic.Type // Variáveis que só mudam nas instruções da função
+	types        *folder                  // Inferência de tipo de temporários
+	literalsOnly bool
+}
+
+func (o *Optimizer) propagate(fn *Function, literalsOnly bool) {
+	p := &propagation{
+		fn:           fn,
+		vars:         promotableVars(fn, o.globals()),
+		types:        newFolder(fn),
+		literalsOnly: literalsOnly,
+	}
+	cfg := BuildCFG(fn)
+
+	// Cópias válidas na saída de cada bloco (nil = ainda não calculado, o que
+	// na interseção vale como "todas")
+	out := make(map[*BasicBlock]copySet)
+	blockIn := func(b *BasicBlock) copySet {
+		var in copySet
+		if b != cfg.Entry {
+			for _, pred := range b.Predecessors {
+				if !cfg.Reachable(pred) || out[pred] == nil {
+					continue
+				}
+				if in == nil {
+					in = make(copySet)
+					for d, s := range out[pred] {
+						in[d] = s
+					}
+					continue
+				}
+				for d, s := range in {
+					if other := out[pred][d]; other == nil || operandKey(other) != operandKey(s) {
+						delete(in, d)
+					}
+				}
+			}
+		}
+		if in == nil {
+			in = make(copySet)
+		}
+		return in
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, b := range cfg.Blocks {
+			if !cfg.Reachable(b) {
+				continue
+			}
+			copies := blockIn(b)
+			for _, instr := range b.Instructions {
+				p.transfer(copies, instr)
+			}
+			if !sameCopies(out[b], copies) {
+				out[b] = copies
+				changed = true
+			}
+		}
+	}
+
+	for _, b := range cfg.Blocks {
+		if !cfg.Reachable(b) {
+			continue
+		}
+		copies := blockIn(b)
+		for _, instr := range b.Instructions {
+			if instr.Op == PHI {
+				// Cada valor da PHI é lido no fim do seu predecessor
+				for j, arg := range instr.Args {
+					if pred := cfg.Block(instr.From[j].Value); pred != nil && out[pred] != nil {
+						instr.Args[j] = p.lookup(out[pred], arg)
+					}
+				}
+			} else {
+				// O receptor de um método pode ser alterado pela chamada: trocá-lo
+				// por outra variável mudaria qual delas é alterada
+				receiver := methodReceiver(instr)
+				instr.replaceUses(func(op *Operand) *Operand {
+					if op == nil || op == receiver {
+						return op
+					}
+					return p.lookup(copies, op)
+				})
+			}
+			p.transfer(copies, instr)
+		}
+	}
+}
+
+// transfer atualiza as cópias válidas depois da instrução
+func (p *propagation) transfer(copies copySet, instr *Instruction) {
+	def := instr.Def()
+	var src *Operand
+	switch {
+	case instr.Op == MOV:
+		src = instr.Arg1
+	case instr.Op == STORE && def != nil:
+		src = instr.Arg2
+	}
+	if src != nil {
+		src = p.lookup(copies, src)
+	}
+
+	killed := []*Operand{def, methodReceiver(instr)}
+	for _, c := range instr.Select {
+		killed = append(killed, c.Value)
+	}
+	for _, op := range killed {
+		key, ok := valueKey(op)
+		if !ok {
+			continue
+		}
+		delete(copies, key)
+		for d, s := range copies {
+			if k, ok := valueKey(s); ok && k == key {
+				delete(copies, d)
+			}
+		}
+	}
+
+	if src != nil && p.copyable(def, src) {
+		key, _ := valueKey(def)
+		copies[key] = src
+	}
+}
+
+// copyable indica se usos de d podem ser trocados por s: os dois só mudam
+// nas instruções da função e têm o mesmo tipo (uma cópia entre tipos
+// diferentes é uma conversão, como int para float ou para any)
+func (p *propagation) copyable(d, s *Operand) bool {
+	if !trackedValue(p.fn, p.vars, d) {
+		return false
+	}
+	if s.Kind != OpLiteral {
+		if p.literalsOnly || !trackedValue(p.fn, p.vars, s) {
+			return false
+		}
+		if k, _ := valueKey(s); k == operandKey(d) {
+			return false
+		}
+	}
+
+	dt, st := p.typeOf(d), p.typeOf(s)
+	return dt == st && dt != "" || dt == "" && d.Kind == OpTemp
+}
+
+func (p *propagation) typeOf(op *Operand) string {
+	if op.Kind == OpVar && op.Type == nil {
+		for _, name := range []string{op.Value, SSABase(op.Value)} {
+			if t := p.vars[name]; t != nil {
+				return semantic.StringifyType(t)
+			}
+		}
+		return ""
+	}
+	return p.types.typeOf(op)
+}
+
+// lookup retorna a origem da cópia que vale para op (ou o próprio op)
+func (p *propagation) lookup(copies copySet, op *Operand) *Operand {
+	if key, ok := valueKey(op); ok {
+		if src := copies[key]; src != nil {
+			return src
+		}
+	}
+	return op
+}
+
+func sameCopies(a, b copySet) bool {
+	if a == nil || len(a) != len(b) {
+		return false
+	}
+	for d, s := range a {
+		if other := b[d]; other == nil || operandKey(other) != operandKey(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// operandKey identifica um operando nas comparações: literais pelo texto e
+// pelo tipo, variáveis e temporários pelo valueKey
+func operandKey(op *Operand) string {
+	if key, ok := valueKey(op); ok {
+		return key
+	}
+	return formatOperand(op)
+}
+
+// methodReceiver retorna o receptor de uma chamada de método (nil se não for)
+func methodReceiver(instr *Instruction) *Operand {
+	if instr.Op == CALL && instr.Arg1 != nil && instr.Arg1.Kind == OpField {
+		return instr.Arg2
+	}
+	return nil
+}
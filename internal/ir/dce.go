@@ -0,0 +1,211 @@
+package ir
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/alpha/internal/semantic"
+)
+
+// ============================
+// ELIMINAÇÃO DE CÓDIGO MORTO
+// ============================
+
+// EliminateDeadCode usa a análise de variáveis vivas para remover as
+// instruções cujo resultado nunca é lido: atribuições mortas (o valor é
+// reescrito ou a função termina antes de qualquer leitura), temporários sem
+// uso e operações puras sem usuários. Só entram variáveis que a análise
+// enxerga por inteiro (as mesmas que podem ir para SSA): globais, variáveis
+// com endereço tomado e as alteradas por built-ins ficam como estão.
+// Chamadas nunca são removidas, mas perdem o resultado que ninguém lê, e a
+// declaração (ALLOCA) de uma variável sai quando nada mais a menciona
+func (o *Optimizer) EliminateDeadCode(fn *Function) {
+	d := &deadCode{fn: fn, vars: promotableVars(fn, o.globals()), defs: make(map[string]*Instruction)}
+	for _, instr := range fn.Instructions {
+		if def := instr.Def(); def != nil && def.Kind == OpTemp {
+			d.defs[def.Value] = instr
+		}
+	}
+
+	for d.sweep() {
+	}
+	d.removeUnusedAllocas()
+}
+
+// globals retorna os nomes das variáveis globais do módulo
+func (o *Optimizer) globals() map[string]bool {
+	globals := make(map[string]bool)
+	for _, instr := range o.Module.Globals {
+		if instr.Result != nil {
+			globals[instr.Result.Value] = true
+		}
+	}
+	return globals
+}
+
+type deadCode struct {
+	fn   *Function
+	vars map[string]semantic.Type // Variáveis cujas leituras a análise enxerga
+	defs map[string]*Instruction  // Uma definição de cada temporário
+}
+
+// sweep percorre cada bloco de trás para frente, partindo dos valores vivos
+// na saída, e remove as definições de valores mortos. Retorna se removeu
+// alguma coisa (os usos dela podem ter morrido também)
+func (d *deadCode) sweep() bool {
+	cfg := BuildCFG(d.fn)
+	live := ComputeLiveness(cfg)
+	dead := make(map[*Instruction]bool)
+	changed := false
+
+	for _, b := range cfg.Blocks {
+		alive := maps.Clone(live.Out[b])
+		for i := len(b.Instructions) - 1; i >= 0; i-- {
+			instr := b.Instructions[i]
+			def := instr.Def()
+			key, ok := valueKey(def)
+			if ok && !alive[key] && d.tracked(def) {
+				if d.pure(instr) {
+					dead[instr] = true
+					changed = true
+					continue
+				}
+				if instr.Op == CALL {
+					instr.Result = nil
+					changed = true
+				}
+			}
+
+			if ok {
+				delete(alive, key)
+			}
+			if instr.Op != PHI {
+				for _, op := range instr.Uses() {
+					k, _ := valueKey(op)
+					alive[k] = true
+				}
+			}
+		}
+	}
+
+	if len(dead) > 0 {
+		kept := d.fn.Instructions[:0]
+		for _, instr := range d.fn.Instructions {
+			if !dead[instr] {
+				kept = append(kept, instr)
+			}
+		}
+		d.fn.Instructions = kept
+	}
+	return changed
+}
+
+// tracked indica se todas as leituras do valor aparecem nas instruções da
+// função. Declarações (ALLOCA) nunca são removidas por aqui: o gerador de
+// código declara a variável nelas
+func (d *deadCode) tracked(def *Operand) bool {
+	return trackedValue(d.fn, d.vars, def)
+}
+
+// trackedValue indica se todas as leituras e escritas do valor aparecem nas
+// instruções da função: temporários, versões SSA e as variáveis de vars
+// (promotableVars). Globais e variáveis com endereço tomado podem mudar por
+// chamadas e ponteiros
+func trackedValue(fn *Function, vars map[string]semantic.Type, op *Operand) bool {
+	switch {
+	case op == nil:
+		return false
+	case op.Kind == OpTemp:
+		return true
+	case op.Kind != OpVar:
+		return false
+	case fn.SSA && SSABase(op.Value) != op.Value:
+		return true
+	}
+	_, ok := vars[op.Value]
+	return ok
+}
+
+// pure indica se a instrução pode ser removida quando o resultado não é
+// usado: não escreve em memória, não chama funções e não pode falhar em tempo
+// de execução (divisão por zero, shift negativo, índice fora do intervalo)
+func (d *deadCode) pure(instr *Instruction) bool {
+	switch instr.Op {
+	case MOV, STORE, NOT, EQ, NEQ, LT, GT, LE, GE, CAST, CONCAT, LEN, HAS, MAKE_MAP, ARRAY_LIT, PHI:
+		return true
+	case GET_ADDR:
+		// &a[i] falha com o índice fora do intervalo, e &p.campo com p nil
+		return false
+	case SUB, MUL, AND, OR, XOR:
+		return true
+	case ADD:
+		// add(set, valor) também é um ADD, e altera o set
+		return !maybeSet(instr.Arg1, d.defs)
+	case DIV, MOD, SHL, SHR:
+		return cannotTrap(instr)
+	}
+	return false
+}
+
+// cannotTrap indica se a divisão ou o shift tem um operando direito constante
+// que não falha: divisor diferente de zero, contagem não negativa
+func cannotTrap(instr *Instruction) bool {
+	v, ok := constOf(instr.Arg2)
+	if !ok {
+		return false
+	}
+	if instr.Op == SHL || instr.Op == SHR {
+		return v.Int >= 0
+	}
+	return v.Kind == semantic.ConstFloat && v.Float != 0 || v.Kind != semantic.ConstFloat && v.Int != 0
+}
+
+// maybeSet indica se o operando pode ser um set: tem tipo set, ou é um
+// temporário sem tipo que não vem de uma operação aritmética (defs associa
+// cada temporário a uma instrução que o define)
+func maybeSet(op *Operand, defs map[string]*Instruction) bool {
+	switch {
+	case op == nil || op.Kind == OpLiteral:
+		return false
+	case op.Type != nil:
+		return strings.HasPrefix(semantic.StringifyType(op.Type), "set<")
+	case op.Kind != OpTemp:
+		return true
+	}
+	def := defs[op.Value]
+	if def == nil {
+		return true
+	}
+	switch def.Op {
+	case SUB, MUL, DIV, MOD, AND, OR, XOR, SHL, SHR, NOT, EQ, NEQ, LT, GT, LE, GE, LEN, CONCAT:
+		return false
+	case ADD:
+		return maybeSet(def.Arg1, defs)
+	}
+	return true
+}
+
+// removeUnusedAllocas apaga a declaração de variáveis que não aparecem em
+// nenhuma outra instrução
+func (d *deadCode) removeUnusedAllocas() {
+	mentioned := make(map[string]bool)
+	for _, instr := range d.fn.Instructions {
+		if instr.Op == ALLOCA {
+			continue
+		}
+		for _, op := range append(instructionOperands(instr), instr.Def()) {
+			if op != nil && op.Kind == OpVar {
+				mentioned[SSABase(op.Value)] = true
+			}
+		}
+	}
+
+	kept := d.fn.Instructions[:0]
+	for _, instr := range d.fn.Instructions {
+		if instr.Op == ALLOCA && instr.Result != nil && !mentioned[SSABase(instr.Result.Value)] {
+			continue
+		}
+		kept = append(kept, instr)
+	}
+	d.fn.Instructions = kept
+}
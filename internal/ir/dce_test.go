@@ -0,0 +1,72 @@
+package ir
+
+import (
+	"testing"
+)
+
+// eliminated roda o dead-code no módulo dado e retorna a função f dele
+func eliminated(t *testing.T, src string) *Function {
+	t.Helper()
+	m, err := ParseModule(src)
+	if err != nil {
+		t.Fatalf("ParseModule: %v", err)
+	}
+	fn := function(t, m, "f")
+	NewOptimizer(m).EliminateDeadCode(fn)
+	return fn
+}
+
+// withBody monta um módulo com a função f(x:int, a:int[], i:int) e o corpo dado
+func withBody(body string) string {
+	return "module main\n\nglobals {\n  total:int = ALLOCA type:int\n}\n\nfunc f(x:int, a:int[], i:int) int {\n" + body + "\n}\n"
+}
+
+func TestDeadCodeRemoves(t *testing.T) {
+	tests := []struct {
+		name, body string
+		gone       string // Instrução que precisa sair
+	}{
+		{"dead store", "y:int = ALLOCA type:int\ny:int = MOV 1\ny:int = MOV x:int\nRET y:int", "y:int = MOV 1"},
+		{"unused temp", "%t0:int = ADD x:int, 1\nRET 0", "%t0:int = ADD x:int, 1"},
+		{"chain of unused temps", "%t0:int = MUL x:int, 2\n%t1:int = SUB %t0:int, 1\nRET 0", "%t0:int = MUL x:int, 2"},
+		{"division by a non-zero literal", "%t0:int = DIV x:int, 2\nRET 0", "%t0:int = DIV x:int, 2"},
+		{"unused declaration", "y:int = ALLOCA type:int\ny:int = MOV 1\nRET 0", "y:int = ALLOCA type:int"},
+		{"store after the last read", "y:int = ALLOCA type:int\ny:int = MOV x:int\n%t0:int = ADD y:int, 1\ny:int = MOV 3\nRET %t0:int", "y:int = MOV 3"},
+	}
+	for _, tt := range tests {
+		fn := eliminated(t, withBody(tt.body))
+		for _, instr := range fn.Instructions {
+			if FormatInstruction(instr) == tt.gone {
+				t.Errorf("%s: %s was kept:\n%s", tt.name, tt.gone, dump(fn))
+			}
+		}
+	}
+}
+
+func TestDeadCodeKeeps(t *testing.T) {
+	tests := []struct {
+		name, body string
+		kept       string // Instrução que precisa ficar, como fica depois do passe
+	}{
+		{"call with an unused result", "%t0:int = CALL @g (x:int)\nRET 0", "CALL @g (x:int)"},
+		{"address of an element", "%t0:*int = GET_ADDR a:int[], i:int\nRET 0", "%t0:*int = GET_ADDR a:int[], i:int"},
+		{"element read", "%t0:int = GET_INDEX a:int[], i:int\nRET 0", "%t0:int = GET_INDEX a:int[], i:int"},
+		{"division by zero", "%t0:int = DIV x:int, 0\nRET 0", "%t0:int = DIV x:int, 0"},
+		{"division by a variable", "%t0:int = DIV 10, x:int\nRET 0", "%t0:int = DIV 10, x:int"},
+		{"negative shift", "%t0:int = SHL x:int, -1\nRET 0", "%t0:int = SHL x:int, -1"},
+		{"global store", "STORE total:int, x:int\nRET 0", "STORE total:int, x:int"},
+		{"live store", "y:int = ALLOCA type:int\ny:int = MOV x:int\nRET y:int", "y:int = MOV x:int"},
+	}
+	for _, tt := range tests {
+		fn := eliminated(t, withBody(tt.body))
+		found := false
+		for _, instr := range fn.Instructions {
+			if FormatInstruction(instr) == tt.kept {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: want %s in\n%s", tt.name, tt.kept, dump(fn))
+		}
+	}
+}
@@ -0,0 +1,6 @@
+//go:build debug
+
+package ir
+
+// debugVerify liga o verificador entre os passes do otimizador
+const debugVerify = true
@@ -0,0 +1,686 @@
+package ir
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/alpha/internal/semantic"
+)
+
+// ============================
+// DOBRAMENTO DE CONSTANTES
+// ============================
+
+// binaryOperators associa cada operação binária ao operador da linguagem
+// usado por semantic.EvalConstBinary
+var binaryOperators = map[OpCode]string{
+	ADD: "+", SUB: "-", MUL: "*", DIV: "/", MOD: "%",
+	AND: "&", OR: "|", XOR: "^", SHL: "<<", SHR: ">>",
+	EQ: "==", NEQ: "!=", LT: "<", GT: ">", LE: "<=", GE: ">=",
+}
+
+// ConstantFolding calcula em tempo de compilação as operações entre literais
+// de acordo com o tipo de cada um (aritmética de int e float, concatenação de
+// strings, lógica booleana, comparações, conversões e shifts), aplica
+// identidades algébricas seguras (x*1, x+0, x*0 para inteiros, dupla negação)
+// e troca saltos com condição constante por JMP. Temporários que viram
+// literais são substituídos onde são usados, então expressões inteiras dobram.
+// Nunca sobra uma operação que o Go recusaria na compilação (divisão por um
+// literal zero, shift por um literal negativo, literais que não dobram): o
+// literal vai para um temporário e a operação fica para o tempo de execução
+func (o *Optimizer) ConstantFolding(fn *Function) {
+	f := newFolder(fn)
+	for changed := true; changed; {
+		changed = false
+		for _, instr := range fn.Instructions {
+			if f.fold(instr) {
+				changed = true
+			}
+		}
+	}
+	f.rewrite()
+
+	// Um salto que virou JMP deixa blocos sem caminho até eles
+	if f.branches {
+		o.EliminateUnreachableCode(fn)
+	}
+}
+
+// folder guarda o estado do dobramento em uma função
+type folder struct {
+	fn        *Function
+	defs      map[string]*Instruction         // Definição de cada temporário definido uma única vez
+	constants map[string]*Operand             // Temporários cujo valor é um literal
+	types     map[string]string               // Tipos já inferidos de temporários
+	removed   map[*Instruction]bool           // Saltos eliminados
+	before    map[*Instruction][]*Instruction // Cópias criadas por isolate, inseridas antes da instrução
+	branches  bool                            // Algum salto condicional foi resolvido
+}
+
+func newFolder(fn *Function) *folder {
+	f := &folder{
+		fn:        fn,
+		defs:      make(map[string]*Instruction),
+		constants: make(map[string]*Operand),
+		types:     make(map[string]string),
+		removed:   make(map[*Instruction]bool),
+		before:    make(map[*Instruction][]*Instruction),
+	}
+
+	// Temporários de ternários e operadores lógicos são escritos em mais de
+	// um caminho; esses ficam de fora
+	count := make(map[string]int)
+	for _, instr := range fn.Instructions {
+		if def := instr.Def(); def != nil && def.Kind == OpTemp {
+			count[def.Value]++
+			f.defs[def.Value] = instr
+		}
+	}
+	for name, n := range count {
+		if n > 1 {
+			delete(f.defs, name)
+		}
+	}
+	return f
+}
+
+// fold simplifica uma instrução e retorna se ela mudou
+func (f *folder) fold(instr *Instruction) bool {
+	if f.removed[instr] {
+		return false
+	}
+
+	changed := false
+	instr.replaceUses(func(op *Operand) *Operand {
+		if op != nil && op.Kind == OpTemp {
+			if lit := f.constants[op.Value]; lit != nil && !rejectsLiteral(instr, op, lit) {
+				changed = true
+				return lit
+			}
+		}
+		return op
+	})
+
+	switch instr.Op {
+	case NOT:
+		if v, ok := constOf(instr.Arg1); ok {
+			if res, err := semantic.EvalConstUnary("~", v); err == nil {
+				f.replace(instr, literalOf(res))
+				changed = true
+			}
+		} else if y := f.doubleNegation(instr); y != nil {
+			f.replace(instr, y)
+			changed = true
+		}
+
+	case CAST:
+		v, ok := constOf(instr.Arg1)
+		if ok && instr.Result != nil && instr.Result.Type != nil {
+			if res, err := semantic.ConvertConst(v, semantic.StringifyType(instr.Result.Type)); err == nil {
+				f.replace(instr, literalOf(res))
+				changed = true
+			}
+		}
+
+	case CONCAT:
+		s, all := "", true
+		for _, arg := range instr.Args {
+			v, ok := constOf(arg)
+			if !ok || v.Kind != semantic.ConstString {
+				all = false
+				break
+			}
+			s += v.Str
+		}
+		if all {
+			f.replace(instr, literalOf(&semantic.ConstValue{Kind: semantic.ConstString, Str: s}))
+			changed = true
+		}
+
+	case JMP_TRUE, JMP_FALSE:
+		if v, ok := constOf(instr.Arg1); ok && v.Kind == semantic.ConstBool {
+			f.resolveBranch(instr, v.Bool == (instr.Op == JMP_TRUE), instr.Arg2)
+			changed = true
+		}
+
+	case SWITCH:
+		if target, ok := f.switchTarget(instr); ok {
+			f.resolveBranch(instr, target != nil, target)
+			changed = true
+		}
+
+	default:
+		if _, ok := binaryOperators[instr.Op]; ok && f.foldBinary(instr) {
+			changed = true
+		}
+	}
+
+	// Um temporário que recebe um literal passa a ser o próprio literal
+	if instr.Op == MOV && instr.Result != nil && instr.Result.Kind == OpTemp &&
+		instr.Arg1 != nil && instr.Arg1.Kind == OpLiteral &&
+		f.defs[instr.Result.Value] == instr && f.constants[instr.Result.Value] == nil {
+		f.constants[instr.Result.Value] = instr.Arg1
+		changed = true
+	}
+	return changed
+}
+
+// replace transforma a instrução em "Result = MOV value"
+func (f *folder) replace(instr *Instruction, value *Operand) {
+	instr.Op = MOV
+	instr.Arg1 = value
+	instr.Arg2 = nil
+	instr.Args = nil
+}
+
+// resolveBranch troca um salto condicional já decidido por JMP (taken) ou o
+// elimina
+func (f *folder) resolveBranch(instr *Instruction, taken bool, target *Operand) {
+	if taken {
+		instr.Op = JMP
+		instr.Arg1 = target
+		instr.Arg2 = nil
+		instr.Switch = nil
+	} else {
+		f.removed[instr] = true
+	}
+	f.branches = true
+}
+
+// switchTarget decide um SWITCH sobre um literal cujos casos também são
+// literais: retorna o label escolhido (o do default se nenhum caso bater) ou
+// nil quando o fluxo segue para a próxima instrução
+func (f *folder) switchTarget(instr *Instruction) (*Operand, bool) {
+	subject, ok := constOf(instr.Arg1)
+	if !ok {
+		return nil, false
+	}
+
+	var match, fallback *Operand
+	for _, c := range instr.Switch {
+		if c.IsDefault() {
+			fallback = c.Label
+			continue
+		}
+		if len(c.Types) > 0 {
+			return nil, false
+		}
+		for _, value := range c.Values {
+			v, ok := constOf(value)
+			if !ok {
+				return nil, false
+			}
+			eq, err := semantic.EvalConstBinary("==", subject, v)
+			if err != nil {
+				return nil, false
+			}
+			if eq.Bool && match == nil {
+				match = c.Label
+			}
+		}
+	}
+	if match != nil {
+		return match, true
+	}
+	return fallback, true
+}
+
+// rewrite apaga os saltos eliminados e as definições de temporários que
+// viraram literais e não têm mais usos, e insere as cópias criadas por isolate
+func (f *folder) rewrite() {
+	used := make(map[string]bool)
+	for _, instr := range f.fn.Instructions {
+		if f.removed[instr] {
+			continue
+		}
+		for _, op := range instr.Uses() {
+			if op.Kind == OpTemp {
+				used[op.Value] = true
+			}
+		}
+	}
+	for name := range f.constants {
+		if !used[name] {
+			f.removed[f.defs[name]] = true
+		}
+	}
+
+	kept := make([]*Instruction, 0, len(f.fn.Instructions))
+	for _, instr := range f.fn.Instructions {
+		if !f.removed[instr] {
+			kept = append(kept, f.before[instr]...)
+			kept = append(kept, instr)
+		}
+	}
+	f.fn.Instructions = kept
+}
+
+// ============================
+// OPERAÇÕES BINÁRIAS
+// ============================
+
+func (f *folder) foldBinary(instr *Instruction) bool {
+	l, lok := constOf(instr.Arg1)
+	r, rok := constOf(instr.Arg2)
+	if lok && rok {
+		if res, ok := evalBinary(binaryOperators[instr.Op], l, r); ok {
+			f.replace(instr, literalOf(res))
+			return true
+		}
+	}
+	if value := f.identity(instr, l, r); value != nil {
+		f.replace(instr, value)
+		return true
+	}
+	if rejectedByGo(instr.Op, instr.Arg1, instr.Arg2) {
+		f.isolate(instr)
+		return true
+	}
+	return false
+}
+
+// isolate move para um temporário o literal que faz o Go recusar a operação:
+// o divisor ou a quantidade do shift, ou o lado esquerdo quando os dois lados
+// são literais. Assim a operação acontece em tempo de execução, com o mesmo
+// resultado (ou o mesmo pânico) do programa original
+func (f *folder) isolate(instr *Instruction) {
+	slot := &instr.Arg1
+	if trapsAsRight(instr.Op, instr.Arg2) {
+		slot = &instr.Arg2
+	}
+	lit := *slot
+	typ := lit.Type
+	if v, ok := constOf(lit); ok && typ == nil {
+		typ = v.Type()
+	}
+
+	tmp := &Operand{Kind: OpTemp, Value: fmt.Sprintf("t%d", f.fn.TempCount), Type: typ}
+	f.fn.TempCount++
+	f.before[instr] = append(f.before[instr], &Instruction{Op: MOV, Arg1: lit, Result: tmp, Line: instr.Line})
+	*slot = tmp
+}
+
+// rejectedByGo indica se o Go recusa na compilação a operação binária com
+// esses operandos: divisão ou resto por um literal zero, shift por um literal
+// negativo ou uma operação entre dois literais que não dobra (o Go calcula
+// expressões constantes e recusa as que dão overflow ou infinito)
+func rejectedByGo(op OpCode, x, y *Operand) bool {
+	name, ok := binaryOperators[op]
+	if !ok {
+		return false
+	}
+	if trapsAsRight(op, y) {
+		return true
+	}
+	l, lok := constOf(x)
+	r, rok := constOf(y)
+	if !lok || !rok {
+		return false
+	}
+	_, ok = evalBinary(name, l, r)
+	return !ok
+}
+
+// trapsAsRight indica se o literal, como lado direito da operação, é um
+// divisor zero ou uma quantidade de shift negativa
+func trapsAsRight(op OpCode, y *Operand) bool {
+	r, ok := constOf(y)
+	if !ok {
+		return false
+	}
+	switch op {
+	case DIV, MOD:
+		return r.Kind == semantic.ConstFloat && r.Float == 0 || r.Kind != semantic.ConstFloat && r.Int == 0
+	case SHL, SHR:
+		return r.Int < 0
+	}
+	return false
+}
+
+// rejectsLiteral indica se trocar o operando op da instrução pelo literal lit
+// faria o Go recusar a instrução. Passes que substituem temporários e cópias
+// por literais consultam esta função para não desfazer o trabalho de isolate
+func rejectsLiteral(instr *Instruction, op, lit *Operand) bool {
+	x, y := instr.Arg1, instr.Arg2
+	if op == x {
+		x = lit
+	}
+	if op == y {
+		y = lit
+	}
+	return rejectedByGo(instr.Op, x, y)
+}
+
+// evalBinary calcula uma operação entre dois literais. Inteiros seguem a
+// aritmética do programa gerado: overflow dá a volta em 64 bits, e em 8 bits
+// para byte e 32 para char. Divisão por zero, shifts negativos e floats que
+// dariam infinito não dobram (ok é false)
+func evalBinary(op string, l, r *semantic.ConstValue) (*semantic.ConstValue, bool) {
+	if l.Kind == semantic.ConstBool && r.Kind == semantic.ConstBool {
+		switch op {
+		case "&":
+			op = "&&"
+		case "|":
+			op = "||"
+		case "^":
+			return &semantic.ConstValue{Kind: semantic.ConstBool, Bool: l.Bool != r.Bool}, true
+		}
+	}
+
+	if kind, ok := integerKind(op, l, r); ok {
+		a, b := l.Int, r.Int
+		var n int64
+		switch op {
+		case "+":
+			n = a + b
+		case "-":
+			n = a - b
+		case "*":
+			n = a * b
+		case "/", "%":
+			if b == 0 {
+				return nil, false
+			}
+			if op == "/" {
+				n = a / b
+			} else {
+				n = a % b
+			}
+		case "&":
+			n = a & b
+		case "|":
+			n = a | b
+		case "^":
+			n = a ^ b
+		case "<<", ">>":
+			if b < 0 {
+				return nil, false
+			}
+			if op == "<<" {
+				n = a << uint64(b)
+			} else {
+				n = a >> uint64(b)
+			}
+		default:
+			res, err := semantic.EvalConstBinary(op, l, r)
+			return res, err == nil
+		}
+		switch kind {
+		case semantic.ConstByte:
+			n = int64(uint8(n))
+		case semantic.ConstChar:
+			if n = int64(int32(n)); !utf8.ValidRune(rune(n)) {
+				return nil, false
+			}
+		}
+		return &semantic.ConstValue{Kind: kind, Int: n}, true
+	}
+
+	res, err := semantic.EvalConstBinary(op, l, r)
+	if err != nil || res.Kind == semantic.ConstFloat && (math.IsInf(res.Float, 0) || math.IsNaN(res.Float)) {
+		return nil, false
+	}
+	return res, true
+}
+
+// integerKind retorna o tipo do resultado de uma operação entre inteiros
+// (int, byte ou char). Um int sem tipo próprio se adapta ao outro lado; nos
+// shifts vale o tipo do lado esquerdo
+func integerKind(op string, l, r *semantic.ConstValue) (semantic.ConstKind, bool) {
+	integer := func(v *semantic.ConstValue) bool {
+		return v.Kind == semantic.ConstInt || v.Kind == semantic.ConstByte || v.Kind == semantic.ConstChar
+	}
+	switch {
+	case !integer(l) || !integer(r):
+		return 0, false
+	case op == "<<" || op == ">>" || l.Kind == r.Kind || r.Kind == semantic.ConstInt:
+		return l.Kind, true
+	case l.Kind == semantic.ConstInt:
+		return r.Kind, true
+	}
+	return 0, false
+}
+
+// identity aplica identidades algébricas quando um dos lados é literal. Só
+// vale para inteiros: x*0 não é 0 para floats NaN ou infinitos, e x+0 pode
+// ser outra operação para strings e sets
+func (f *folder) identity(instr *Instruction, l, r *semantic.ConstValue) *Operand {
+	is := func(v *semantic.ConstValue, n int64) bool {
+		return v != nil && v.Kind == semantic.ConstInt && v.Int == n
+	}
+	x, y := instr.Arg1, instr.Arg2
+	zero := literalOf(&semantic.ConstValue{Kind: semantic.ConstInt})
+
+	switch instr.Op {
+	case ADD, OR, XOR:
+		if is(r, 0) && f.isInt(x) {
+			return x
+		}
+		if is(l, 0) && f.isInt(y) {
+			return y
+		}
+	case SUB:
+		if is(r, 0) && f.isInt(x) {
+			return x
+		}
+		if is(l, 0) {
+			return f.doubleNegation(instr)
+		}
+	case SHL, SHR:
+		if is(r, 0) && f.isInt(x) {
+			return x
+		}
+	case MUL:
+		switch {
+		case is(r, 1) && f.isInt(x):
+			return x
+		case is(l, 1) && f.isInt(y):
+			return y
+		case is(r, 0) && f.isInt(x), is(l, 0) && f.isInt(y):
+			return zero
+		}
+	case DIV:
+		if is(r, 1) && f.isInt(x) {
+			return x
+		}
+	case AND:
+		if is(r, 0) && f.isInt(x) || is(l, 0) && f.isInt(y) {
+			return zero
+		}
+	case EQ, NEQ:
+		// x == true e x != false são o próprio x; !!x é x
+		if r != nil && r.Kind == semantic.ConstBool && f.typeOf(x) == "bool" {
+			if r.Bool == (instr.Op == EQ) {
+				return x
+			}
+			if instr.Op == EQ {
+				return f.doubleNegation(instr)
+			}
+		}
+	}
+	return nil
+}
+
+// doubleNegation reconhece -(-y) (SUB 0, t com t = SUB 0, y), !!y (EQ t,
+// false com t = EQ y, false) e ~~y, retornando y
+func (f *folder) doubleNegation(instr *Instruction) *Operand {
+	inner := instr.Arg1
+	if instr.Op == SUB {
+		inner = instr.Arg2
+	}
+	if inner == nil || inner.Kind != OpTemp {
+		return nil
+	}
+	def := f.defs[inner.Value]
+	if def == nil || def.Op != instr.Op {
+		return nil
+	}
+
+	var y *Operand
+	switch instr.Op {
+	case SUB:
+		if v, ok := constOf(def.Arg1); !ok || v.Kind != semantic.ConstInt || v.Int != 0 || !f.isInt(def.Arg2) {
+			return nil
+		}
+		y = def.Arg2
+	case EQ:
+		if v, ok := constOf(def.Arg2); !ok || v.Kind != semantic.ConstBool || v.Bool || f.typeOf(def.Arg1) != "bool" {
+			return nil
+		}
+		y = def.Arg1
+	case NOT:
+		y = def.Arg1
+	}
+
+	if !f.unchanged(y, def, instr) {
+		return nil
+	}
+	return y
+}
+
+// unchanged indica se op tem o mesmo valor em from e em to. Literais,
+// temporários de definição única e versões SSA nunca mudam; outras variáveis,
+// só se nada entre as duas instruções (no mesmo bloco) puder escrevê-las
+func (f *folder) unchanged(op *Operand, from, to *Instruction) bool {
+	switch {
+	case op == nil:
+		return false
+	case op.Kind == OpLiteral:
+		return true
+	case op.Kind == OpTemp:
+		return f.defs[op.Value] != nil
+	case op.Kind != OpVar:
+		return false
+	case f.fn.SSA && SSABase(op.Value) != op.Value:
+		return true
+	}
+
+	between := false
+	for _, instr := range f.fn.Instructions {
+		switch {
+		case instr == from:
+			between = true
+		case instr == to:
+			return between
+		case !between || f.removed[instr]:
+		case instr.Op == LABEL, instr.Op == CALL, instr.Op == STORE:
+			return false
+		default:
+			if def := instr.Def(); def != nil && def.Value == op.Value {
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// ============================
+// TIPOS E LITERAIS
+// ============================
+
+func (f *folder) isInt(op *Operand) bool {
+	return f.typeOf(op) == "int"
+}
+
+// typeOf retorna o nome do tipo de um operando, inferindo o de temporários
+// sem tipo a partir da instrução que os define ("" se não souber)
+func (f *folder) typeOf(op *Operand) string {
+	switch {
+	case op == nil:
+		return ""
+	case op.Type != nil:
+		return semantic.StringifyType(op.Type)
+	case op.Kind == OpLiteral:
+		return untypedLiteralType(op.Value)
+	case op.Kind != OpTemp:
+		return ""
+	}
+
+	if t, ok := f.types[op.Value]; ok {
+		return t
+	}
+	f.types[op.Value] = "" // Evita recursão infinita em definições circulares
+
+	t := ""
+	if def := f.defs[op.Value]; def != nil {
+		switch def.Op {
+		case EQ, NEQ, LT, GT, LE, GE, HAS:
+			t = "bool"
+		case LEN:
+			t = "int"
+		case MOV, NOT:
+			t = f.typeOf(def.Arg1)
+		case ADD, SUB, MUL, DIV, MOD, AND, OR, XOR, SHL, SHR:
+			l, r := f.typeOf(def.Arg1), f.typeOf(def.Arg2)
+			switch {
+			case l == r:
+				t = l
+			case l == "float" && r == "int", l == "int" && r == "float":
+				t = "float"
+			}
+		}
+	}
+	f.types[op.Value] = t
+	return t
+}
+
+// untypedLiteralType deduz o tipo de literais criados sem tipo pelo gerador
+// (o 0 de -x, o false de !x, o 1 de i++)
+func untypedLiteralType(value string) string {
+	if value == "true" || value == "false" {
+		return "bool"
+	}
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return "int"
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return "float"
+	}
+	return ""
+}
+
+// constOf converte um literal em valor constante
+func constOf(op *Operand) (*semantic.ConstValue, bool) {
+	if op == nil || op.Kind != OpLiteral {
+		return nil, false
+	}
+	typeName := untypedLiteralType(op.Value)
+	if op.Type != nil {
+		typeName = semantic.StringifyType(op.Type)
+	}
+
+	switch typeName {
+	case "int", "byte":
+		n, err := strconv.ParseInt(op.Value, 10, 64)
+		if err != nil || typeName == "byte" && (n < 0 || n > 255) {
+			return nil, false
+		}
+		kind := semantic.ConstInt
+		if typeName == "byte" {
+			kind = semantic.ConstByte
+		}
+		return &semantic.ConstValue{Kind: kind, Int: n}, true
+	case "float":
+		x, err := strconv.ParseFloat(op.Value, 64)
+		return &semantic.ConstValue{Kind: semantic.ConstFloat, Float: x}, err == nil
+	case "bool":
+		b, err := strconv.ParseBool(op.Value)
+		return &semantic.ConstValue{Kind: semantic.ConstBool, Bool: b}, err == nil
+	case "string":
+		return &semantic.ConstValue{Kind: semantic.ConstString, Str: op.Value}, true
+	case "char":
+		c, size := utf8.DecodeRuneInString(op.Value)
+		if size == 0 || size != len(op.Value) {
+			return nil, false
+		}
+		return &semantic.ConstValue{Kind: semantic.ConstChar, Int: int64(c)}, true
+	}
+	return nil, false
+}
+
+// literalOf cria o operando literal de um valor constante
+func literalOf(v *semantic.ConstValue) *Operand {
+	return &Operand{Kind: OpLiteral, Value: v.String(), Type: v.Type()}
+}
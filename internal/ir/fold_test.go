@@ -0,0 +1,142 @@
+package ir
+
+import (
+	"strings"
+	"testing"
+)
+
+// folded dobra o corpo dado (parâmetros x:int, b:bool e y:float) e retorna
+// a função resultante
+func folded(t *testing.T, body string) *Function {
+	t.Helper()
+	fn := parseOnly(t, "module main\n\nfunc f(x:int, b:bool, y:float) int {\n"+body+"\n}\n")
+	NewOptimizer(&Module{Functions: []*Function{fn}}).ConstantFolding(fn)
+	return fn
+}
+
+// returned retorna o operando do único RET da função, seguindo as cópias
+// "%t = MOV v" que o dobramento deixa no lugar das operações simplificadas
+func returned(t *testing.T, fn *Function) string {
+	t.Helper()
+	var rets []*Instruction
+	for _, instr := range fn.Instructions {
+		if instr.Op == RET {
+			rets = append(rets, instr)
+		}
+	}
+	if len(rets) != 1 {
+		t.Fatalf("got %d RET, want 1:\n%s", len(rets), dump(fn))
+	}
+	op := rets[0].Arg1
+	for op.Kind == OpTemp {
+		def := newFolder(fn).defs[op.Value]
+		if def == nil || def.Op != MOV {
+			break
+		}
+		op = def.Arg1
+	}
+	return formatOperand(op)
+}
+
+func TestFoldIdentities(t *testing.T) {
+	tests := []struct{ body, want string }{
+		{"%t0:int = MUL x:int, 1\nRET %t0:int", "x:int"},
+		{"%t0:int = ADD 0, x:int\nRET %t0:int", "x:int"},
+		{"%t0:int = SUB x:int, 0\nRET %t0:int", "x:int"},
+		{"%t0:int = DIV x:int, 1\nRET %t0:int", "x:int"},
+		{"%t0:int = SHL x:int, 0\nRET %t0:int", "x:int"},
+		{"%t0:int = MUL x:int, 0\nRET %t0:int", "0:int"},
+		{"%t0:int = AND 0, x:int\nRET %t0:int", "0:int"},
+		{"%t0:int = SUB 0, x:int\n%t1:int = SUB 0, %t0:int\nRET %t1:int", "x:int"},
+		{"%t0:bool = EQ b:bool, false\n%t1:bool = EQ %t0:bool, false\nRET %t1:bool", "b:bool"},
+		{"%t0:bool = EQ b:bool, true\nRET %t0:bool", "b:bool"},
+		{"%t0:int = NOT x:int\n%t1:int = NOT %t0:int\nRET %t1:int", "x:int"},
+		// x*0 não é 0 para floats (NaN, infinito)
+		{"%t0:float = MUL y:float, 0\nRET %t0:float", "%t0:float"},
+		// -(-x) só se x não mudou entre as duas negações
+		{"%t0:int = SUB 0, x:int\nx:int = MOV 5\n%t1:int = SUB 0, %t0:int\nRET %t1:int", "%t1:int"},
+	}
+	for _, tt := range tests {
+		if got := returned(t, folded(t, tt.body)); got != tt.want {
+			t.Errorf("%q returns %s, want %s", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestFoldWraparound(t *testing.T) {
+	tests := []struct{ body, want string }{
+		{"%t0:int = ADD 9223372036854775807, 1\nRET %t0:int", "-9223372036854775808:int"},
+		{"%t0:int = SUB -9223372036854775808, 1\nRET %t0:int", "9223372036854775807:int"},
+		{"%t0:int = MUL 4611686018427387904, 2\nRET %t0:int", "-9223372036854775808:int"},
+		{"%t0:int = DIV -9223372036854775808, -1\nRET %t0:int", "-9223372036854775808:int"},
+		{"%t0:int = SHL 1, 64\nRET %t0:int", "0:int"},
+		{"%t0:byte = ADD 250:byte, 10:byte\nRET %t0:byte", "4:byte"},
+		{"%t0:byte = SUB 0:byte, 1\nRET %t0:byte", "255:byte"},
+		{"%t0:int = ADD 2, 3\n%t1:int = MUL %t0:int, 4\nRET %t1:int", "20:int"},
+	}
+	for _, tt := range tests {
+		if got := returned(t, folded(t, tt.body)); got != tt.want {
+			t.Errorf("%q returns %s, want %s", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestFoldKeepsTrapsForRuntime(t *testing.T) {
+	tests := []struct {
+		body string
+		op   OpCode
+	}{
+		{"%t0:int = DIV x:int, 0\nRET %t0:int", DIV},
+		{"%t0:int = MOD 1, 0\nRET %t0:int", MOD},
+		{"%t0:int = SHL x:int, -1\nRET %t0:int", SHL},
+		{"%t0:float = DIV 1.5, 0.0\nRET %t0:float", DIV},
+		{"%t0:float = MUL 1e308, 10.0\nRET %t0:float", MUL},
+		// O zero chega ao divisor pela substituição de um temporário
+		{"%t0:int = MOV 0\n%t1:int = DIV x:int, %t0:int\nRET %t1:int", DIV},
+	}
+	for _, tt := range tests {
+		fn := folded(t, tt.body)
+		at := indexOf(fn, tt.op)
+		if at < 0 {
+			t.Errorf("%q: the operation was removed:\n%s", tt.body, dump(fn))
+			continue
+		}
+		instr := fn.Instructions[at]
+		if rejectedByGo(instr.Op, instr.Arg1, instr.Arg2) {
+			t.Errorf("%q: Go would reject %s", tt.body, FormatInstruction(instr))
+		}
+		for _, op := range []*Operand{instr.Arg1, instr.Arg2} {
+			if op.Kind == OpTemp && op.Type == nil {
+				t.Errorf("%q: untyped temp in %s", tt.body, FormatInstruction(instr))
+			}
+		}
+
+		// Dobrar de novo não traz o literal de volta
+		again := dump(fn)
+		NewOptimizer(&Module{Functions: []*Function{fn}}).ConstantFolding(fn)
+		if at := indexOf(fn, tt.op); at < 0 || rejectedByGo(fn.Instructions[at].Op, fn.Instructions[at].Arg1, fn.Instructions[at].Arg2) {
+			t.Errorf("%q: second folding undid the temp:\nbefore\n%s\nafter\n%s", tt.body, again, dump(fn))
+		}
+	}
+}
+
+func TestFoldResolvesBranches(t *testing.T) {
+	tests := []struct{ body, want string }{
+		{"%t0:bool = LT 1, 2\nJMP_TRUE %t0:bool, .yes\nRET 0\n.yes:\nRET 1", "1"},
+		{"%t0:bool = LT 1, 2\nJMP_FALSE %t0:bool, .no\nRET 1\n.no:\nRET 0", "1"},
+		{"%t0:bool = EQ \"a\":string, \"b\":string\nJMP_TRUE %t0:bool, .yes\nRET 0\n.yes:\nRET 1", "0"},
+		{"SWITCH 2 [1: .one] [2, 3: .two] [default: .other]\n.one:\nRET 1\n.two:\nRET 2\n.other:\nRET 0", "2"},
+		{"SWITCH 9 [1: .one] [default: .other]\n.one:\nRET 1\n.other:\nRET 0", "0"},
+	}
+	for _, tt := range tests {
+		fn := folded(t, tt.body)
+		for _, op := range []OpCode{JMP_TRUE, JMP_FALSE, SWITCH} {
+			if indexOf(fn, op) >= 0 {
+				t.Errorf("%q: branch not resolved:\n%s", tt.body, dump(fn))
+			}
+		}
+		if got := returned(t, fn); strings.TrimSuffix(got, ":int") != tt.want {
+			t.Errorf("%q returns %s, want %s", tt.body, got, tt.want)
+		}
+	}
+}
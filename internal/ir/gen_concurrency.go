@@ -0,0 +1,128 @@
+package ir
+
+import (
+	"github.com/alpha/internal/parser"
+	"github.com/alpha/internal/semantic"
+)
+
+// ============================
+// Concorrência
+// ============================
+
+func (g *Generator) genSpawn(stmt *parser.SpawnStmt) {
+	var calleeExpr parser.Expr
+	var argExprs []parser.Expr
+
+	switch call := stmt.Call.(type) {
+	case *parser.CallExpr:
+		calleeExpr, argExprs = call.Callee, call.Args
+	case *parser.GenericCallExpr:
+		calleeExpr, argExprs = call.Callee, call.Args
+	default:
+		return
+	}
+
+	// Os argumentos são avaliados no momento do spawn, não dentro da tarefa
+	var args []*Operand
+	for _, arg := range argExprs {
+		args = append(args, g.genExpr(arg))
+	}
+
+	var callee *Operand
+	if ident, ok := calleeExpr.(*parser.Identifier); ok {
+		callee = &Operand{Kind: OpFunction, Value: ident.Name}
+	} else {
+		callee = g.genExpr(calleeExpr)
+	}
+
+	instr := g.builder.Emit(SPAWN, callee, nil, nil)
+	instr.Args = args
+}
+
+func (g *Generator) genSelect(stmt *parser.SelectStmt) {
+	endLabel := g.builder.NewLabel("select_end")
+
+	// Os canais e valores de todos os casos são avaliados antes do select
+	cases := make([]*SelectCase, 0, len(stmt.Cases))
+	for _, clause := range stmt.Cases {
+		cases = append(cases, g.genSelectComm(clause.Comm))
+	}
+
+	instr := g.builder.Emit(SELECT, nil, nil, nil)
+	instr.Select = cases
+
+	for i, clause := range stmt.Cases {
+		g.builder.EmitLabel(cases[i].Label)
+		for _, s := range clause.Body {
+			g.genStmt(s)
+		}
+		g.builder.Emit(JMP, endLabel, nil, nil)
+	}
+
+	g.builder.EmitLabel(endLabel)
+}
+
+// genSelectComm gera os operandos da operação de canal de um caso de select
+func (g *Generator) genSelectComm(comm parser.Stmt) *SelectCase {
+	label := g.builder.NewLabel("select_case")
+
+	switch s := comm.(type) {
+	case nil:
+		return &SelectCase{Kind: SelectDefault, Label: label}
+
+	case *parser.VarDecl:
+		// case var msg = <-ch: a variável é declarada antes do select
+		recv := s.Init.(*parser.ReceiveExpr)
+		ch := g.genExpr(recv.Channel)
+		typ := semantic.ToType(s.Type)
+		dest := Var(s.Name, typ)
+		g.builder.Emit(ALLOCA, &Operand{Kind: OpType, Type: typ}, nil, dest)
+		return &SelectCase{Kind: SelectRecv, Chan: ch, Value: dest, Label: label}
+
+	case *parser.ExprStmt:
+		switch e := s.Expr.(type) {
+		case *parser.SendExpr:
+			ch := g.genExpr(e.Channel)
+			val := g.genExpr(e.Value)
+			return &SelectCase{Kind: SelectSend, Chan: ch, Value: val, Label: label}
+
+		case *parser.ReceiveExpr:
+			ch := g.genExpr(e.Channel)
+			return &SelectCase{Kind: SelectRecv, Chan: ch, Label: label}
+
+		case *parser.AssignExpr:
+			recv := e.Right.(*parser.ReceiveExpr)
+			ch := g.genExpr(recv.Channel)
+			dest := g.genAddr(e.Left)
+			return &SelectCase{Kind: SelectRecv, Chan: ch, Value: dest, Label: label}
+		}
+	}
+
+	return &SelectCase{Kind: SelectDefault, Label: label}
+}
+
+func (g *Generator) genChannelExpr(e *parser.ChannelExpr) *Operand {
+	res := g.builder.NewTemp(g.resolveType(&parser.ChannelType{ElementType: e.ElementType}))
+
+	var capacity *Operand
+	if e.Capacity != nil {
+		capacity = g.genExpr(e.Capacity)
+	}
+
+	g.builder.Emit(MAKE_CHAN, capacity, nil, res)
+	return res
+}
+
+func (g *Generator) genSendExpr(e *parser.SendExpr) *Operand {
+	ch := g.genExpr(e.Channel)
+	val := g.genExpr(e.Value)
+	g.builder.Emit(SEND, ch, val, nil)
+	return val
+}
+
+func (g *Generator) genReceiveExpr(e *parser.ReceiveExpr) *Operand {
+	ch := g.genExpr(e.Channel)
+	res := g.builder.NewTemp(g.typeOf(e))
+	g.builder.Emit(RECV, ch, nil, res)
+	return res
+}
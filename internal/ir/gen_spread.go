@@ -0,0 +1,125 @@
+package ir
+
+import (
+	"github.com/alpha/internal/parser"
+	"github.com/alpha/internal/semantic"
+)
+
+// ============================
+// Variádicos e Spread
+// ============================
+
+// genCallArgs gera os argumentos de uma chamada. Em funções variádicas,
+// argumentos extras misturados com ...arrays são reunidos em um único slice,
+// passado espalhado (xs...) para o parâmetro variádico
+func (g *Generator) genCallArgs(e *parser.CallExpr) ([]*Operand, bool) {
+	fixed, variadic := 0, false
+	if g.checker != nil {
+		if slots, ok := g.checker.CallArgs[e]; ok {
+			return g.genNamedArgs(e, slots), false
+		}
+		fixed, variadic = g.checker.VariadicCalls[e]
+	}
+	if !variadic || !hasSpread(e.Args[fixed:]) {
+		var args []*Operand
+		for _, arg := range e.Args {
+			args = append(args, g.genExpr(arg))
+		}
+		return args, false
+	}
+
+	var args []*Operand
+	for _, arg := range e.Args[:fixed] {
+		args = append(args, g.genExpr(arg))
+	}
+
+	rest := e.Args[fixed:]
+	if spread, ok := rest[0].(*parser.SpreadExpr); ok && len(rest) == 1 {
+		// f(...lista): o array é repassado diretamente
+		return append(args, g.genExpr(spread.Expr)), true
+	}
+	return append(args, g.genSpreadSlice(rest, g.spreadSliceType(rest))), true
+}
+
+// genNamedArgs gera os argumentos de uma chamada com argumentos nomeados ou
+// omitidos. Os argumentos escritos são avaliados na ordem do código e depois
+// posicionados na ordem dos parâmetros; os omitidos recebem o valor padrão
+func (g *Generator) genNamedArgs(e *parser.CallExpr, slots []parser.Expr) []*Operand {
+	written := make(map[parser.Expr]*Operand, len(e.Args))
+	for _, arg := range e.Args {
+		if na, ok := arg.(*parser.NamedArg); ok {
+			arg = na.Value
+		}
+		written[arg] = g.genExpr(arg)
+	}
+
+	args := make([]*Operand, len(slots))
+	for i, slot := range slots {
+		if op, ok := written[slot]; ok {
+			args[i] = op
+		} else {
+			// Valor padrão: constante calculada pelo checker
+			args[i] = ConstLiteral(g.checker.ConstValueOf(slot))
+		}
+	}
+	return args
+}
+
+// genSpreadSlice monta um slice a partir de elementos e ...arrays: os
+// elementos iniciais viram um literal e o restante uma cadeia de appends
+func (g *Generator) genSpreadSlice(elems []parser.Expr, typ semantic.Type) *Operand {
+	var head []*Operand
+	i := 0
+	for ; i < len(elems); i++ {
+		if _, ok := elems[i].(*parser.SpreadExpr); ok {
+			break
+		}
+		head = append(head, g.genExpr(elems[i]))
+	}
+
+	res := g.builder.NewTemp(typ)
+	lit := g.builder.Emit(ARRAY_LIT, nil, nil, res)
+	lit.Args = head
+
+	for ; i < len(elems); i++ {
+		if spread, ok := elems[i].(*parser.SpreadExpr); ok {
+			instr := g.builder.Emit(APPEND, res, g.genExpr(spread.Expr), res)
+			instr.Spread = true
+		} else {
+			g.builder.Emit(APPEND, res, g.genExpr(elems[i]), res)
+		}
+	}
+	return res
+}
+
+// spreadSliceType é o tipo T[] do slice montado a partir dos ...arrays
+func (g *Generator) spreadSliceType(elems []parser.Expr) semantic.Type {
+	for _, elem := range elems {
+		if spread, ok := elem.(*parser.SpreadExpr); ok {
+			return sliceOf(g.typeOf(spread.Expr))
+		}
+	}
+	return nil
+}
+
+// sliceOf retorna a versão dinâmica (T[]) de um tipo de array
+func sliceOf(t semantic.Type) semantic.Type {
+	w, ok := t.(*semantic.ParserTypeWrapper)
+	if !ok {
+		return t
+	}
+	arr, ok := w.Type.(*parser.ArrayType)
+	if !ok {
+		return t
+	}
+	return &semantic.ParserTypeWrapper{Type: &parser.ArrayType{ElementType: arr.ElementType}}
+}
+
+func hasSpread(elems []parser.Expr) bool {
+	for _, elem := range elems {
+		if _, ok := elem.(*parser.SpreadExpr); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package ir
+
+import (
+	"github.com/alpha/internal/parser"
+	"github.com/alpha/internal/semantic"
+)
+
+// ============================
+// Strings interpoladas
+// ============================
+
+// genInterpolatedString converte cada parte de "Olá ${nome}" para string e as
+// concatena com uma única instrução CONCAT
+func (g *Generator) genInterpolatedString(e *parser.InterpolatedString) *Operand {
+	if val := g.constOperand(e); val != nil {
+		return val
+	}
+
+	stringType := &semantic.ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "string"}}
+
+	parts := make([]*Operand, 0, len(e.Parts))
+	for _, part := range e.Parts {
+		val := g.genExpr(part)
+		if partType := g.typeOf(part); semantic.StringifyType(partType) != "string" {
+			// CAST para string; Arg2 guarda o tipo de origem
+			str := g.builder.NewTemp(stringType)
+			g.builder.Emit(CAST, val, &Operand{Kind: OpType, Type: partType}, str)
+			val = str
+		}
+		parts = append(parts, val)
+	}
+
+	if len(parts) == 1 {
+		return parts[0]
+	}
+
+	res := g.builder.NewTemp(stringType)
+	instr := g.builder.Emit(CONCAT, nil, nil, res)
+	instr.Args = parts
+	return res
+}
@@ -0,0 +1,80 @@
+package ir
+
+import (
+	"github.com/alpha/internal/parser"
+	"github.com/alpha/internal/semantic"
+)
+
+// ============================
+// Structs e Métodos
+// ============================
+
+// genImpl gera o construtor (init) e os métodos de um bloco implement.
+// Cada um vira uma função com Receiver definido e 'self' como primeiro parâmetro
+func (g *Generator) genImpl(impl *parser.ImplDecl) {
+	if impl.Init != nil {
+		fn := g.genMethod(impl.TargetName, "init", true, impl.Init.Params, nil, impl.Init.Body)
+		fn.ReturnType = g.selfType(impl.TargetName)
+
+		// Construtores sem return explícito no final devolvem self
+		lastIdx := len(fn.Instructions) - 1
+		if lastIdx < 0 || fn.Instructions[lastIdx].Op != RET {
+			g.builder.CurrentFunc = fn
+			g.builder.Emit(RET, fn.Params[0], nil, nil)
+		}
+	}
+
+	for _, m := range impl.Methods {
+		g.genMethod(impl.TargetName, m.Name, !m.IsPrivate, m.Params, m.ReturnTypes, m.Body)
+	}
+}
+
+func (g *Generator) genMethod(structName, name string, exported bool, params []*parser.Param, returnTypes []parser.Type, body []parser.Stmt) *Function {
+	irFunc := &Function{
+		Name:       name,
+		Receiver:   structName,
+		IsExported: exported,
+		Variadic:   parser.IsVariadic(params),
+	}
+	if len(returnTypes) > 0 {
+		irFunc.ReturnType = semantic.ToType(returnTypes[0])
+	}
+
+	g.builder.CurrentFunc = irFunc
+
+	irFunc.Params = append(irFunc.Params, Var("self", g.selfType(structName)))
+	for _, param := range params {
+		irFunc.Params = append(irFunc.Params, Var(param.Name, semantic.ToType(param.ValueType())))
+	}
+
+	for _, stmt := range body {
+		g.genStmt(stmt)
+	}
+
+	g.builder.Module.Functions = append(g.builder.Module.Functions, irFunc)
+	return irFunc
+}
+
+// selfType é o tipo de 'self': ponteiro para a struct, para que métodos e o
+// construtor alterem a própria instância
+func (g *Generator) selfType(structName string) semantic.Type {
+	return &semantic.ParserTypeWrapper{Type: &parser.PointerType{
+		BaseType: &parser.IdentifierType{Name: structName},
+	}}
+}
+
+func (g *Generator) selfOperand() *Operand {
+	if fn := g.builder.CurrentFunc; fn != nil && fn.Receiver != "" {
+		return fn.Params[0]
+	}
+	return Var("self", nil)
+}
+
+func (g *Generator) isStruct(name string) bool {
+	for _, s := range g.builder.Module.Structs {
+		if s.Name == name {
+			return true
+		}
+	}
+	return false
+}
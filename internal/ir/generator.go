@@ -1,6 +1,9 @@
 package ir
 
 import (
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/alpha/internal/parser"
 	"github.com/alpha/internal/semantic"
 )
@@ -65,8 +68,14 @@ func (g *Generator) genGlobalStmt(stmt parser.Stmt) {
 		g.builder.Module.Name = s.Name
 	case *parser.VarDecl:
 		g.genGlobalVarDecl(s)
+	case *parser.ImplDecl:
+		g.genImpl(s)
 	case *parser.ConstDecl:
-		g.genGlobalConstDecl(s)
+		g.genGlobalConstDecl(s.Name, s.Init)
+	case *parser.MultiConstDecl:
+		for _, name := range s.Names {
+			g.genGlobalConstDecl(name, s.Init)
+		}
 	}
 }
 
@@ -104,24 +113,39 @@ func (g *Generator) genGlobalVarDecl(decl *parser.VarDecl) {
 	}
 }
 
-func (g *Generator) genGlobalConstDecl(decl *parser.ConstDecl) {
-	// Constantes globais são resolvidas em tempo de compilação
-	// Usamos genExpr para obter o operando e seu tipo
-	valOperand := g.genExpr(decl.Init)
-
-	globalOp := &Operand{
-		Kind:  OpVar,
-		Value: decl.Name,
-		Type:  valOperand.Type,
+func (g *Generator) genGlobalConstDecl(name string, init parser.Expr) {
+	// Constantes globais são resolvidas em tempo de compilação pelo checker
+	val := g.constOperand(init)
+	if val == nil {
+		return
 	}
 
 	g.builder.Module.Globals = append(g.builder.Module.Globals, &Instruction{
-		Op:     MOV,
-		Result: globalOp,
-		Arg1:   valOperand,
+		Op:     CONST,
+		Result: Var(name, val.Type),
+		Arg1:   val,
 	})
 }
 
+// genConstDecl declara uma constante local com o valor calculado pelo checker
+func (g *Generator) genConstDecl(name string, init parser.Expr) {
+	if val := g.constOperand(init); val != nil {
+		g.builder.Emit(CONST, val, nil, Var(name, val.Type))
+	}
+}
+
+// constOperand retorna o literal do valor de uma constante, ou nil se o
+// checker não conseguiu calculá-lo (o erro já foi reportado)
+func (g *Generator) constOperand(init parser.Expr) *Operand {
+	if g.checker == nil {
+		return nil
+	}
+	if val := g.checker.ConstValueOf(init); val != nil {
+		return ConstLiteral(val)
+	}
+	return nil
+}
+
 func (g *Generator) ensureInitFunction() *Function {
 	// Verifica se já existe uma função init
 	for _, fn := range g.builder.Module.Functions {
@@ -167,10 +191,11 @@ func (g *Generator) resolveType(parserType parser.Type) semantic.Type {
 		// Para tipos definidos pelo usuário, por enquanto, retornamos um wrapper básico
 		return &semantic.ParserTypeWrapper{Type: t}
 	case *parser.ArrayType:
-		// Por enquanto, não resolvemos o tipo do elemento recursivamente
+		// O tipo do elemento e o tamanho definem [N]T ou []T no backend
+		elemType := g.resolveType(t.ElementType).(*semantic.ParserTypeWrapper)
 		return &semantic.ParserTypeWrapper{
 			Type: &parser.ArrayType{
-				ElementType: &parser.PrimitiveType{Name: "any"}, // Simplificação
+				ElementType: elemType.Type,
 				Size:        t.Size,
 			},
 		}
@@ -181,6 +206,9 @@ func (g *Generator) resolveType(parserType parser.Type) semantic.Type {
 				BaseType: &parser.PrimitiveType{Name: "any"}, // Simplificação
 			},
 		}
+	case *parser.ChannelType:
+		// O tipo do elemento é necessário para emitir make(chan T)
+		return &semantic.ParserTypeWrapper{Type: t}
 	default:
 		// Para outros tipos, retorna um tipo "any"
 		return &semantic.ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "any"}}
@@ -204,12 +232,14 @@ func (g *Generator) genFunction(fn *parser.FunctionDecl) {
 	}
 	irFunc.Generics = generics
 
+	irFunc.Variadic = parser.IsVariadic(fn.Params)
+
 	// Configurar builder para a nova função
 	g.builder.CurrentFunc = irFunc
 
 	// Processar parâmetros
 	for _, param := range fn.Params {
-		operand := Var(param.Name, semantic.ToType(param.Type))
+		operand := Var(param.Name, semantic.ToType(param.ValueType()))
 		irFunc.Params = append(irFunc.Params, operand)
 		// Em algumas arquiteturas, precisamos fazer STORE do param registro -> stack
 	}
@@ -230,6 +260,12 @@ func (g *Generator) genStmt(stmt parser.Stmt) {
 	switch s := stmt.(type) {
 	case *parser.VarDecl:
 		g.genVarDecl(s)
+	case *parser.ConstDecl:
+		g.genConstDecl(s.Name, s.Init)
+	case *parser.MultiConstDecl:
+		for _, name := range s.Names {
+			g.genConstDecl(name, s.Init)
+		}
 	case *parser.ExprStmt:
 		g.genExpr(s.Expr) // Avalia expressão (efeitos colaterais)
 	case *parser.ReturnStmt:
@@ -250,6 +286,10 @@ func (g *Generator) genStmt(stmt parser.Stmt) {
 		g.genBreak()
 	case *parser.ContinueStmt:
 		g.genContinue()
+	case *parser.SpawnStmt:
+		g.genSpawn(s)
+	case *parser.SelectStmt:
+		g.genSelect(s)
 	}
 }
 
@@ -270,6 +310,11 @@ func (g *Generator) genVarDecl(decl *parser.VarDecl) {
 
 func (g *Generator) genReturn(ret *parser.ReturnStmt) {
 	if len(ret.Values) == 0 {
+		if fn := g.builder.CurrentFunc; fn != nil && fn.IsConstructor() {
+			// O construtor sempre devolve a instância criada
+			g.builder.Emit(RET, fn.Params[0], nil, nil)
+			return
+		}
 		g.builder.Emit(RET, nil, nil, nil)
 		return
 	}
@@ -325,24 +370,19 @@ func (g *Generator) genWhile(stmt *parser.WhileStmt) {
 	startLabel := g.builder.NewLabel("while_start")
 	endLabel := g.builder.NewLabel("while_end")
 
-	// Empilhar labels para break/continue
-	prevBreak := g.builder.CurrentFunc.LabelCount
-	g.builder.CurrentFunc.LabelCount += 2
-
 	g.builder.EmitLabel(startLabel)
 
 	cond := g.genExpr(stmt.Cond)
 	g.builder.Emit(JMP_FALSE, cond, endLabel, nil)
 
+	g.pushLoop(endLabel, startLabel)
 	for _, s := range stmt.Body {
 		g.genStmt(s)
 	}
+	g.popLoop()
 
 	g.builder.Emit(JMP, startLabel, nil, nil)
 	g.builder.EmitLabel(endLabel)
-
-	// Restaurar labels
-	g.builder.CurrentFunc.LabelCount = prevBreak
 }
 
 func (g *Generator) genFor(stmt *parser.ForStmt) {
@@ -352,16 +392,20 @@ func (g *Generator) genFor(stmt *parser.ForStmt) {
 	}
 
 	startLabel := g.builder.NewLabel("for_start")
+	postLabel := g.builder.NewLabel("for_post")
 	condLabel := g.builder.NewLabel("for_cond")
 	endLabel := g.builder.NewLabel("for_end")
 
 	g.builder.Emit(JMP, condLabel, nil, nil)
 	g.builder.EmitLabel(startLabel)
 
+	g.pushLoop(endLabel, postLabel)
 	for _, s := range stmt.Body {
 		g.genStmt(s)
 	}
+	g.popLoop()
 
+	g.builder.EmitLabel(postLabel)
 	if stmt.Post != nil {
 		g.genStmt(stmt.Post)
 	}
@@ -377,43 +421,190 @@ func (g *Generator) genFor(stmt *parser.ForStmt) {
 	g.builder.EmitLabel(endLabel)
 }
 
+// genSwitch gera instruções SWITCH onde cada caso salta para o label do seu
+// corpo (genCaseSwitch e genTypeDispatch escolhem o caso)
 func (g *Generator) genSwitch(stmt *parser.SwitchStmt) {
-	// Implementação simplificada de switch
-	expr := g.genExpr(stmt.Expr)
+	var subject *Operand
+	if stmt.Expr != nil {
+		subject = g.genExpr(stmt.Expr)
+		// O valor é o do início do switch, mesmo que um caso altere a variável
+		if subject.Kind == OpVar {
+			value := g.builder.NewTemp(g.typeOf(stmt.Expr))
+			g.builder.Emit(MOV, subject, nil, value)
+			subject = value
+		}
+	}
 	endLabel := g.builder.NewLabel("switch_end")
 
-	for _, clause := range stmt.Cases {
-		caseLabel := g.builder.NewLabel("case")
+	labels := make([]*Operand, len(stmt.Cases))
+	for i := range stmt.Cases {
+		labels[i] = g.builder.NewLabel("case")
+	}
 
-		if clause.Value != nil {
-			caseVal := g.genExpr(clause.Value)
-			cond := g.builder.NewTemp(nil)
-			g.builder.Emit(EQ, expr, caseVal, cond)
-			g.builder.Emit(JMP_TRUE, cond, caseLabel, nil)
+	if stmt.IsTypeSwitch() {
+		g.genTypeDispatch(subject, stmt, labels, endLabel)
+	} else {
+		g.genCaseSwitch(subject, stmt, labels, endLabel)
+	}
+
+	// break dentro do switch sai do switch; continue segue para o loop externo
+	g.builder.CurrentFunc.BreakLabels = append(g.builder.CurrentFunc.BreakLabels, endLabel.Value)
+	for i, clause := range stmt.Cases {
+		g.builder.EmitLabel(labels[i])
+		for _, s := range clause.Body {
+			g.genStmt(s)
+		}
+
+		// fallthrough continua no corpo do próximo caso (o checker rejeita
+		// fallthrough no último)
+		if clause.Fallthrough() {
+			g.builder.Emit(JMP, labels[i+1], nil, nil)
 		} else {
-			// default case
-			g.builder.EmitLabel(caseLabel)
+			g.builder.Emit(JMP, endLabel, nil, nil)
+		}
+	}
+	fn := g.builder.CurrentFunc
+	fn.BreakLabels = fn.BreakLabels[:len(fn.BreakLabels)-1]
+
+	g.builder.EmitLabel(endLabel)
+}
+
+// genCaseSwitch escolhe o caso de um switch de valores (ou sem expressão) com
+// instruções SWITCH, que viram switch nativos do Go. O Go testa os casos na
+// ordem e para no primeiro que corresponde, mas os valores precisam estar
+// calculados antes do SWITCH. Um valor que pode falhar ou ter efeitos
+// colaterais (chamadas, índices como arr[0] com o array vazio) começa um novo
+// SWITCH, depois do anterior, e só é calculado se nenhum caso anterior
+// correspondeu. Os demais (x > 10, a + 1) são calculados antes do SWITCH em
+// que estão. O default fica no último SWITCH, testado depois de todos os casos
+func (g *Generator) genCaseSwitch(subject *Operand, stmt *parser.SwitchStmt, labels []*Operand, endLabel *Operand) {
+	fn := g.builder.CurrentFunc
+	var pending []*SwitchCase
+	flush := func() {
+		if len(pending) > 0 {
+			instr := g.builder.Emit(SWITCH, subject, nil, nil)
+			instr.Switch = pending
+			pending = nil
 		}
+	}
 
-		for _, bodyStmt := range clause.Body {
-			g.genStmt(bodyStmt)
+	var fallback *Operand
+	for i, clause := range stmt.Cases {
+		if clause.IsDefault() {
+			fallback = labels[i]
+			continue
 		}
+
+		var c *SwitchCase
+		for _, v := range clause.Values {
+			start := len(fn.Instructions)
+			value := g.genExpr(v)
+			if code := fn.Instructions[start:]; !sideEffectFree(code) {
+				// Mover o cálculo do valor para depois do SWITCH dos casos anteriores
+				code = append([]*Instruction(nil), code...)
+				fn.Instructions = fn.Instructions[:start]
+				flush()
+				c = nil
+				fn.Instructions = append(fn.Instructions, code...)
+			}
+			if c == nil {
+				c = &SwitchCase{Label: labels[i]}
+				pending = append(pending, c)
+			}
+			c.Values = append(c.Values, value)
+		}
+	}
+
+	if fallback != nil {
+		pending = append(pending, &SwitchCase{Label: fallback})
+	}
+	flush()
+
+	// Sem default, nenhum caso correspondente sai do switch
+	if fallback == nil {
 		g.builder.Emit(JMP, endLabel, nil, nil)
 	}
+}
 
-	g.builder.EmitLabel(endLabel)
+// sideEffectFree indica se as instruções podem ser executadas antes da hora
+// sem mudar o programa: só escrevem temporários e não podem falhar
+func sideEffectFree(code []*Instruction) bool {
+	for _, instr := range code {
+		if instr.Result != nil && instr.Result.Kind != OpTemp {
+			return false
+		}
+		switch instr.Op {
+		case LABEL, JMP, JMP_TRUE, JMP_FALSE, MOV, NOT, EQ, NEQ, LT, GT, LE, GE, CONCAT, LEN, SUB, MUL, AND, OR, XOR:
+		case ADD:
+			// add(set, valor) também é um ADD, e altera o set
+			if maybeSet(instr.Arg1, nil) {
+				return false
+			}
+		case DIV, MOD, SHL, SHR:
+			if !cannotTrap(instr) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// genTypeDispatch escolhe o caso de um switch de tipo com uma instrução
+// SWITCH (os testes de tipo não têm efeitos colaterais)
+func (g *Generator) genTypeDispatch(subject *Operand, stmt *parser.SwitchStmt, labels []*Operand, endLabel *Operand) {
+	cases := make([]*SwitchCase, len(stmt.Cases))
+	hasDefault := false
+	for i, clause := range stmt.Cases {
+		c := &SwitchCase{Label: labels[i]}
+		for _, t := range clause.Types {
+			c.Types = append(c.Types, semantic.ToType(t))
+		}
+		if c.IsDefault() {
+			hasDefault = true
+		}
+		cases[i] = c
+	}
+
+	instr := g.builder.Emit(SWITCH, subject, nil, nil)
+	instr.Switch = cases
+
+	// Sem default, nenhum caso correspondente sai do switch
+	if !hasDefault {
+		g.builder.Emit(JMP, endLabel, nil, nil)
+	}
+}
+
+// pushLoop empilha os destinos de break e continue do loop mais interno
+func (g *Generator) pushLoop(breakLabel, continueLabel *Operand) {
+	fn := g.builder.CurrentFunc
+	fn.BreakLabels = append(fn.BreakLabels, breakLabel.Value)
+	fn.ContinueLabels = append(fn.ContinueLabels, continueLabel.Value)
+}
+
+func (g *Generator) popLoop() {
+	fn := g.builder.CurrentFunc
+	fn.BreakLabels = fn.BreakLabels[:len(fn.BreakLabels)-1]
+	fn.ContinueLabels = fn.ContinueLabels[:len(fn.ContinueLabels)-1]
 }
 
 func (g *Generator) genBreak() {
-	// Implementação simplificada: pular para o fim do loop mais interno
-	endLabel := g.builder.NewLabel("loop_end")
-	g.builder.Emit(JMP, endLabel, nil, nil)
+	// Pula para o fim do loop ou switch mais interno
+	labels := g.builder.CurrentFunc.BreakLabels
+	if len(labels) == 0 {
+		return
+	}
+	g.builder.Emit(JMP, &Operand{Kind: OpLabel, Value: labels[len(labels)-1]}, nil, nil)
 }
 
 func (g *Generator) genContinue() {
-	// Implementação simplificada: pular para o início do loop mais interno
-	startLabel := g.builder.NewLabel("loop_start")
-	g.builder.Emit(JMP, startLabel, nil, nil)
+	// Pula para a próxima iteração do loop mais interno
+	labels := g.builder.CurrentFunc.ContinueLabels
+	if len(labels) == 0 {
+		return
+	}
+	g.builder.Emit(JMP, &Operand{Kind: OpLabel, Value: labels[len(labels)-1]}, nil, nil)
 }
 
 // ============================
@@ -424,13 +615,21 @@ func (g *Generator) genExpr(expr parser.Expr) *Operand {
 	switch e := expr.(type) {
 	case *parser.IntLiteral:
 		return IntLiteral(e.Value)
+	case *parser.FloatLiteral:
+		return FloatLiteral(e.Value)
 	case *parser.BoolLiteral:
 		return BoolLiteral(e.Value)
 	case *parser.StringLiteral:
-		return Literal(e.Value, nil) // Tipo string
+		return StringLiteral(e.Value)
+	case *parser.InterpolatedString:
+		return g.genInterpolatedString(e)
+	case *parser.CharLiteral:
+		return CharLiteral(e.Value)
 	case *parser.Identifier:
 		// Assumimos que semantic check já resolveu se existe
-		return Var(e.Name, nil)
+		return Var(e.Name, g.typeOf(e))
+	case *parser.SelfExpr:
+		return g.selfOperand()
 	case *parser.BinaryExpr:
 		return g.genBinaryExpr(e)
 	case *parser.CallExpr:
@@ -447,6 +646,19 @@ func (g *Generator) genExpr(expr parser.Expr) *Operand {
 		return g.genTernaryExpr(e)
 	case *parser.TypeCastExpr:
 		return g.genTypeCast(e)
+	case *parser.ArrayLiteral:
+		return g.genArrayLiteral(e)
+	case *parser.ChannelExpr:
+		return g.genChannelExpr(e)
+	case *parser.SendExpr:
+		return g.genSendExpr(e)
+	case *parser.ReceiveExpr:
+		return g.genReceiveExpr(e)
+	case *parser.SpreadExpr:
+		// O espalhamento é marcado na instrução que consome o array (Spread)
+		return g.genExpr(e.Expr)
+	case *parser.NamedArg:
+		return g.genExpr(e.Value)
 	default:
 		// Fallback para outros tipos não implementados aqui
 		return g.builder.NewTemp(nil)
@@ -469,7 +681,9 @@ func (g *Generator) genUnaryExpr(e *parser.UnaryExpr) *Operand {
 	case "-":
 		g.builder.Emit(SUB, Literal("0", nil), expr, res)
 	case "!":
-		g.builder.Emit(XOR, expr, Literal("1", nil), res)
+		g.builder.Emit(EQ, expr, Literal("false", nil), res)
+	case "~":
+		g.builder.Emit(NOT, expr, nil, res)
 	case "&":
 		// Operador de endereço
 		return g.genAddr(e.Expr)
@@ -508,70 +722,105 @@ func (g *Generator) genTypeCast(e *parser.TypeCastExpr) *Operand {
 	expr := g.genExpr(e.Expr)
 	res := g.builder.NewTemp(semantic.ToType(e.Type))
 
-	// Emitir instrução CAST
-	g.builder.Emit(CAST, expr, nil, res)
+	// Emitir instrução CAST; Arg2 guarda o tipo de origem
+	g.builder.Emit(CAST, expr, &Operand{Kind: OpType, Type: g.typeOf(e.Expr)}, res)
+	return res
+}
+
+func (g *Generator) genArrayLiteral(e *parser.ArrayLiteral) *Operand {
+	if hasSpread(e.Elements) {
+		// [...a, x, ...b]: monta um slice e converte para T[N] quando o tamanho é fixo
+		typ := g.typeOf(e)
+		slice := g.genSpreadSlice(e.Elements, sliceOf(typ))
+		if semantic.StringifyType(typ) == semantic.StringifyType(slice.Type) {
+			return slice
+		}
+		res := g.builder.NewTemp(typ)
+		g.builder.Emit(CAST, slice, nil, res)
+		return res
+	}
+
+	var elems []*Operand
+	for _, elem := range e.Elements {
+		elems = append(elems, g.genExpr(elem))
+	}
+
+	// O tipo vem do checker: T[N] para literais inferidos ou o tipo do destino
+	res := g.builder.NewTemp(g.typeOf(e))
+	instr := g.builder.Emit(ARRAY_LIT, nil, nil, res)
+	instr.Args = elems
 	return res
 }
 
+// binaryOpCodes associa os operadores binários do Alpha aos OpCodes
+var binaryOpCodes = map[string]OpCode{
+	"+": ADD, "-": SUB, "*": MUL, "/": DIV, "%": MOD,
+	"==": EQ, "!=": NEQ, "<": LT, ">": GT, "<=": LE, ">=": GE,
+	"&": AND, "|": OR, "^": XOR, "<<": SHL, ">>": SHR,
+}
+
 func (g *Generator) genBinaryExpr(e *parser.BinaryExpr) *Operand {
 	// Curto circuito para && e ||
 	if e.Op == "&&" || e.Op == "||" {
 		return g.genLogicalShortCircuit(e)
 	}
 
+	// Atribuição composta: x += y, x <<= y, ...
+	if op, ok := compoundOpCodes[e.Op]; ok {
+		return g.genCompoundAssign(e, op)
+	}
+
 	left := g.genExpr(e.Left)
 	right := g.genExpr(e.Right)
 
 	// Determina OpCode
-	var op OpCode
-	switch e.Op {
-	case "+":
-		op = ADD
-	case "-":
-		op = SUB
-	case "*":
-		op = MUL
-	case "/":
-		op = DIV
-	case "%":
-		op = MOD
-	case "==":
-		op = EQ
-	case "!=":
-		op = NEQ
-	case "<":
-		op = LT
-	case ">":
-		op = GT
-	case "<=":
-		op = LE
-	case ">=":
-		op = GE
-	case "&":
-		op = AND
-	case "|":
-		op = OR
-	case "^":
-		op = XOR
-	case "<<":
-		op = SHL
-	case ">>":
-		op = SHR
-	default:
+	op, ok := binaryOpCodes[e.Op]
+	if !ok {
 		panic("Unknown operator " + e.Op)
 	}
 
-	result := g.builder.NewTemp(nil)
+	result := g.builder.NewTemp(g.typeOf(e))
 	g.builder.Emit(op, left, right, result)
 	return result
 }
 
+// compoundOpCodes associa as atribuições compostas à operação que aplicam
+var compoundOpCodes = map[string]OpCode{
+	"+=": ADD, "-=": SUB, "*=": MUL, "/=": DIV, "%=": MOD,
+	"&=": AND, "|=": OR, "^=": XOR, "<<=": SHL, ">>=": SHR,
+}
+
+// genCompoundAssign reduz x op= y a x = x op y, avaliando o destino uma única vez
+func (g *Generator) genCompoundAssign(e *parser.BinaryExpr, op OpCode) *Operand {
+	result := g.builder.NewTemp(g.typeOf(e.Left))
+
+	if ident, ok := e.Left.(*parser.Identifier); ok {
+		dest := Var(ident.Name, nil)
+		right := g.genExpr(e.Right)
+		g.builder.Emit(op, dest, right, result)
+		g.builder.Emit(STORE, dest, result, nil)
+		return result
+	}
+
+	// Acesso complexo (array/struct): carrega pelo endereço e grava de volta
+	addr := g.genAddr(e.Left)
+	current := g.builder.NewTemp(g.typeOf(e.Left))
+	g.builder.Emit(LOAD, addr, nil, current)
+	right := g.genExpr(e.Right)
+	g.builder.Emit(op, current, right, result)
+	g.builder.Emit(STORE, addr, result, nil)
+	return result
+}
+
 func (g *Generator) genLogicalShortCircuit(e *parser.BinaryExpr) *Operand {
 	result := g.builder.NewTemp(nil)
 	left := g.genExpr(e.Left)
 
 	endLabel := g.builder.NewLabel("logic_end")
 
+	// Se o lado direito não for avaliado, o resultado é o próprio lado esquerdo
+	g.builder.Emit(MOV, left, nil, result)
+
 	if e.Op == "&&" {
 		// left && right
 		g.builder.Emit(JMP_FALSE, left, endLabel, nil)
@@ -589,19 +838,34 @@ func (g *Generator) genLogicalShortCircuit(e *parser.BinaryExpr) *Operand {
 }
 
 func (g *Generator) genCallExpr(e *parser.CallExpr) *Operand {
-	var args []*Operand
-	for _, arg := range e.Args {
-		args = append(args, g.genExpr(arg))
-	}
+	args, spread := g.genCallArgs(e)
 
 	// Resolve callee
 	var callee *Operand
 	if ident, ok := e.Callee.(*parser.Identifier); ok {
 		// Trata built-ins
 		if isBuiltin(ident.Name) {
-			return g.genBuiltin(ident.Name, args)
+			res := g.genBuiltin(ident.Name, args)
+			// append(lista, ...outra) acrescenta todos os elementos
+			if ident.Name == "append" && len(e.Args) == 2 && hasSpread(e.Args[1:]) {
+				last := g.builder.CurrentFunc.Instructions[len(g.builder.CurrentFunc.Instructions)-1]
+				last.Spread = true
+			}
+			return res
 		}
 		callee = &Operand{Kind: OpFunction, Value: ident.Name}
+		if g.isStruct(ident.Name) {
+			// User(args) chama o construtor gerado a partir do init
+			callee.Value = ConstructorName(ident.Name)
+		}
+	} else if member, ok := e.Callee.(*parser.MemberExpr); ok {
+		// Chamada de método: CALL .metodo, receiver
+		obj := g.genExpr(member.Object)
+		result := g.builder.NewTemp(nil)
+		instr := g.builder.Emit(CALL, &Operand{Kind: OpField, Value: member.Member}, obj, result)
+		instr.Args = args
+		instr.Spread = spread
+		return result
 	} else {
 		callee = g.genExpr(e.Callee) // Ponteiro de função
 	}
@@ -610,6 +874,7 @@ func (g *Generator) genCallExpr(e *parser.CallExpr) *Operand {
 
 	instr := g.builder.Emit(CALL, callee, nil, result)
 	instr.Args = args
+	instr.Spread = spread
 
 	return result
 }
@@ -643,6 +908,12 @@ func (g *Generator) genBuiltin(name string, args []*Operand) *Operand {
 	case "has":
 		// has(&set, value) -> retorna bool
 		g.builder.Emit(HAS, args[0], args[1], res)
+	case "close":
+		// close(ch)
+		g.builder.Emit(CLOSE, args[0], nil, nil)
+	case "waitAll":
+		// waitAll() - aguarda todas as tarefas disparadas com spawn
+		g.builder.Emit(WAIT, nil, nil, nil)
 	default:
 		// Para funções não mapeadas, tratamos como uma chamada genérica de sistema
 		g.builder.Emit(CALL, &Operand{Kind: OpFunction, Value: "builtin_" + name}, nil, res)
@@ -670,7 +941,19 @@ func (g *Generator) genAssign(e *parser.AssignExpr) *Operand {
 func (g *Generator) genIndexExpr(e *parser.IndexExpr) *Operand {
 	arr := g.genExpr(e.Array)
 	idx := g.genExpr(e.Index)
-	res := g.builder.NewTemp(nil)
+
+	var resType semantic.Type
+	if semantic.StringifyType(g.typeOf(e.Array)) == "string" {
+		// s[i] é o i-ésimo caractere: a string é convertida para char[] antes
+		runes := g.builder.NewTemp(&semantic.ParserTypeWrapper{Type: &parser.ArrayType{
+			ElementType: &parser.PrimitiveType{Name: "char"},
+		}})
+		g.builder.Emit(CAST, arr, nil, runes)
+		arr = runes
+		resType = g.typeOf(e)
+	}
+
+	res := g.builder.NewTemp(resType)
 	g.builder.Emit(GET_INDEX, arr, idx, res)
 	return res
 }
@@ -678,12 +961,21 @@ func (g *Generator) genIndexExpr(e *parser.IndexExpr) *Operand {
 func (g *Generator) genMemberExpr(e *parser.MemberExpr) *Operand {
 	obj := g.genExpr(e.Object)
 	field := &Operand{Kind: OpField, Value: e.Member}
-	res := g.builder.NewTemp(nil)
+	res := g.builder.NewTemp(g.typeOf(e))
 	g.builder.Emit(GET_FIELD, obj, field, res)
 	return res
 }
 
 // Helpers
+
+// typeOf consulta o tipo que o checker registrou para uma expressão
+func (g *Generator) typeOf(expr parser.Expr) semantic.Type {
+	if g.checker == nil {
+		return nil
+	}
+	return g.checker.TypeOf(expr)
+}
+
 func (g *Generator) genAddr(expr parser.Expr) *Operand {
 	// Lógica para obter endereço de memória ao invés do valor
 	switch e := expr.(type) {
@@ -711,14 +1003,13 @@ func isBuiltin(name string) bool {
 	builtins := map[string]bool{
 		"append": true, "length": true, "remove": true, "delete": true,
 		"add": true, "clear": true, "has": true, "removeIndex": true,
+		"close": true, "waitAll": true,
 	}
 	return builtins[name]
 }
 
 func isExported(name string) bool {
-	// Exemplo: letra maiúscula exporta
-	if len(name) > 0 {
-		return name[0] >= 'A' && name[0] <= 'Z'
-	}
-	return false
+	// Como no Go: inicial maiúscula (em qualquer alfabeto) exporta
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
 }
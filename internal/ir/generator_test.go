@@ -0,0 +1,135 @@
+package ir
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alpha/internal/lexer"
+	"github.com/alpha/internal/parser"
+	"github.com/alpha/internal/semantic"
+)
+
+// generate gera o IR (sem otimizações) de um programa Alpha válido
+func generate(t *testing.T, src string) *Module {
+	t.Helper()
+	p := parser.New(lexer.NewScanner(src))
+	prog := p.ParseProgram()
+	if p.HasErrors() {
+		t.Fatalf("parse errors: %v", p.Errors)
+	}
+	checker := semantic.NewChecker()
+	checker.CheckProgram(prog)
+	if len(checker.Errors) > 0 {
+		t.Fatalf("semantic errors: %v", checker.Errors)
+	}
+	return NewGenerator(checker).Generate(prog)
+}
+
+// function retorna a função do módulo com o nome dado
+func function(t *testing.T, m *Module, name string) *Function {
+	t.Helper()
+	for _, fn := range m.Functions {
+		if fn.Name == name {
+			return fn
+		}
+	}
+	t.Fatalf("function %s not found in\n%s", name, FormatModule(m))
+	return nil
+}
+
+// dump escreve a função no formato textual, para as mensagens de erro
+func dump(fn *Function) string {
+	var sb strings.Builder
+	formatFunction(&sb, fn)
+	return sb.String()
+}
+
+// indexOf retorna a posição da primeira instrução com a operação (-1 se não houver)
+func indexOf(fn *Function, op OpCode) int {
+	for i, instr := range fn.Instructions {
+		if instr.Op == op {
+			return i
+		}
+	}
+	return -1
+}
+
+// switches retorna as instruções SWITCH da função, na ordem
+func switches(fn *Function) []*Instruction {
+	var list []*Instruction
+	for _, instr := range fn.Instructions {
+		if instr.Op == SWITCH {
+			list = append(list, instr)
+		}
+	}
+	return list
+}
+
+func TestSwitchEvaluatesCasesLazily(t *testing.T) {
+	m := generate(t, `package main
+int function first(int[] arr) {
+    switch {
+    case length(arr) == 0:
+        return -1
+    case arr[0] == 1:
+        return 1
+    }
+    return 0
+}
+`)
+	fn := function(t, m, "first")
+	if indexOf(fn, JMP_TRUE) >= 0 {
+		t.Errorf("cases should be tested by SWITCH, not a compare-and-branch chain:\n%s", dump(fn))
+	}
+	first, index := indexOf(fn, SWITCH), indexOf(fn, GET_INDEX)
+	if first < 0 || index < first {
+		t.Errorf("arr[0] is evaluated before the first case is tested:\n%s", dump(fn))
+	}
+}
+
+func TestSwitchGroupsSafeCases(t *testing.T) {
+	m := generate(t, `package main
+int function grade(int x) {
+    switch {
+    case x > 90:
+        return 1
+    case x + 1 > 50, x == 0:
+        return 2
+    default:
+        return 3
+    }
+    return 0
+}
+`)
+	fn := function(t, m, "grade")
+	list := switches(fn)
+	if len(list) != 1 || len(list[0].Switch) != 3 {
+		t.Fatalf("cases without side effects should share one SWITCH:\n%s", dump(fn))
+	}
+	if c := list[0].Switch[1]; len(c.Values) != 2 {
+		t.Errorf("case with two values has %d in the SWITCH:\n%s", len(c.Values), dump(fn))
+	}
+}
+
+func TestSwitchTestsDefaultLast(t *testing.T) {
+	m := generate(t, `package main
+int function pick(int x) {
+    switch (x) {
+    default:
+        return 0
+    case 1, 2:
+        return 1
+    }
+    return -1
+}
+`)
+	fn := function(t, m, "pick")
+	list := switches(fn)
+	if len(list) != 1 {
+		t.Fatalf("want one SWITCH, got %d:\n%s", len(list), dump(fn))
+	}
+	cases := list[0].Switch
+	if len(cases) != 2 || len(cases[0].Values) != 2 || !cases[1].IsDefault() {
+		t.Errorf("want the case with 1, 2 and then the default:\n%s", dump(fn))
+	}
+}
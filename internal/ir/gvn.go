@@ -0,0 +1,156 @@
+package ir
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/alpha/internal/semantic"
+)
+
+// ============================
+// NUMERAÇÃO DE VALORES (GVN)
+// ============================
+
+// NumberValues reaproveita cálculos puros repetidos: se a mesma operação com
+// os mesmos operandos já foi calculada em um ponto que domina a atual, a
+// atual vira uma cópia do resultado anterior (que a propagação de cópias e o
+// dead-code terminam de limpar). Expressões sobre valores que não mudam
+// (literais, temporários de definição única e versões SSA) valem em toda a
+// árvore de dominadores (numeração global); expressões que leem variáveis
+// valem só dentro do bloco, até a variável ser reescrita (numeração local)
+func (o *Optimizer) NumberValues(fn *Function) {
+	cfg := BuildCFG(fn)
+	vn := &valueNumbering{
+		fn:       fn,
+		vars:     promotableVars(fn, o.globals()),
+		defs:     newFolder(fn).defs,
+		children: cfg.DomChildren(),
+	}
+	vn.visit(cfg.Entry, make(map[string]*Operand))
+}
+
+type valueNumbering struct {
+	fn       *Function
+	vars     map[string]semantic.Type
+	defs     map[string]*Instruction // Temporários com uma única definição
+	children map[*BasicBlock][]*BasicBlock
+}
+
+// visit numera o bloco e depois os blocos que ele domina imediatamente, que
+// herdam as expressões globais disponíveis no fim dele
+func (vn *valueNumbering) visit(b *BasicBlock, available map[string]*Operand) {
+	available = maps.Clone(available)
+	local := make(map[string]*Operand)
+	reads := make(map[string][]string) // Expressão local -> variáveis que ela lê
+
+	for _, instr := range b.Instructions {
+		if key, vars, ok := vn.expression(instr); ok {
+			table := available
+			if len(vars) > 0 {
+				table = local
+			}
+			if prev := table[key]; prev != nil {
+				instr.Op = MOV
+				instr.Arg1 = prev
+				instr.Arg2 = nil
+				instr.Args = nil
+			} else if vn.stable(instr.Result) {
+				table[key] = instr.Result
+				if len(vars) > 0 {
+					reads[key] = vars
+				}
+			}
+		}
+
+		// Expressões locais que leem uma variável reescrita deixam de valer
+		for _, op := range []*Operand{instr.Def(), methodReceiver(instr)} {
+			written, ok := valueKey(op)
+			if !ok {
+				continue
+			}
+			for key, vars := range reads {
+				if slices.Contains(vars, written) {
+					delete(local, key)
+					delete(reads, key)
+				}
+			}
+		}
+	}
+
+	for _, child := range vn.children[b] {
+		vn.visit(child, available)
+	}
+}
+
+// expression retorna a chave de uma operação pura (a mesma para operações
+// equivalentes) e as variáveis que ela lê. ok é false para instruções que não
+// entram na numeração
+func (vn *valueNumbering) expression(instr *Instruction) (key string, vars []string, ok bool) {
+	var ops []*Operand
+	switch instr.Op {
+	case ADD:
+		// add(set, valor) também é um ADD, e altera o set
+		if maybeSet(instr.Arg1, vn.defs) {
+			return "", nil, false
+		}
+		ops = []*Operand{instr.Arg1, instr.Arg2}
+	case SUB, MUL, DIV, MOD, AND, OR, XOR, SHL, SHR, EQ, NEQ, LT, GT, LE, GE:
+		ops = []*Operand{instr.Arg1, instr.Arg2}
+	case NOT:
+		ops = []*Operand{instr.Arg1}
+	case CAST:
+		if instr.Result == nil || instr.Result.Type == nil {
+			return "", nil, false
+		}
+		ops = []*Operand{instr.Arg1}
+	case CONCAT:
+		ops = instr.Args
+	default:
+		return "", nil, false
+	}
+	if instr.Result == nil {
+		return "", nil, false
+	}
+
+	keys := make([]string, len(ops))
+	for i, op := range ops {
+		switch {
+		case op == nil:
+			return "", nil, false
+		case vn.stable(op):
+		case op.Kind == OpVar && trackedValue(vn.fn, vn.vars, op):
+			vars = append(vars, operandKey(op))
+		default:
+			return "", nil, false
+		}
+		keys[i] = operandKey(op)
+	}
+
+	switch instr.Op {
+	case MUL, AND, OR, XOR, EQ, NEQ:
+		slices.Sort(keys)
+	}
+	key = instr.opToString() + " " + strings.Join(keys, ", ")
+	if instr.Op == CAST {
+		key += " " + semantic.StringifyType(instr.Result.Type)
+	}
+	return key, vars, true
+}
+
+// stable indica se o valor é o mesmo em todo ponto onde é lido: literais,
+// temporários com uma única definição e, em SSA, as versões e os parâmetros
+// (o nome sem versão de uma variável promovida é o valor da entrada)
+func (vn *valueNumbering) stable(op *Operand) bool {
+	switch {
+	case op == nil:
+		return false
+	case op.Kind == OpLiteral:
+		return true
+	case op.Kind == OpTemp:
+		return vn.defs[op.Value] != nil
+	case op.Kind == OpVar && vn.fn.SSA:
+		return trackedValue(vn.fn, vn.vars, op)
+	}
+	return false
+}
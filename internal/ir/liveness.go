@@ -0,0 +1,134 @@
+package ir
+
+// ============================
+// VARIÁVEIS VIVAS (LIVENESS)
+// ============================
+
+// Liveness guarda, para cada bloco, os valores (variáveis e temporários) que
+// estão vivos na entrada e na saída: valores que ainda podem ser lidos antes
+// de serem reescritos
+type Liveness struct {
+	In  map[*BasicBlock]map[string]bool
+	Out map[*BasicBlock]map[string]bool
+}
+
+// valueKey identifica um valor nas análises: temporários levam o prefixo %
+// ("%t1") para não se confundirem com uma variável chamada t1
+func valueKey(op *Operand) (string, bool) {
+	if op == nil || (op.Kind != OpVar && op.Kind != OpTemp) {
+		return "", false
+	}
+	return op.String(), true
+}
+
+// Uses retorna os operandos que a instrução lê
+func (i *Instruction) Uses() []*Operand {
+	var uses []*Operand
+	add := func(ops ...*Operand) {
+		for _, op := range ops {
+			if op != nil && (op.Kind == OpVar || op.Kind == OpTemp) {
+				uses = append(uses, op)
+			}
+		}
+	}
+
+	// STORE em uma variável é uma atribuição; em um temporário, o temporário
+	// é um endereço (GET_ADDR) e é lido
+	if i.Op != STORE || i.Arg1 == nil || i.Arg1.Kind != OpVar {
+		add(i.Arg1)
+	}
+	add(i.Arg2)
+	add(i.Args...)
+	for _, c := range i.Switch {
+		add(c.Values...)
+	}
+	for _, c := range i.Select {
+		// O destino de um recv é escrito só se o caso for escolhido, então
+		// conta como leitura para continuar vivo nos outros caminhos
+		add(c.Chan, c.Value)
+	}
+	return uses
+}
+
+// Def retorna o valor que a instrução escreve (nil se nenhum)
+func (i *Instruction) Def() *Operand {
+	if i.Op == STORE {
+		if i.Arg1 != nil && i.Arg1.Kind == OpVar {
+			return i.Arg1
+		}
+		return nil
+	}
+	if i.Result != nil && (i.Result.Kind == OpVar || i.Result.Kind == OpTemp) {
+		return i.Result
+	}
+	return nil
+}
+
+// ComputeLiveness resolve o fluxo de dados de trás para frente até um ponto
+// fixo: um valor está vivo na entrada de um bloco se é lido nele antes de ser
+// escrito, ou se está vivo na saída e o bloco não o escreve
+func ComputeLiveness(cfg *CFG) *Liveness {
+	live := &Liveness{
+		In:  make(map[*BasicBlock]map[string]bool),
+		Out: make(map[*BasicBlock]map[string]bool),
+	}
+
+	uses := make(map[*BasicBlock]map[string]bool)
+	defs := make(map[*BasicBlock]map[string]bool)
+	for _, b := range append(cfg.Blocks, cfg.Exit) {
+		uses[b], defs[b] = make(map[string]bool), make(map[string]bool)
+		live.In[b], live.Out[b] = make(map[string]bool), make(map[string]bool)
+	}
+
+	for _, b := range cfg.Blocks {
+		for _, instr := range b.Instructions {
+			if instr.Op == PHI {
+				// Uma PHI lê cada valor no fim do predecessor correspondente
+				for j, arg := range instr.Args {
+					key, ok := valueKey(arg)
+					if pred := cfg.Block(instr.From[j].Value); ok && pred != nil {
+						live.Out[pred][key] = true
+					}
+				}
+			} else {
+				for _, op := range instr.Uses() {
+					if key, _ := valueKey(op); !defs[b][key] {
+						uses[b][key] = true
+					}
+				}
+			}
+			if key, ok := valueKey(instr.Def()); ok {
+				defs[b][key] = true
+			}
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for i := len(cfg.Blocks) - 1; i >= 0; i-- {
+			b := cfg.Blocks[i]
+			for _, s := range b.Successors {
+				for key := range live.In[s] {
+					if !live.Out[b][key] {
+						live.Out[b][key] = true
+						changed = true
+					}
+				}
+			}
+			for key := range uses[b] {
+				if !live.In[b][key] {
+					live.In[b][key] = true
+					changed = true
+				}
+			}
+			for key := range live.Out[b] {
+				if !defs[b][key] && !live.In[b][key] {
+					live.In[b][key] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	return live
+}
@@ -23,6 +23,7 @@ const (
 	XOR
 	SHL
 	SHR
+	NOT // t1 = ~t2 (complemento bit a bit)
 
 	// Comparação
 	EQ
@@ -55,7 +56,7 @@ const (
 	APPEND     // t1 = append(t1, t2)
 	MAKE_SLICE // t1 = make([]T, len)
 	MAKE_MAP   // t1 = make(map[K]V)
-	CAST       // t1 = type(t2)
+	CAST       // t1 = type(t2) (Arg2 opcional: tipo de origem)
 	NOP        // No Operation
 
 	// Operações para built-ins (adicionadas)
@@ -64,8 +65,60 @@ const (
 	DELETE       // delete(&map, key)
 	CLEAR        // clear(&map | &set)
 	HAS          // has(&set, value)
+
+	// Concorrência
+	SPAWN     // spawn f(args...) - Arg1 = função, Args = argumentos
+	SEND      // ch <- t2
+	RECV      // t1 = <-ch
+	CLOSE     // close(ch)
+	WAIT      // waitAll()
+	MAKE_CHAN // t1 = make(chan T, cap)
+	SELECT    // select { ... } - casos em Select
+
+	// Literais compostos
+	ARRAY_LIT // t1 = [Args...] (tipo do array em Result.Type)
+
+	// Constantes
+	CONST // const t1 = literal (valor calculado em tempo de compilação)
+
+	// Switch
+	SWITCH // switch Arg1 { ... } - casos em Switch (Arg1 nil = sem condição)
+
+	// Strings
+	CONCAT // t1 = Args[0] + Args[1] + ... (concatenação de strings)
+)
+
+// SelectCaseKind define o tipo de operação de um caso de select
+type SelectCaseKind int
+
+const (
+	SelectRecv    SelectCaseKind = iota // case [dest =] <-ch
+	SelectSend                          // case ch <- valor
+	SelectDefault                       // default
 )
 
+// SelectCase representa um caso de uma instrução SELECT.
+// Cada caso salta para o Label onde seu corpo foi gerado
+type SelectCase struct {
+	Kind  SelectCaseKind
+	Chan  *Operand
+	Value *Operand // Valor enviado (send) ou destino do valor recebido (recv, opcional)
+	Label *Operand
+}
+
+// SwitchCase representa um caso de uma instrução SWITCH.
+// Casos de valor usam Values, casos de tipo usam Types; o default não tem nenhum
+type SwitchCase struct {
+	Values []*Operand
+	Types  []semantic.Type
+	Label  *Operand
+}
+
+// IsDefault indica se o caso é o default do switch
+func (c *SwitchCase) IsDefault() bool {
+	return len(c.Values) == 0 && len(c.Types) == 0
+}
+
 // OperandType define o tipo do operando
 type OperandType int
 
@@ -109,7 +162,11 @@ type Instruction struct {
 	Arg1   *Operand
 	Arg2   *Operand
 	Result *Operand
-	Args   [](*Operand) // Para instruções com número variável de argumentos (ex: CALL)
+	Args   [](*Operand)  // Para instruções com número variável de argumentos (ex: CALL)
+	Select []*SelectCase // Casos da instrução SELECT
+	Switch []*SwitchCase // Casos da instrução SWITCH
+	Spread bool          // CALL/APPEND: o último argumento é espalhado (xs...)
+	From   []*Operand    // PHI: label do bloco de origem de cada valor em Args
 	// Metadados adicionais para debug ou backend específico
 	Line int
 }
@@ -121,6 +178,19 @@ func (i *Instruction) String() string {
 		return fmt.Sprintf("%s:", i.Arg1)
 	}
 
+	if i.Op == PHI {
+		// x.3 = PHI [x.1, .if_then_0], [x.2, .if_else_1]
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("%s = PHI", i.Result))
+		for j, arg := range i.Args {
+			if j > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString(fmt.Sprintf(" [%s, %s]", arg, i.From[j]))
+		}
+		return sb.String()
+	}
+
 	var sb strings.Builder
 	if i.Result != nil {
 		sb.WriteString(fmt.Sprintf("%s = ", i.Result))
@@ -137,8 +207,8 @@ func (i *Instruction) String() string {
 		sb.WriteString(i.Arg2.String())
 	}
 
-	// Para CALL com múltiplos argumentos
-	if i.Op == CALL && len(i.Args) > 0 {
+	// Para CALL/SPAWN/ARRAY_LIT/CONCAT com múltiplos argumentos
+	if (i.Op == CALL || i.Op == SPAWN || i.Op == ARRAY_LIT || i.Op == CONCAT) && len(i.Args) > 0 {
 		sb.WriteString("(")
 		for j, arg := range i.Args {
 			if j > 0 {
@@ -148,18 +218,61 @@ func (i *Instruction) String() string {
 		}
 		sb.WriteString(")")
 	}
+
+	for _, c := range i.Select {
+		switch c.Kind {
+		case SelectRecv:
+			sb.WriteString(fmt.Sprintf(" [recv %s", c.Chan))
+			if c.Value != nil {
+				sb.WriteString(fmt.Sprintf(" -> %s", c.Value))
+			}
+		case SelectSend:
+			sb.WriteString(fmt.Sprintf(" [send %s <- %s", c.Chan, c.Value))
+		case SelectDefault:
+			sb.WriteString(" [default")
+		}
+		sb.WriteString(fmt.Sprintf(": %s]", c.Label))
+	}
+
+	for _, c := range i.Switch {
+		sb.WriteString(" [")
+		switch {
+		case c.IsDefault():
+			sb.WriteString("default")
+		case len(c.Types) > 0:
+			for j, t := range c.Types {
+				if j > 0 {
+					sb.WriteString(", ")
+				}
+				sb.WriteString(semantic.StringifyType(t))
+			}
+		default:
+			for j, v := range c.Values {
+				if j > 0 {
+					sb.WriteString(", ")
+				}
+				sb.WriteString(v.String())
+			}
+		}
+		sb.WriteString(fmt.Sprintf(": %s]", c.Label))
+	}
 	return sb.String()
 }
 
 func (i *Instruction) opToString() string {
 	// Mapeamento simples para debug
 	names := []string{
-		"ADD", "SUB", "MUL", "DIV", "MOD", "AND", "OR", "XOR", "SHL", "SHR",
+		"ADD", "SUB", "MUL", "DIV", "MOD", "AND", "OR", "XOR", "SHL", "SHR", "NOT",
 		"EQ", "NEQ", "LT", "GT", "LE", "GE",
 		"MOV", "LOAD", "STORE", "ALLOCA", "GET_FIELD", "GET_INDEX", "GET_ADDR",
 		"LABEL", "JMP", "JMP_TRUE", "JMP_FALSE", "CALL", "RET", "PHI",
 		"LEN", "APPEND", "MAKE_SLICE", "MAKE_MAP", "CAST", "NOP",
 		"REMOVE", "REMOVE_INDEX", "DELETE", "CLEAR", "HAS", // Novas operações
+		"SPAWN", "SEND", "RECV", "CLOSE", "WAIT", "MAKE_CHAN", "SELECT",
+		"ARRAY_LIT",
+		"CONST",
+		"SWITCH",
+		"CONCAT",
 	}
 	if int(i.Op) < len(names) {
 		return names[i.Op]
@@ -178,17 +291,31 @@ type BasicBlock struct {
 // Function representa uma função compilada no IR
 type Function struct {
 	Name         string
-	Receiver     string
+	Receiver     string // Struct dos métodos e construtores (vazio em funções)
 	Params       []*Operand
 	Instructions []*Instruction // Representação linear
 	TempCount    int            // Contador para variáveis temporárias
 	LabelCount   int            // Contador para labels
 	ReturnType   semantic.Type
 	IsExported   bool
+	SSA          bool // As instruções estão em forma SSA (ver ToSSA e FromSSA)
+	Variadic     bool // O último parâmetro recebe os argumentos extras (tipo T[])
 	Generics     []string
 	// Pilha de labels para break/continue
 	BreakLabels    []string
 	ContinueLabels []string
+
+	ssaLabels map[string]bool // Labels criados por ToSSA, removidos por FromSSA se ficarem sem uso
+}
+
+// IsConstructor indica se a função é o init de uma struct
+func (f *Function) IsConstructor() bool {
+	return f.Receiver != "" && f.Name == "init"
+}
+
+// ConstructorName é o nome usado no IR para chamar o init de uma struct
+func ConstructorName(structName string) string {
+	return structName + ".init"
 }
 
 // Module representa o programa inteiro (pacote)
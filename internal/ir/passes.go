@@ -0,0 +1,354 @@
+package ir
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ============================
+// REGISTRO DE PASSES
+// ============================
+
+// PassInfo descreve um passe do otimizador
+type PassInfo struct {
+	Name        string
+	Description string
+	Requires    []string // Passes que precisam ter rodado antes deste
+	UndoneBy    string   // Passe que desfaz este e precisa rodar depois dele, antes da geração de código
+	Run         func(m *Module)
+}
+
+var (
+	registry      = make(map[string]*PassInfo)
+	registryOrder []string
+)
+
+// RegisterPass adiciona um passe ao registro, para ser usado pelos níveis de
+// otimização e por --passes
+func RegisterPass(p *PassInfo) {
+	if _, exists := registry[p.Name]; exists {
+		panic("ir: pass registered twice: " + p.Name)
+	}
+	registry[p.Name] = p
+	registryOrder = append(registryOrder, p.Name)
+}
+
+// RegisteredPasses retorna os passes na ordem em que foram registrados
+func RegisteredPasses() []*PassInfo {
+	passes := make([]*PassInfo, len(registryOrder))
+	for i, name := range registryOrder {
+		passes[i] = registry[name]
+	}
+	return passes
+}
+
+func init() {
+	RegisterPass(&PassInfo{
+		Name:        "unreachable-code",
+		Description: "remove os blocos que a entrada da função não alcança (pelo CFG)",
+		Run: func(m *Module) {
+			o := NewOptimizer(m)
+			for _, fn := range m.Functions {
+				o.EliminateUnreachableCode(fn)
+			}
+		},
+	})
+	RegisterPass(&PassInfo{
+		Name:        "constant-folding",
+		Description: "calcula operações entre literais, simplifica identidades e resolve saltos com condição constante",
+		Run: func(m *Module) {
+			o := NewOptimizer(m)
+			for _, fn := range m.Functions {
+				o.ConstantFolding(fn)
+			}
+		},
+	})
+	RegisterPass(&PassInfo{
+		Name:        "dead-code",
+		Description: "remove atribuições mortas, temporários sem uso e operações puras sem usuários (variáveis vivas)",
+		Run: func(m *Module) {
+			o := NewOptimizer(m)
+			for _, fn := range m.Functions {
+				o.EliminateDeadCode(fn)
+			}
+		},
+	})
+	RegisterPass(&PassInfo{
+		Name:        "ssa",
+		Description: "converte as funções para SSA com PHI",
+		UndoneBy:    "out-of-ssa", // O gerador de código não conhece PHI
+		Run:         (*Module).ToSSA,
+	})
+	RegisterPass(&PassInfo{
+		Name:        "constant-propagation",
+		Description: "troca usos de valores copiados de um literal pelo literal, enquanto a cópia valer",
+		Run: func(m *Module) {
+			o := NewOptimizer(m)
+			for _, fn := range m.Functions {
+				o.PropagateConstants(fn)
+			}
+		},
+	})
+	RegisterPass(&PassInfo{
+		Name:        "copy-propagation",
+		Description: "troca usos do destino de um MOV pela origem, enquanto nenhum dos dois for reescrito",
+		Run: func(m *Module) {
+			o := NewOptimizer(m)
+			for _, fn := range m.Functions {
+				o.PropagateCopies(fn)
+			}
+		},
+	})
+	RegisterPass(&PassInfo{
+		Name:        "gvn",
+		Description: "numeração de valores: reaproveita operações puras já calculadas em um ponto dominante",
+		Run: func(m *Module) {
+			o := NewOptimizer(m)
+			for _, fn := range m.Functions {
+				o.NumberValues(fn)
+			}
+		},
+	})
+	RegisterPass(&PassInfo{
+		Name:        "out-of-ssa",
+		Description: "traz as funções de volta da forma SSA (obrigatório antes da geração de código)",
+		Requires:    []string{"ssa"},
+		Run:         (*Module).FromSSA,
+	})
+}
+
+// OptLevels são os passes de cada nível (-O0, -O1, -O2). Toda propagação é
+// seguida de constant-folding: ela pode levar literais até uma operação, e o
+// Go recusa operações entre constantes que não dobram ou dividem por zero
+var OptLevels = [][]string{
+	{},
+	{"unreachable-code", "constant-folding", "copy-propagation", "constant-folding", "dead-code"},
+	{"unreachable-code", "constant-folding", "ssa", "constant-propagation", "constant-folding", "gvn", "copy-propagation", "constant-folding", "dead-code", "out-of-ssa", "copy-propagation", "constant-folding", "dead-code"},
+}
+
+// DefaultOptLevel é o nível usado quando nenhum -O é informado
+const DefaultOptLevel = 1
+
+// ============================
+// GERENCIADOR DE PASSES
+// ============================
+
+// PassOptions escolhe os passes a executar
+type PassOptions struct {
+	Level      int      // Nível de otimização (-O0, -O1, -O2)
+	Passes     []string // Lista explícita (--passes); substitui o nível
+	Disabled   []string // Passes removidos da lista (--disable-pass)
+	PrintAfter []string // Mostra a diferença no IR depois destes passes (--print-after; "all" para todos)
+}
+
+// PassTiming é o tempo gasto em uma execução de um passe
+type PassTiming struct {
+	Pass     string
+	Duration time.Duration
+}
+
+// PassManager executa uma sequência de passes sobre um módulo, rodando o
+// verificador entre eles em builds de depuração
+type PassManager struct {
+	Pipeline []string     // Passes na ordem de execução, com as dependências resolvidas
+	Timings  []PassTiming // Preenchido por Run
+	Out      io.Writer    // Destino de --print-after (padrão: stderr)
+
+	printAfter map[string]bool
+	reported   map[string]bool // Problemas do verificador já mostrados
+}
+
+// NewPassManager monta o pipeline: parte do nível (ou de --passes), remove
+// os desabilitados, insere as dependências que faltam antes de quem precisa
+// delas e, no final, os passes que desfazem os que ficaram sem desfazer
+// (UndoneBy). Um passe do nível cuja dependência foi desabilitada sai junto
+// com ela; pedido em --passes, é um erro
+func NewPassManager(opts PassOptions) (*PassManager, error) {
+	requested, explicit := opts.Passes, opts.Passes != nil
+	if !explicit {
+		if opts.Level < 0 || opts.Level >= len(OptLevels) {
+			return nil, fmt.Errorf("nível de otimização desconhecido: -O%d (use -O0 a -O%d)", opts.Level, len(OptLevels)-1)
+		}
+		requested = OptLevels[opts.Level]
+	}
+
+	for _, name := range append(append(slices.Clone(requested), opts.Disabled...), opts.PrintAfter...) {
+		if _, ok := registry[name]; !ok && name != "all" {
+			return nil, fmt.Errorf("passe desconhecido: '%s'", name)
+		}
+	}
+
+	pm := &PassManager{Out: os.Stderr, printAfter: make(map[string]bool)}
+	for _, name := range opts.PrintAfter {
+		pm.printAfter[name] = true
+	}
+
+	// add acrescenta o passe depois das suas dependências. Retorna false se
+	// o passe ou uma dependência dele está desabilitado
+	var add func(name string) (bool, error)
+	add = func(name string) (bool, error) {
+		if slices.Contains(opts.Disabled, name) {
+			return false, nil
+		}
+		for _, dep := range registry[name].Requires {
+			if slices.Contains(pm.Pipeline, dep) {
+				continue
+			}
+			ok, err := add(dep)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				if explicit {
+					return false, fmt.Errorf("o passe '%s' precisa de '%s', que está desabilitado", name, dep)
+				}
+				return false, nil
+			}
+		}
+		pm.Pipeline = append(pm.Pipeline, name)
+		return true, nil
+	}
+	for _, name := range requested {
+		if _, err := add(name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Passes que ainda precisam ser desfeitos no fim do pipeline
+	var pending []string
+	for _, name := range pm.Pipeline {
+		pending = slices.DeleteFunc(pending, func(p string) bool { return registry[p].UndoneBy == name })
+		if registry[name].UndoneBy != "" && !slices.Contains(pending, name) {
+			pending = append(pending, name)
+		}
+	}
+	for i := len(pending) - 1; i >= 0; i-- {
+		undo := registry[pending[i]].UndoneBy
+		if slices.Contains(opts.Disabled, undo) {
+			return nil, fmt.Errorf("o passe '%s' precisa de '%s' antes da geração de código, que está desabilitado", pending[i], undo)
+		}
+		pm.Pipeline = append(pm.Pipeline, undo)
+	}
+	return pm, nil
+}
+
+// Run executa o pipeline no módulo
+func (pm *PassManager) Run(m *Module) {
+	for _, name := range pm.Pipeline {
+		var before string
+		show := pm.printAfter[name] || pm.printAfter["all"]
+		if show {
+			before = FormatModule(m)
+		}
+
+		start := time.Now()
+		registry[name].Run(m)
+		pm.Timings = append(pm.Timings, PassTiming{Pass: name, Duration: time.Since(start)})
+
+		if show {
+			fmt.Fprintf(pm.Out, "; *** IR depois de %s ***\n", name)
+			if diff := DiffText(before, FormatModule(m)); diff != "" {
+				fmt.Fprint(pm.Out, diff)
+			} else {
+				fmt.Fprintln(pm.Out, "; (sem mudanças)")
+			}
+		}
+		pm.verifyAfter(m, name)
+	}
+}
+
+// verifyAfter roda o verificador depois de um passe em builds de depuração
+// (go build -tags debug). Cada problema é mostrado uma vez, junto do primeiro
+// passe depois do qual apareceu
+func (pm *PassManager) verifyAfter(m *Module, pass string) {
+	if !debugVerify {
+		return
+	}
+	err := Verify(m)
+	if err == nil {
+		return
+	}
+	if pm.reported == nil {
+		pm.reported = make(map[string]bool)
+	}
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		if msg := e.Error(); !pm.reported[msg] {
+			pm.reported[msg] = true
+			fmt.Fprintf(os.Stderr, "ir: after %s: %s\n", pass, msg)
+		}
+	}
+}
+
+// ============================
+// DIFERENÇA ENTRE VERSÕES DO IR
+// ============================
+
+// DiffText compara dois textos linha a linha (maior subsequência comum) e
+// retorna só os trechos alterados, com duas linhas de contexto: "-" para
+// linhas removidas e "+" para adicionadas. Retorna "" se forem iguais
+func DiffText(before, after string) string {
+	a, b := strings.Split(before, "\n"), strings.Split(after, "\n")
+
+	// lcs[i][j] = tamanho da maior subsequência comum de a[i:] e b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type line struct {
+		mark byte // ' ', '-' ou '+'
+		text string
+	}
+	var lines []line
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, line{' ', a[i]})
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, line{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, line{'+', b[j]})
+			j++
+		}
+	}
+
+	// Mantém as linhas alteradas e o contexto em volta delas
+	const context = 2
+	keep := make([]bool, len(lines))
+	for k, l := range lines {
+		if l.mark == ' ' {
+			continue
+		}
+		for c := max(0, k-context); c <= min(len(lines)-1, k+context); c++ {
+			keep[c] = true
+		}
+	}
+
+	var sb strings.Builder
+	for k, l := range lines {
+		if !keep[k] {
+			continue
+		}
+		if k > 0 && !keep[k-1] && sb.Len() > 0 {
+			sb.WriteString("...\n")
+		}
+		sb.WriteString(string(l.mark) + " " + l.text + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,49 @@
+package ir
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPassPipeline(t *testing.T) {
+	tests := []struct {
+		name string
+		opts PassOptions
+		want []string
+	}{
+		{"level", PassOptions{Level: 1}, OptLevels[1]},
+		{"requires", PassOptions{Passes: []string{"out-of-ssa"}}, []string{"ssa", "out-of-ssa"}},
+		{"undone", PassOptions{Passes: []string{"ssa", "gvn"}}, []string{"ssa", "gvn", "out-of-ssa"}},
+		{"undone once", PassOptions{Passes: []string{"ssa", "out-of-ssa", "dead-code"}}, []string{"ssa", "out-of-ssa", "dead-code"}},
+		{
+			"disabled dependency drops the level passes that need it",
+			PassOptions{Level: 2, Disabled: []string{"ssa"}},
+			slices.DeleteFunc(slices.Clone(OptLevels[2]), func(p string) bool { return p == "ssa" || p == "out-of-ssa" }),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm, err := NewPassManager(tt.opts)
+			if err != nil {
+				t.Fatalf("NewPassManager: %v", err)
+			}
+			if !slices.Equal(pm.Pipeline, tt.want) {
+				t.Errorf("pipeline = %v, want %v", pm.Pipeline, tt.want)
+			}
+		})
+	}
+}
+
+func TestPassPipelineErrors(t *testing.T) {
+	tests := []PassOptions{
+		{Level: 3},
+		{Passes: []string{"inline"}},
+		{Passes: []string{"out-of-ssa"}, Disabled: []string{"ssa"}},
+		{Passes: []string{"ssa"}, Disabled: []string{"out-of-ssa"}},
+	}
+	for _, opts := range tests {
+		if pm, err := NewPassManager(opts); err == nil {
+			t.Errorf("NewPassManager(%+v) = %v, want an error", opts, pm.Pipeline)
+		}
+	}
+}
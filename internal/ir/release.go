@@ -0,0 +1,7 @@
+//go:build !debug
+
+package ir
+
+// debugVerify liga o verificador entre os passes do otimizador
+// (go build -tags debug)
+const debugVerify = false
@@ -0,0 +1,520 @@
+package ir
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alpha/internal/semantic"
+)
+
+// ============================
+// FORMA SSA (STATIC SINGLE ASSIGNMENT)
+// ============================
+
+// Em SSA cada variável local é escrita uma única vez: cada atribuição cria uma
+// versão nova (x.1, x.2, ...) e, onde caminhos com versões diferentes se
+// encontram, uma PHI escolhe a versão conforme o bloco de onde o fluxo veio.
+// A própria variável (x, sem versão) é o valor que ela tinha ao entrar na
+// função: o argumento, no caso de parâmetros.
+//
+// Só entram em SSA as variáveis locais cujo valor não pode mudar por fora
+// das atribuições: globais, constantes e variáveis alteradas pelo endereço
+// (campos e elementos, remove, select) continuam como estão
+
+// ssaName é o nome da versão n de uma variável
+func ssaName(base string, version int) string {
+	return fmt.Sprintf("%s.%d", base, version)
+}
+
+// SSABase retorna a variável de origem de uma versão ("x.2" -> "x").
+// Identificadores do Alpha não têm ponto, então não há ambiguidade
+func SSABase(name string) string {
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// ToSSA converte todas as funções do módulo para a forma SSA
+func (m *Module) ToSSA() {
+	globals := make(map[string]bool)
+	for _, instr := range m.Globals {
+		if instr.Result != nil {
+			globals[instr.Result.Value] = true
+		}
+	}
+	for _, fn := range m.Functions {
+		toSSA(fn, globals)
+	}
+}
+
+// FromSSA traz todas as funções do módulo de volta da forma SSA. Deve ser
+// chamado antes da geração de código, que não conhece PHI nem versões
+func (m *Module) FromSSA() {
+	for _, fn := range m.Functions {
+		fromSSA(fn)
+	}
+}
+
+// promotableVars retorna as variáveis da função que podem entrar em SSA,
+// com o tipo declarado de cada uma
+func promotableVars(fn *Function, globals map[string]bool) map[string]semantic.Type {
+	vars := make(map[string]semantic.Type)
+	for _, p := range fn.Params {
+		vars[p.Value] = p.Type
+	}
+	for _, instr := range fn.Instructions {
+		if instr.Op == ALLOCA && instr.Result != nil && instr.Result.Kind == OpVar {
+			if _, ok := vars[instr.Result.Value]; !ok && instr.Arg1 != nil {
+				vars[instr.Result.Value] = instr.Arg1.Type
+			}
+		}
+	}
+
+	exclude := func(ops ...*Operand) {
+		for _, op := range ops {
+			if op != nil && op.Kind == OpVar {
+				delete(vars, op.Value)
+			}
+		}
+	}
+	for name := range vars {
+		if globals[name] {
+			delete(vars, name)
+		}
+	}
+	for _, instr := range fn.Instructions {
+		switch instr.Op {
+		case CONST:
+			exclude(instr.Result)
+		case GET_ADDR, REMOVE, REMOVE_INDEX, DELETE, CLEAR:
+			exclude(instr.Arg1)
+		}
+		for _, c := range instr.Select {
+			exclude(c.Chan, c.Value)
+		}
+	}
+	return vars
+}
+
+// replaceUses troca cada operando lido pela instrução pelo retorno de f.
+// Os operandos são substituídos, nunca alterados: o gerador compartilha o
+// mesmo *Operand entre instruções
+func (i *Instruction) replaceUses(f func(*Operand) *Operand) {
+	if i.Op != STORE || i.Arg1 == nil || i.Arg1.Kind != OpVar {
+		i.Arg1 = f(i.Arg1)
+	}
+	i.Arg2 = f(i.Arg2)
+	for j := range i.Args {
+		i.Args[j] = f(i.Args[j])
+	}
+	for _, c := range i.Switch {
+		for j := range c.Values {
+			c.Values[j] = f(c.Values[j])
+		}
+	}
+	for _, c := range i.Select {
+		c.Chan, c.Value = f(c.Chan), f(c.Value)
+	}
+}
+
+// ============================
+// CONSTRUÇÃO
+// ============================
+
+// toSSA converte a função: remove blocos inalcançáveis, garante um label no
+// início de cada bloco (as PHI referenciam os predecessores pelo label),
+// insere PHI nas fronteiras de dominância onde a variável está viva (SSA
+// podada) e renomeia as definições e os usos percorrendo a árvore de
+// dominadores
+func toSSA(fn *Function, globals map[string]bool) {
+	if fn.SSA {
+		return
+	}
+
+	vars := promotableVars(fn, globals)
+	cfg := prepareBlocks(fn)
+	live := ComputeLiveness(cfg)
+
+	// Blocos que definem cada variável
+	defsites := make(map[string][]*BasicBlock)
+	for _, b := range cfg.Blocks {
+		for _, instr := range b.Instructions {
+			if def := instr.Def(); def != nil && def.Kind == OpVar {
+				if _, ok := vars[def.Value]; ok && !containsBlock(defsites[def.Value], b) {
+					defsites[def.Value] = append(defsites[def.Value], b)
+				}
+			}
+		}
+	}
+
+	// Inserção das PHI (Cytron et al.), em ordem de nome para um resultado estável
+	names := make([]string, 0, len(defsites))
+	for name := range defsites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	frontier := cfg.DominanceFrontier()
+	phis := make(map[*BasicBlock][]*Instruction)
+	phiVar := make(map[*Instruction]string)
+	for _, name := range names {
+		placed := make(map[*BasicBlock]bool)
+		work := append([]*BasicBlock(nil), defsites[name]...)
+		for len(work) > 0 {
+			b := work[len(work)-1]
+			work = work[:len(work)-1]
+			for _, join := range frontier[b] {
+				if placed[join] || !live.In[join][name] {
+					continue
+				}
+				placed[join] = true
+
+				phi := &Instruction{Op: PHI, Result: Var(name, vars[name])}
+				for _, pred := range join.Predecessors {
+					phi.Args = append(phi.Args, Var(name, vars[name]))
+					phi.From = append(phi.From, &Operand{Kind: OpLabel, Value: pred.Label})
+				}
+				phis[join] = append(phis[join], phi)
+				phiVar[phi] = name
+
+				if !containsBlock(defsites[name], join) {
+					work = append(work, join)
+				}
+			}
+		}
+	}
+	for b, list := range phis {
+		// Depois do LABEL que abre o bloco
+		rest := append(list, b.Instructions[1:]...)
+		b.Instructions = append(b.Instructions[:1:1], rest...)
+	}
+
+	// Renomeação
+	counters := make(map[string]int)
+	stacks := make(map[string][]*Operand)
+	current := func(name string, typ semantic.Type) *Operand {
+		if stack := stacks[name]; len(stack) > 0 {
+			return stack[len(stack)-1]
+		}
+		return Var(name, typ)
+	}
+
+	children := cfg.DomChildren()
+	var rename func(b *BasicBlock)
+	rename = func(b *BasicBlock) {
+		pushed := make(map[string]int)
+		define := func(old *Operand) *Operand {
+			name := old.Value
+			counters[name]++
+			typ := old.Type
+			if typ == nil {
+				typ = vars[name]
+			}
+			version := Var(ssaName(name, counters[name]), typ)
+			stacks[name] = append(stacks[name], version)
+			pushed[name]++
+			return version
+		}
+
+		for _, instr := range b.Instructions {
+			if instr.Op == PHI {
+				instr.Result = define(instr.Result)
+				continue
+			}
+
+			instr.replaceUses(func(op *Operand) *Operand {
+				if op == nil || op.Kind != OpVar {
+					return op
+				}
+				if _, ok := vars[op.Value]; !ok {
+					return op
+				}
+				return current(op.Value, op.Type)
+			})
+
+			def := instr.Def()
+			if def == nil || def.Kind != OpVar {
+				continue
+			}
+			if _, ok := vars[def.Value]; !ok {
+				continue
+			}
+			if instr.Op == STORE {
+				// STORE x, v vira x.n = MOV v: em SSA toda definição fica em Result
+				instr.Op, instr.Arg1, instr.Arg2 = MOV, instr.Arg2, nil
+			}
+			instr.Result = define(def)
+		}
+
+		// Preenche nas PHI dos sucessores o valor que sai deste bloco
+		for _, s := range b.Successors {
+			for _, phi := range phis[s] {
+				for j, from := range phi.From {
+					if from.Value == b.Label {
+						name := phiVar[phi]
+						phi.Args[j] = current(name, vars[name])
+					}
+				}
+			}
+		}
+
+		for _, child := range children[b] {
+			rename(child)
+		}
+		for name, n := range pushed {
+			stacks[name] = stacks[name][:len(stacks[name])-n]
+		}
+	}
+	rename(cfg.Entry)
+
+	fn.Instructions = flattenBlocks(cfg)
+	fn.SSA = true
+}
+
+// prepareBlocks remove os blocos inalcançáveis e garante que todo bloco
+// comece com um LABEL e que a entrada não tenha predecessores (um loop logo
+// no início da função ganha um bloco vazio antes dele). Retorna o CFG das
+// instruções resultantes
+func prepareBlocks(fn *Function) *CFG {
+	cfg := BuildCFG(fn)
+
+	newLabel := func() *Instruction {
+		name := fmt.Sprintf("bb_%d", fn.LabelCount)
+		fn.LabelCount++
+		if fn.ssaLabels == nil {
+			fn.ssaLabels = make(map[string]bool)
+		}
+		fn.ssaLabels[name] = true
+		return &Instruction{Op: LABEL, Arg1: &Operand{Kind: OpLabel, Value: name}}
+	}
+
+	var out []*Instruction
+	if len(cfg.Entry.Predecessors) > 0 {
+		out = append(out, newLabel())
+	}
+	for _, b := range cfg.Blocks {
+		if !cfg.Reachable(b) {
+			continue
+		}
+		if first := b.first(); first == nil || first.Op != LABEL {
+			out = append(out, newLabel())
+		}
+		out = append(out, b.Instructions...)
+	}
+
+	fn.Instructions = out
+	return BuildCFG(fn)
+}
+
+// flattenBlocks junta as instruções dos blocos de volta na ordem linear
+func flattenBlocks(cfg *CFG) []*Instruction {
+	var out []*Instruction
+	for _, b := range cfg.Blocks {
+		out = append(out, b.Instructions...)
+	}
+	return out
+}
+
+// ============================
+// DESTRUIÇÃO
+// ============================
+
+// fromSSA troca cada PHI por cópias: cada predecessor copia o seu valor para
+// uma versão nova e exclusiva da PHI antes de saltar, e a PHI vira uma cópia
+// dessa versão. Como cada PHI tem sua própria versão, as cópias de PHI
+// diferentes nunca se atrapalham (problemas da "cópia perdida" e da troca).
+// Depois as versões voltam aos nomes originais, e as cópias que ficam com o
+// mesmo nome dos dois lados desaparecem
+func fromSSA(fn *Function) {
+	if !fn.SSA {
+		return
+	}
+
+	// Última versão de cada variável, para criar as versões das cópias
+	versions := make(map[string]int)
+	for _, instr := range fn.Instructions {
+		if def := instr.Def(); def != nil && def.Kind == OpVar {
+			var n int
+			if _, err := fmt.Sscanf(strings.TrimPrefix(def.Value, SSABase(def.Value)), ".%d", &n); err == nil {
+				versions[SSABase(def.Value)] = max(versions[SSABase(def.Value)], n)
+			}
+		}
+	}
+
+	cfg := BuildCFG(fn)
+	for _, b := range cfg.Blocks {
+		for i, instr := range b.Instructions {
+			if instr.Op != PHI {
+				continue
+			}
+			base := SSABase(instr.Result.Value)
+			versions[base]++
+			tmp := Var(ssaName(base, versions[base]), instr.Result.Type)
+
+			for j, arg := range instr.Args {
+				if pred := cfg.Block(instr.From[j].Value); pred != nil {
+					pred.insertBeforeTerminator(&Instruction{Op: MOV, Arg1: arg, Result: tmp})
+				}
+			}
+			b.Instructions[i] = &Instruction{Op: MOV, Arg1: tmp, Result: instr.Result, Line: instr.Line}
+		}
+	}
+	fn.Instructions = flattenBlocks(cfg)
+
+	restoreNames(fn)
+	removeSSALabels(fn)
+	fn.SSA = false
+}
+
+// insertBeforeTerminator acrescenta a instrução no fim do bloco, antes do
+// salto que o encerra (se houver)
+func (b *BasicBlock) insertBeforeTerminator(instr *Instruction) {
+	at := len(b.Instructions)
+	if last := b.last(); last != nil && isTerminator(last.Op) {
+		at--
+	}
+	b.Instructions = append(b.Instructions, nil)
+	copy(b.Instructions[at+1:], b.Instructions[at:])
+	b.Instructions[at] = instr
+}
+
+// restoreNames devolve às versões o nome da variável original. Versões da
+// mesma variável que ficam vivas ao mesmo tempo (o que acontece quando uma
+// otimização move usos, como a propagação de cópias) não podem dividir o
+// nome: as que conflitam com as já renomeadas viram temporários
+func restoreNames(fn *Function) {
+	cfg := BuildCFG(fn)
+	live := ComputeLiveness(cfg)
+
+	// Conflitos entre versões da mesma variável: a definição de uma acontece
+	// enquanto a outra ainda está viva (exceto em x.2 = MOV x.1, em que
+	// dividir o nome é justamente o que se quer)
+	conflicts := make(map[string]map[string]bool)
+	conflict := func(a, b string) {
+		if conflicts[a] == nil {
+			conflicts[a] = make(map[string]bool)
+		}
+		if conflicts[b] == nil {
+			conflicts[b] = make(map[string]bool)
+		}
+		conflicts[a][b], conflicts[b][a] = true, true
+	}
+
+	for _, b := range cfg.Blocks {
+		alive := make(map[string]bool)
+		for key := range live.Out[b] {
+			alive[key] = true
+		}
+		for i := len(b.Instructions) - 1; i >= 0; i-- {
+			instr := b.Instructions[i]
+			if def := instr.Def(); def != nil && def.Kind == OpVar {
+				for key := range alive {
+					if key == def.Value || SSABase(key) != SSABase(def.Value) {
+						continue
+					}
+					if instr.Op == MOV && instr.Arg1 != nil && instr.Arg1.Kind == OpVar && instr.Arg1.Value == key {
+						continue
+					}
+					conflict(def.Value, key)
+				}
+				delete(alive, def.Value)
+			}
+			for _, op := range instr.Uses() {
+				if key, ok := valueKey(op); ok {
+					alive[key] = true
+				}
+			}
+		}
+	}
+
+	// Em ordem de definição, cada versão fica com o nome original se não
+	// conflitar com nenhuma versão que já o recebeu
+	renamed := make(map[string]*Operand)
+	owners := make(map[string][]string)
+	for _, instr := range fn.Instructions {
+		def := instr.Def()
+		if def == nil || def.Kind != OpVar || !strings.Contains(def.Value, ".") {
+			continue
+		}
+		if _, done := renamed[def.Value]; done {
+			continue
+		}
+
+		base := SSABase(def.Value)
+		shared := !conflicts[def.Value][base]
+		for _, owner := range owners[base] {
+			if conflicts[def.Value][owner] {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			renamed[def.Value] = Var(base, def.Type)
+			owners[base] = append(owners[base], def.Value)
+		} else {
+			renamed[def.Value] = &Operand{Kind: OpTemp, Value: fmt.Sprintf("t%d", fn.TempCount), Type: def.Type}
+			fn.TempCount++
+		}
+	}
+
+	rename := func(op *Operand) *Operand {
+		if op != nil && op.Kind == OpVar {
+			if to, ok := renamed[op.Value]; ok {
+				return &Operand{Kind: to.Kind, Value: to.Value, Type: op.Type}
+			}
+		}
+		return op
+	}
+
+	out := fn.Instructions[:0]
+	for _, instr := range fn.Instructions {
+		instr.replaceUses(rename)
+		instr.Result = rename(instr.Result)
+		if instr.Op == STORE && instr.Arg1 != nil && instr.Arg1.Kind == OpVar {
+			instr.Arg1 = rename(instr.Arg1)
+		}
+
+		// x = MOV x sobra das cópias de PHI que voltaram ao mesmo nome
+		if instr.Op == MOV && instr.Arg1 != nil && instr.Result != nil &&
+			instr.Arg1.Kind == instr.Result.Kind && instr.Arg1.Value == instr.Result.Value {
+			continue
+		}
+		out = append(out, instr)
+	}
+	fn.Instructions = out
+}
+
+// removeSSALabels remove os labels criados por ToSSA que nenhum salto usa
+func removeSSALabels(fn *Function) {
+	if len(fn.ssaLabels) == 0 {
+		return
+	}
+
+	used := make(map[string]bool)
+	for _, instr := range fn.Instructions {
+		switch instr.Op {
+		case JMP:
+			used[instr.Arg1.Value] = true
+		case JMP_TRUE, JMP_FALSE:
+			used[instr.Arg2.Value] = true
+		}
+		for _, c := range instr.Switch {
+			used[c.Label.Value] = true
+		}
+		for _, c := range instr.Select {
+			used[c.Label.Value] = true
+		}
+	}
+
+	out := fn.Instructions[:0]
+	for _, instr := range fn.Instructions {
+		if instr.Op == LABEL && fn.ssaLabels[instr.Arg1.Value] && !used[instr.Arg1.Value] {
+			continue
+		}
+		out = append(out, instr)
+	}
+	fn.Instructions = out
+	fn.ssaLabels = nil
+}
@@ -0,0 +1,287 @@
+package ir
+
+import (
+	"strconv"
+	"testing"
+)
+
+// diamond define x e y nos dois lados de um if, mas só x é lido depois
+const diamond = `module main
+
+func pick(c:bool) int {
+  x:int = ALLOCA type:int
+  y:int = ALLOCA type:int
+  x:int = MOV 1
+  y:int = MOV 5
+  JMP_FALSE c:bool, .else
+  x:int = MOV 2
+  y:int = MOV 6
+  JMP .join
+.else:
+  x:int = MOV 3
+  y:int = MOV 7
+.join:
+  RET x:int
+}
+`
+
+// swap troca a e b a cada volta: as PHI do cabeçalho leem uma à outra
+const swap = `module main
+
+func ssa swap(n:int) int {
+.start:
+  a.1:int = MOV 1
+  b.1:int = MOV 2
+  i.1:int = MOV 0
+.loop:
+  a.2:int = PHI [a.1:int, .start], [b.2:int, .body]
+  b.2:int = PHI [b.1:int, .start], [a.2:int, .body]
+  i.2:int = PHI [i.1:int, .start], [i.3:int, .body]
+  %t0:bool = LT i.2:int, n:int
+  JMP_FALSE %t0:bool, .done
+.body:
+  i.3:int = ADD i.2:int, 1
+  JMP .loop
+.done:
+  %t1:int = MUL a.2:int, 10
+  %t2:int = ADD %t1:int, b.2:int
+  RET %t2:int
+}
+`
+
+// lostCopy lê depois do loop a versão de antes do último incremento, que
+// continua viva enquanto a PHI já recebeu a nova
+const lostCopy = `module main
+
+func ssa count(n:int) int {
+.start:
+  x.1:int = MOV 0
+.loop:
+  x.2:int = PHI [x.1:int, .start], [x.3:int, .loop]
+  x.3:int = ADD x.2:int, 1
+  %t0:bool = LT x.3:int, n:int
+  JMP_TRUE %t0:bool, .loop
+.done:
+  RET x.2:int
+}
+`
+
+// phis retorna as PHI do bloco com o label dado
+func phis(t *testing.T, fn *Function, label string) []*Instruction {
+	t.Helper()
+	b := BuildCFG(fn).Block(label)
+	if b == nil {
+		t.Fatalf("no block %s in\n%s", label, dump(fn))
+	}
+	var list []*Instruction
+	for _, instr := range b.Instructions {
+		if instr.Op == PHI {
+			list = append(list, instr)
+		}
+	}
+	return list
+}
+
+// phiBases retorna as variáveis de origem das PHI
+func phiBases(list []*Instruction) []string {
+	names := make([]string, len(list))
+	for i, phi := range list {
+		names[i] = SSABase(phi.Result.Value)
+	}
+	return names
+}
+
+func TestPhiAtJoin(t *testing.T) {
+	fn := parseOnly(t, diamond)
+	toSSA(fn, nil)
+
+	// y é redefinida nos dois lados, mas está morta no join (SSA podada)
+	if got := phiBases(phis(t, fn, "join")); len(got) != 1 || got[0] != "x" {
+		t.Fatalf("PHI at join for %v, want [x]:\n%s", got, dump(fn))
+	}
+	if phi := phis(t, fn, "join")[0]; len(phi.Args) != 2 || phi.Args[0].Value == phi.Args[1].Value {
+		t.Errorf("join PHI should merge the two branch versions:\n%s", dump(fn))
+	}
+	for _, c := range []bool{true, false} {
+		want := map[bool]int64{true: 2, false: 3}[c]
+		if got := run(t, fn, boolValue(c)); got != want {
+			t.Errorf("pick(%v) = %d, want %d", c, got, want)
+		}
+	}
+}
+
+func TestPhiAtLoopHeaders(t *testing.T) {
+	fn := parseOnly(t, nestedLoops)
+	toSSA(fn, nil)
+
+	// j é zerada antes de o loop interno começar, e i não muda dentro dele
+	if got := phiBases(phis(t, fn, "outer")); len(got) != 1 || got[0] != "i" {
+		t.Errorf("PHI at outer for %v, want [i]:\n%s", got, dump(fn))
+	}
+	if got := phiBases(phis(t, fn, "inner")); len(got) != 1 || got[0] != "j" {
+		t.Errorf("PHI at inner for %v, want [j]:\n%s", got, dump(fn))
+	}
+	if got := run(t, fn, 4); got != 4 {
+		t.Errorf("count(4) = %d, want 4", got)
+	}
+}
+
+func TestFromSSASwap(t *testing.T) {
+	fn := parseOnly(t, swap)
+	want := map[int64]int64{0: 12, 1: 21, 2: 12, 3: 21}
+	for n, w := range want {
+		if got := run(t, fn, n); got != w {
+			t.Fatalf("SSA swap(%d) = %d, want %d", n, got, w)
+		}
+	}
+
+	fromSSA(fn)
+	if fn.SSA || indexOf(fn, PHI) >= 0 {
+		t.Fatalf("PHI left after fromSSA:\n%s", dump(fn))
+	}
+	for n, w := range want {
+		if got := run(t, fn, n); got != w {
+			t.Errorf("swap(%d) = %d, want %d:\n%s", n, got, w, dump(fn))
+		}
+	}
+}
+
+func TestFromSSALostCopy(t *testing.T) {
+	fn := parseOnly(t, lostCopy)
+	want := map[int64]int64{0: 0, 1: 0, 5: 4}
+	for n, w := range want {
+		if got := run(t, fn, n); got != w {
+			t.Fatalf("SSA count(%d) = %d, want %d", n, got, w)
+		}
+	}
+
+	fromSSA(fn)
+	if fn.SSA || indexOf(fn, PHI) >= 0 {
+		t.Fatalf("PHI left after fromSSA:\n%s", dump(fn))
+	}
+	for n, w := range want {
+		if got := run(t, fn, n); got != w {
+			t.Errorf("count(%d) = %d, want %d:\n%s", n, got, w, dump(fn))
+		}
+	}
+}
+
+func boolValue(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// run interpreta uma função de inteiros e booleanos (verdadeiro é 1) com os
+// argumentos dados e retorna o valor do RET. As PHI do início de um bloco
+// são avaliadas juntas, com o label do bloco de onde o fluxo veio
+func run(t *testing.T, fn *Function, args ...int64) int64 {
+	t.Helper()
+	env := make(map[string]int64)
+	for i, p := range fn.Params {
+		env[p.Value] = args[i]
+	}
+	labels := make(map[string]int)
+	for i, instr := range fn.Instructions {
+		if instr.Op == LABEL {
+			labels[instr.Arg1.Value] = i
+		}
+	}
+
+	value := func(op *Operand) int64 {
+		if op.Kind == OpLiteral {
+			switch op.Value {
+			case "true":
+				return 1
+			case "false":
+				return 0
+			}
+			n, err := strconv.ParseInt(op.Value, 10, 64)
+			if err != nil {
+				t.Fatalf("literal %s: %v", op.Value, err)
+			}
+			return n
+		}
+		key, _ := valueKey(op)
+		v, ok := env[key]
+		if !ok {
+			t.Fatalf("%s read before being written:\n%s", key, dump(fn))
+		}
+		return v
+	}
+
+	var block, from string
+	for pc, steps := 0, 0; pc < len(fn.Instructions); pc++ {
+		if steps++; steps > 10000 {
+			t.Fatalf("%s does not terminate", fn.Name)
+		}
+		instr := fn.Instructions[pc]
+		set := func(v int64) {
+			key, _ := valueKey(instr.Result)
+			env[key] = v
+		}
+		jump := func(label string) {
+			pc = labels[label] - 1
+		}
+
+		switch instr.Op {
+		case LABEL:
+			block, from = instr.Arg1.Value, block
+		case PHI:
+			// Todas as PHI do bloco leem os valores de antes de qualquer uma escrever
+			end := pc
+			for end < len(fn.Instructions) && fn.Instructions[end].Op == PHI {
+				end++
+			}
+			values := make([]int64, 0, end-pc)
+			for _, phi := range fn.Instructions[pc:end] {
+				found := false
+				for j, f := range phi.From {
+					if f.Value == from {
+						values = append(values, value(phi.Args[j]))
+						found = true
+					}
+				}
+				if !found {
+					t.Fatalf("PHI %s has no value for .%s", FormatInstruction(phi), from)
+				}
+			}
+			for j, phi := range fn.Instructions[pc:end] {
+				key, _ := valueKey(phi.Result)
+				env[key] = values[j]
+			}
+			pc = end - 1
+		case ALLOCA:
+			set(0)
+		case MOV:
+			set(value(instr.Arg1))
+		case ADD:
+			set(value(instr.Arg1) + value(instr.Arg2))
+		case SUB:
+			set(value(instr.Arg1) - value(instr.Arg2))
+		case MUL:
+			set(value(instr.Arg1) * value(instr.Arg2))
+		case LT:
+			set(boolValue(value(instr.Arg1) < value(instr.Arg2)))
+		case EQ:
+			set(boolValue(value(instr.Arg1) == value(instr.Arg2)))
+		case JMP:
+			jump(instr.Arg1.Value)
+		case JMP_TRUE:
+			if value(instr.Arg1) != 0 {
+				jump(instr.Arg2.Value)
+			}
+		case JMP_FALSE:
+			if value(instr.Arg1) == 0 {
+				jump(instr.Arg2.Value)
+			}
+		case RET:
+			return value(instr.Arg1)
+		default:
+			t.Fatalf("run does not support %s", FormatInstruction(instr))
+		}
+	}
+	t.Fatalf("%s ended without RET", fn.Name)
+	return 0
+}
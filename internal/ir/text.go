@@ -0,0 +1,1045 @@
+package ir
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/alpha/internal/parser"
+	"github.com/alpha/internal/semantic"
+)
+
+// ============================
+// FORMATO TEXTUAL DO IR (.air)
+// ============================
+
+// O formato textual descreve um módulo inteiro de forma que ParseModule
+// consiga reconstruí-lo:
+//
+//	; comentário
+//	module main
+//	import "strings"
+//
+//	struct Point {
+//	  x int
+//	  private y int
+//	}
+//
+//	globals {
+//	  total:int = ALLOCA type:int
+//	}
+//
+//	func export sum(a:int, b:int) int {
+//	.loop_0:
+//	  %t0:int = ADD a:int, b:int
+//	  RET %t0:int
+//	}
+//
+// Operandos: %t0 (temporário), x ou x.2 (variável ou versão SSA), .label,
+// @função, #campo, type:T, literais (10, 2.5, true, "texto", 'c', ou $"..."
+// para qualquer outro texto) e _ (ausente). O tipo vem depois de ':' e é
+// escrito sem espaços (map<string,int>, int|string). Métodos levam o nome da
+// struct (Point.area) e os atributos export, variadic e ssa vêm antes do nome
+
+// FormatModule escreve o módulo no formato textual
+func FormatModule(m *Module) string {
+	var sb strings.Builder
+	sb.WriteString("; Alpha IR\n")
+	fmt.Fprintf(&sb, "module %s\n", m.Name)
+	for _, imp := range m.Imports {
+		fmt.Fprintf(&sb, "import %s\n", strconv.Quote(imp))
+	}
+
+	for _, s := range m.Structs {
+		sb.WriteString("\nstruct " + s.Name)
+		if len(s.Generics) > 0 {
+			names := make([]string, len(s.Generics))
+			for i, g := range s.Generics {
+				names[i] = g.Name
+			}
+			sb.WriteString("<" + strings.Join(names, ",") + ">")
+		}
+		sb.WriteString(" {\n")
+		for _, f := range s.Fields {
+			sb.WriteString("  ")
+			if f.IsPrivate {
+				sb.WriteString("private ")
+			}
+			fmt.Fprintf(&sb, "%s %s\n", f.Name, formatParserType(f.Type))
+		}
+		sb.WriteString("}\n")
+	}
+
+	if len(m.Globals) > 0 {
+		sb.WriteString("\nglobals {\n")
+		for _, instr := range m.Globals {
+			sb.WriteString("  " + FormatInstruction(instr) + "\n")
+		}
+		sb.WriteString("}\n")
+	}
+
+	for _, fn := range m.Functions {
+		sb.WriteString("\n")
+		formatFunction(&sb, fn)
+	}
+	return sb.String()
+}
+
+func formatFunction(sb *strings.Builder, fn *Function) {
+	sb.WriteString("func ")
+	if fn.IsExported {
+		sb.WriteString("export ")
+	}
+	if fn.Variadic {
+		sb.WriteString("variadic ")
+	}
+	if fn.SSA {
+		sb.WriteString("ssa ")
+	}
+	if fn.Receiver != "" {
+		sb.WriteString(fn.Receiver + ".")
+	}
+	sb.WriteString(fn.Name)
+	if len(fn.Generics) > 0 {
+		sb.WriteString("<" + strings.Join(fn.Generics, ",") + ">")
+	}
+
+	params := make([]string, len(fn.Params))
+	for i, p := range fn.Params {
+		params[i] = formatOperand(p)
+	}
+	sb.WriteString("(" + strings.Join(params, ", ") + ")")
+	if t := formatType(fn.ReturnType); t != "" {
+		sb.WriteString(" " + t)
+	}
+	sb.WriteString(" {\n")
+
+	for _, instr := range fn.Instructions {
+		if instr.Op != LABEL {
+			sb.WriteString("  ")
+		}
+		sb.WriteString(FormatInstruction(instr) + "\n")
+	}
+	sb.WriteString("}\n")
+}
+
+// FormatInstruction escreve uma instrução no formato textual (uma linha)
+func FormatInstruction(i *Instruction) string {
+	if i.Op == LABEL {
+		return formatOperand(i.Arg1) + ":"
+	}
+
+	var sb strings.Builder
+	if i.Result != nil {
+		sb.WriteString(formatOperand(i.Result) + " = ")
+	}
+	sb.WriteString(i.opToString())
+
+	if i.Op == PHI {
+		for j, arg := range i.Args {
+			if j > 0 {
+				sb.WriteString(",")
+			}
+			fmt.Fprintf(&sb, " [%s, %s]", formatOperand(arg), formatOperand(i.From[j]))
+		}
+		return sb.String()
+	}
+
+	if i.Arg1 != nil || i.Arg2 != nil {
+		sb.WriteString(" " + formatOperand(i.Arg1))
+		if i.Arg2 != nil {
+			sb.WriteString(", " + formatOperand(i.Arg2))
+		}
+	}
+	if len(i.Args) > 0 {
+		args := make([]string, len(i.Args))
+		for j, arg := range i.Args {
+			args[j] = formatOperand(arg)
+		}
+		sb.WriteString(" (" + strings.Join(args, ", ") + ")")
+	}
+	if i.Spread {
+		sb.WriteString(" ...")
+	}
+
+	for _, c := range i.Select {
+		switch c.Kind {
+		case SelectRecv:
+			sb.WriteString(" [recv " + formatOperand(c.Chan))
+			if c.Value != nil {
+				sb.WriteString(" -> " + formatOperand(c.Value))
+			}
+		case SelectSend:
+			fmt.Fprintf(&sb, " [send %s <- %s", formatOperand(c.Chan), formatOperand(c.Value))
+		case SelectDefault:
+			sb.WriteString(" [default")
+		}
+		fmt.Fprintf(&sb, ": %s]", formatOperand(c.Label))
+	}
+
+	for _, c := range i.Switch {
+		var items []string
+		for _, v := range c.Values {
+			items = append(items, formatOperand(v))
+		}
+		for _, t := range c.Types {
+			items = append(items, formatOperand(&Operand{Kind: OpType, Type: t}))
+		}
+		if c.IsDefault() {
+			items = []string{"default"}
+		}
+		fmt.Fprintf(&sb, " [%s: %s]", strings.Join(items, ", "), formatOperand(c.Label))
+	}
+	return sb.String()
+}
+
+func formatOperand(op *Operand) string {
+	if op == nil {
+		return "_"
+	}
+
+	var core string
+	switch op.Kind {
+	case OpTemp:
+		core = "%" + op.Value
+	case OpLabel:
+		return "." + op.Value
+	case OpFunction:
+		core = "@" + op.Value
+	case OpField:
+		core = "#" + op.Value
+	case OpType:
+		core = "type"
+	case OpLiteral:
+		core = formatLiteral(op)
+	default:
+		core = op.Value
+	}
+
+	if t := formatType(op.Type); t != "" {
+		core += ":" + t
+	}
+	return core
+}
+
+func formatLiteral(op *Operand) string {
+	switch semantic.StringifyType(op.Type) {
+	case "string":
+		return strconv.Quote(op.Value)
+	case "char":
+		r, _ := utf8.DecodeRuneInString(op.Value)
+		return strconv.QuoteRune(r)
+	}
+	if isBareLiteral(op.Value) {
+		return op.Value
+	}
+	return "$" + strconv.Quote(op.Value)
+}
+
+// isBareLiteral indica se o literal pode ser escrito sem aspas: números
+// (com sinal, expoente, prefixo de base ou separadores) e booleanos
+func isBareLiteral(s string) bool {
+	if s == "true" || s == "false" {
+		return true
+	}
+	digits := strings.TrimLeft(s, "+-")
+	if digits == "" || digits[0] < '0' || digits[0] > '9' {
+		return false
+	}
+	for _, r := range digits {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("._+-", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatType escreve o tipo sem espaços ("" quando não há tipo)
+func formatType(t semantic.Type) string {
+	switch v := t.(type) {
+	case nil:
+		return ""
+	case *semantic.MultiValueType:
+		parts := make([]string, len(v.Types))
+		for i, sub := range v.Types {
+			parts[i] = formatType(sub)
+		}
+		return "(" + strings.Join(parts, ",") + ")"
+	case *semantic.ParserTypeWrapper:
+		return formatParserType(v.Type)
+	default:
+		return strings.ReplaceAll(t.String(), " ", "")
+	}
+}
+
+func formatParserType(t parser.Type) string {
+	return strings.ReplaceAll(semantic.StringifyParserType(t), " ", "")
+}
+
+// ============================
+// LEITURA DO FORMATO TEXTUAL
+// ============================
+
+// ParseModule lê um módulo no formato textual escrito por FormatModule
+func ParseModule(src string) (*Module, error) {
+	m := &Module{Name: "main"}
+	lines := strings.Split(src, "\n")
+
+	for n := 0; n < len(lines); n++ {
+		line := strings.TrimSpace(lines[n])
+		if line == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+		word, rest, _ := strings.Cut(line, " ")
+
+		switch word {
+		case "module":
+			m.Name = strings.TrimSpace(rest)
+
+		case "import":
+			path, err := strconv.Unquote(strings.TrimSpace(rest))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid import path %s", n+1, rest)
+			}
+			m.Imports = append(m.Imports, path)
+
+		case "struct":
+			s, end, err := parseStruct(lines, n)
+			if err != nil {
+				return nil, err
+			}
+			m.Structs = append(m.Structs, s)
+			n = end
+
+		case "globals":
+			body, end, err := blockLines(lines, n)
+			if err != nil {
+				return nil, err
+			}
+			for _, bl := range body {
+				instr, err := parseInstructionLine(bl.text, bl.n)
+				if err != nil {
+					return nil, err
+				}
+				m.Globals = append(m.Globals, instr)
+			}
+			n = end
+
+		case "func":
+			fn, end, err := parseFunction(lines, n)
+			if err != nil {
+				return nil, err
+			}
+			m.Functions = append(m.Functions, fn)
+			n = end
+
+		default:
+			return nil, fmt.Errorf("line %d: unexpected '%s'", n+1, word)
+		}
+	}
+	return m, nil
+}
+
+// sourceLine é uma linha do corpo de um bloco com seu número (a partir de 1)
+type sourceLine struct {
+	text string
+	n    int
+}
+
+// blockLines retorna as linhas entre o '{' da linha start e o '}' que fecha
+// o bloco (sozinho em uma linha), sem linhas vazias e comentários
+func blockLines(lines []string, start int) ([]sourceLine, int, error) {
+	if !strings.HasSuffix(strings.TrimSpace(lines[start]), "{") {
+		return nil, start, fmt.Errorf("line %d: expected '{' at the end of the line", start+1)
+	}
+	var body []sourceLine
+	for n := start + 1; n < len(lines); n++ {
+		line := strings.TrimSpace(lines[n])
+		switch {
+		case line == "}":
+			return body, n, nil
+		case line == "" || strings.HasPrefix(line, ";"):
+			continue
+		}
+		body = append(body, sourceLine{text: line, n: n + 1})
+	}
+	return nil, len(lines), fmt.Errorf("line %d: block is never closed with '}'", start+1)
+}
+
+func parseStruct(lines []string, start int) (*parser.StructDecl, int, error) {
+	header := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(lines[start]), "{"))
+	name := strings.TrimSpace(strings.TrimPrefix(header, "struct"))
+
+	s := &parser.StructDecl{}
+	name, generics := splitGenerics(name)
+	s.Name = name
+	for _, g := range generics {
+		s.Generics = append(s.Generics, &parser.GenericParam{Name: g})
+	}
+
+	body, end, err := blockLines(lines, start)
+	if err != nil {
+		return nil, end, err
+	}
+	for _, bl := range body {
+		fields := strings.Fields(bl.text)
+		field := &parser.FieldDecl{}
+		if len(fields) == 3 && fields[0] == "private" {
+			field.IsPrivate = true
+			fields = fields[1:]
+		}
+		if len(fields) != 2 {
+			return nil, end, fmt.Errorf("line %d: expected a field as 'name type'", bl.n)
+		}
+		typ, err := parseTypeText(fields[1])
+		if err != nil {
+			return nil, end, fmt.Errorf("line %d: %v", bl.n, err)
+		}
+		field.Name, field.Type = fields[0], typ
+		s.Fields = append(s.Fields, field)
+	}
+	return s, end, nil
+}
+
+// splitGenerics separa "Box<T,U>" em "Box" e [T U]
+func splitGenerics(name string) (string, []string) {
+	open := strings.IndexByte(name, '<')
+	if open < 0 || !strings.HasSuffix(name, ">") {
+		return name, nil
+	}
+	return name[:open], strings.Split(name[open+1:len(name)-1], ",")
+}
+
+func parseFunction(lines []string, start int) (*Function, int, error) {
+	header := strings.TrimSpace(lines[start])
+	header = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(header, "func"), "{"))
+	open, close := strings.IndexByte(header, '('), -1
+	for i, depth := open+1, 1; open >= 0 && i < len(header) && close < 0; i++ {
+		switch header[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				close = i
+			}
+		}
+	}
+	if open < 0 || close < 0 {
+		return nil, start, fmt.Errorf("line %d: expected a parameter list in the function header", start+1)
+	}
+
+	fn := &Function{}
+	words := strings.Fields(header[:open])
+	if len(words) == 0 {
+		return nil, start, fmt.Errorf("line %d: function without a name", start+1)
+	}
+	for _, attr := range words[:len(words)-1] {
+		switch attr {
+		case "export":
+			fn.IsExported = true
+		case "variadic":
+			fn.Variadic = true
+		case "ssa":
+			fn.SSA = true
+		default:
+			return nil, start, fmt.Errorf("line %d: unknown function attribute '%s'", start+1, attr)
+		}
+	}
+
+	name, generics := splitGenerics(words[len(words)-1])
+	if receiver, method, ok := strings.Cut(name, "."); ok {
+		fn.Receiver, name = receiver, method
+	}
+	fn.Name, fn.Generics = name, generics
+	if fn.Generics == nil {
+		fn.Generics = []string{}
+	}
+
+	if params := strings.TrimSpace(header[open+1 : close]); params != "" {
+		l := &lineReader{s: params, n: start + 1}
+		for {
+			p, err := l.operand()
+			if err != nil {
+				return nil, start, err
+			}
+			fn.Params = append(fn.Params, p)
+			if !l.eat(",") {
+				break
+			}
+		}
+		if !l.done() {
+			return nil, start, l.errorf("unexpected text in the parameter list")
+		}
+	}
+	if ret := strings.TrimSpace(header[close+1:]); ret != "" {
+		typ, err := parseSemanticType(ret)
+		if err != nil {
+			return nil, start, fmt.Errorf("line %d: %v", start+1, err)
+		}
+		fn.ReturnType = typ
+	}
+
+	body, end, err := blockLines(lines, start)
+	if err != nil {
+		return nil, end, err
+	}
+	for _, bl := range body {
+		instr, err := parseInstructionLine(bl.text, bl.n)
+		if err != nil {
+			return nil, end, err
+		}
+		fn.Instructions = append(fn.Instructions, instr)
+	}
+
+	fn.TempCount, fn.LabelCount = nextCounters(fn)
+	return fn, end, nil
+}
+
+// nextCounters calcula os contadores de temporários e labels a partir dos
+// nomes já usados (t3 -> 4, for_end_7 -> 8), para que passes possam criar
+// nomes novos sem colisão
+func nextCounters(fn *Function) (int, int) {
+	temps, labels := 0, 0
+	suffix := func(name string) int {
+		i := strings.LastIndexFunc(name, func(r rune) bool { return !unicode.IsDigit(r) })
+		n, err := strconv.Atoi(name[i+1:])
+		if err != nil {
+			return -1
+		}
+		return n
+	}
+
+	for _, instr := range fn.Instructions {
+		ops := append(instr.Uses(), instr.Result)
+		if instr.Op == LABEL {
+			ops = append(ops, instr.Arg1)
+		}
+		for _, op := range ops {
+			switch {
+			case op == nil:
+			case op.Kind == OpTemp && strings.HasPrefix(op.Value, "t"):
+				temps = max(temps, suffix(op.Value)+1)
+			case op.Kind == OpLabel:
+				labels = max(labels, suffix(op.Value)+1)
+			}
+		}
+	}
+	return temps, labels
+}
+
+// ============================
+// LEITURA DE INSTRUÇÕES E OPERANDOS
+// ============================
+
+// opCodes associa o nome de cada OpCode ao valor
+var opCodes = func() map[string]OpCode {
+	codes := make(map[string]OpCode)
+	for op := ADD; op <= CONCAT; op++ {
+		codes[(&Instruction{Op: op}).opToString()] = op
+	}
+	return codes
+}()
+
+// ParseInstruction lê uma instrução escrita por FormatInstruction
+func ParseInstruction(text string) (*Instruction, error) {
+	return parseInstructionLine(strings.TrimSpace(text), 1)
+}
+
+func parseInstructionLine(text string, n int) (*Instruction, error) {
+	// .label:
+	if strings.HasPrefix(text, ".") && strings.HasSuffix(text, ":") && !strings.ContainsAny(text, " ,") {
+		return &Instruction{Op: LABEL, Arg1: &Operand{Kind: OpLabel, Value: text[1 : len(text)-1]}}, nil
+	}
+
+	l := &lineReader{s: text, n: n}
+	instr := &Instruction{}
+
+	// Resultado (opcional): "x:int = ..."
+	start := l.pos
+	if result, err := l.operand(); err == nil && l.eat("=") {
+		instr.Result = result
+	} else {
+		l.pos = start
+	}
+
+	l.skipSpace()
+	name := l.word()
+	op, ok := opCodes[name]
+	if !ok {
+		return nil, l.errorf("unknown instruction '%s'", name)
+	}
+	instr.Op = op
+
+	if op == PHI {
+		for l.eat("[") {
+			arg, err := l.operand()
+			if err != nil {
+				return nil, err
+			}
+			if !l.eat(",") {
+				return nil, l.errorf("expected ',' between the PHI value and its block")
+			}
+			from, err := l.operand()
+			if err != nil {
+				return nil, err
+			}
+			if !l.eat("]") {
+				return nil, l.errorf("expected ']'")
+			}
+			instr.Args = append(instr.Args, arg)
+			instr.From = append(instr.From, from)
+			if !l.eat(",") {
+				break
+			}
+		}
+		if !l.done() {
+			return nil, l.errorf("unexpected text after the PHI")
+		}
+		return instr, nil
+	}
+
+	// Arg1 e Arg2
+	if l.skipSpace(); !l.done() && !l.peekAny("([") && !l.peekPrefix("...") {
+		arg, err := l.operand()
+		if err != nil {
+			return nil, err
+		}
+		instr.Arg1 = arg
+		if l.eat(",") {
+			if instr.Arg2, err = l.operand(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// (Args...)
+	if l.eat("(") {
+		for !l.eat(")") {
+			arg, err := l.operand()
+			if err != nil {
+				return nil, err
+			}
+			instr.Args = append(instr.Args, arg)
+			if !l.eat(",") && !l.peekAny(")") {
+				return nil, l.errorf("expected ',' or ')' in the argument list")
+			}
+		}
+	}
+	instr.Spread = l.eat("...")
+
+	// Casos de SELECT e SWITCH
+	for l.eat("[") {
+		var err error
+		switch op {
+		case SELECT:
+			err = l.selectCase(instr)
+		case SWITCH:
+			err = l.switchCase(instr)
+		default:
+			err = l.errorf("only SELECT and SWITCH have cases")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !l.done() {
+		return nil, l.errorf("unexpected text '%s'", strings.TrimSpace(l.s[l.pos:]))
+	}
+	return instr, nil
+}
+
+// lineReader percorre uma linha do formato textual
+type lineReader struct {
+	s   string
+	pos int
+	n   int // Número da linha, para mensagens de erro
+}
+
+func (l *lineReader) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", l.n, fmt.Sprintf(format, args...))
+}
+
+func (l *lineReader) skipSpace() {
+	for l.pos < len(l.s) && l.s[l.pos] == ' ' || l.pos < len(l.s) && l.s[l.pos] == '\t' {
+		l.pos++
+	}
+}
+
+func (l *lineReader) done() bool {
+	l.skipSpace()
+	return l.pos >= len(l.s)
+}
+
+// eat consome s (depois de espaços) se a linha continuar com ele
+func (l *lineReader) eat(s string) bool {
+	l.skipSpace()
+	// "=" não pode consumir o início de "=="
+	if strings.HasPrefix(l.s[l.pos:], s) && !(s == "=" && strings.HasPrefix(l.s[l.pos:], "==")) {
+		l.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (l *lineReader) peekAny(chars string) bool {
+	return l.pos < len(l.s) && strings.IndexByte(chars, l.s[l.pos]) >= 0
+}
+
+func (l *lineReader) peekPrefix(s string) bool {
+	return strings.HasPrefix(l.s[l.pos:], s)
+}
+
+// word lê um identificador (letras, dígitos, '_' e '.', para versões SSA e
+// métodos como Point.init)
+func (l *lineReader) word() string {
+	start := l.pos
+	for l.pos < len(l.s) {
+		r, size := utf8.DecodeRuneInString(l.s[l.pos:])
+		if r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mn, r) {
+			break
+		}
+		l.pos += size
+	}
+	return l.s[start:l.pos]
+}
+
+// operand lê um operando e o tipo opcional depois de ':'
+func (l *lineReader) operand() (*Operand, error) {
+	l.skipSpace()
+	if l.pos >= len(l.s) {
+		return nil, l.errorf("expected an operand")
+	}
+
+	op := &Operand{}
+	switch c := l.s[l.pos]; {
+	case c == '%':
+		l.pos++
+		op.Kind, op.Value = OpTemp, l.word()
+	case c == '.':
+		l.pos++
+		op.Kind, op.Value = OpLabel, l.word()
+		return op, nil
+	case c == '@':
+		l.pos++
+		op.Kind, op.Value = OpFunction, l.word()
+	case c == '#':
+		l.pos++
+		op.Kind, op.Value = OpField, l.word()
+	case c == '"' || c == '$':
+		l.pos += strings.IndexByte(l.s[l.pos:], '"')
+		quoted, err := strconv.QuotedPrefix(l.s[l.pos:])
+		if err != nil {
+			return nil, l.errorf("invalid string literal")
+		}
+		l.pos += len(quoted)
+		op.Kind = OpLiteral
+		op.Value, _ = strconv.Unquote(quoted)
+	case c == '\'':
+		quoted, err := strconv.QuotedPrefix(l.s[l.pos:])
+		if err != nil {
+			return nil, l.errorf("invalid char literal")
+		}
+		l.pos += len(quoted)
+		r, _, _, _ := strconv.UnquoteChar(quoted[1:len(quoted)-1], '\'')
+		op.Kind, op.Value = OpLiteral, string(r)
+	case c == '-' || c == '+' || (c >= '0' && c <= '9'):
+		start := l.pos
+		for l.pos < len(l.s) && strings.IndexByte(" ,)]:", l.s[l.pos]) < 0 {
+			l.pos++
+		}
+		op.Kind, op.Value = OpLiteral, l.s[start:l.pos]
+	default:
+		name := l.word()
+		switch name {
+		case "":
+			return nil, l.errorf("expected an operand")
+		case "_":
+			return nil, nil
+		case "true", "false":
+			op.Kind, op.Value = OpLiteral, name
+		case "type":
+			op.Kind = OpType
+		default:
+			op.Kind, op.Value = OpVar, name
+		}
+	}
+
+	// ":tipo" colado ao operando (": " separa o label de um caso)
+	if l.pos+1 < len(l.s) && l.s[l.pos] == ':' && l.s[l.pos+1] != ' ' {
+		l.pos++
+		typ, err := parseSemanticType(l.typeText())
+		if err != nil {
+			return nil, l.errorf("%v", err)
+		}
+		op.Type = typ
+	}
+	return op, nil
+}
+
+// typeText lê o texto de um tipo até um separador fora de <>, [] e ()
+func (l *lineReader) typeText() string {
+	start, depth := l.pos, 0
+	for ; l.pos < len(l.s); l.pos++ {
+		switch c := l.s[l.pos]; c {
+		case '<', '[', '(':
+			depth++
+		case '>', ']', ')':
+			if depth == 0 {
+				return l.s[start:l.pos]
+			}
+			depth--
+		case ' ', ',', ':':
+			if depth == 0 {
+				return l.s[start:l.pos]
+			}
+		}
+	}
+	return l.s[start:l.pos]
+}
+
+// selectCase lê "recv ch -> v: .L]", "send ch <- v: .L]" ou "default: .L]"
+func (l *lineReader) selectCase(instr *Instruction) error {
+	l.skipSpace()
+	c := &SelectCase{}
+	var err error
+	switch l.word() {
+	case "recv":
+		c.Kind = SelectRecv
+		if c.Chan, err = l.operand(); err != nil {
+			return err
+		}
+		if l.eat("->") {
+			if c.Value, err = l.operand(); err != nil {
+				return err
+			}
+		}
+	case "send":
+		c.Kind = SelectSend
+		if c.Chan, err = l.operand(); err != nil {
+			return err
+		}
+		if !l.eat("<-") {
+			return l.errorf("expected '<-' in a send case")
+		}
+		if c.Value, err = l.operand(); err != nil {
+			return err
+		}
+	case "default":
+		c.Kind = SelectDefault
+	default:
+		return l.errorf("expected recv, send or default in a select case")
+	}
+
+	if c.Label, err = l.caseLabel(); err != nil {
+		return err
+	}
+	instr.Select = append(instr.Select, c)
+	return nil
+}
+
+// switchCase lê "v1, v2: .L]", "type:int: .L]" ou "default: .L]"
+func (l *lineReader) switchCase(instr *Instruction) error {
+	c := &SwitchCase{}
+	l.skipSpace()
+	if !l.peekPrefix("default") {
+		for {
+			item, err := l.operand()
+			if err != nil {
+				return err
+			}
+			if item != nil && item.Kind == OpType {
+				c.Types = append(c.Types, item.Type)
+			} else {
+				c.Values = append(c.Values, item)
+			}
+			if !l.eat(",") {
+				break
+			}
+		}
+	} else {
+		l.word()
+	}
+
+	label, err := l.caseLabel()
+	if err != nil {
+		return err
+	}
+	c.Label = label
+	instr.Switch = append(instr.Switch, c)
+	return nil
+}
+
+// caseLabel lê ": .label]" no fim de um caso
+func (l *lineReader) caseLabel() (*Operand, error) {
+	if !l.eat(":") {
+		return nil, l.errorf("expected ':' before the case label")
+	}
+	label, err := l.operand()
+	if err != nil {
+		return nil, err
+	}
+	if label == nil || label.Kind != OpLabel {
+		return nil, l.errorf("expected a label in the case")
+	}
+	if !l.eat("]") {
+		return nil, l.errorf("expected ']' at the end of the case")
+	}
+	return label, nil
+}
+
+// ============================
+// LEITURA DE TIPOS
+// ============================
+
+// parseSemanticType lê um tipo escrito por formatType
+func parseSemanticType(text string) (semantic.Type, error) {
+	if strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")") {
+		// Múltiplos retornos: (int,string)
+		multi := &semantic.MultiValueType{}
+		r := &typeReader{s: text[1 : len(text)-1]}
+		for {
+			t, err := r.union()
+			if err != nil {
+				return nil, err
+			}
+			multi.Types = append(multi.Types, semantic.ToType(t))
+			if !r.eat(',') {
+				break
+			}
+		}
+		if r.pos < len(r.s) {
+			return nil, fmt.Errorf("invalid type '%s'", text)
+		}
+		return multi, nil
+	}
+
+	t, err := parseTypeText(text)
+	if err != nil {
+		return nil, err
+	}
+	return semantic.ToType(t), nil
+}
+
+// parseTypeText lê um tipo do Alpha escrito sem espaços
+func parseTypeText(text string) (parser.Type, error) {
+	r := &typeReader{s: text}
+	t, err := r.union()
+	if err == nil && r.pos < len(r.s) {
+		err = fmt.Errorf("invalid type '%s'", text)
+	}
+	return t, err
+}
+
+// typeReader analisa a sintaxe de tipos gerada por StringifyParserType
+type typeReader struct {
+	s   string
+	pos int
+}
+
+func (r *typeReader) eat(c byte) bool {
+	if r.pos < len(r.s) && r.s[r.pos] == c {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+func (r *typeReader) union() (parser.Type, error) {
+	t, err := r.single()
+	if err != nil || r.pos >= len(r.s) || r.s[r.pos] != '|' {
+		return t, err
+	}
+	union := &parser.UnionType{Types: []parser.Type{t}}
+	for r.eat('|') {
+		next, err := r.single()
+		if err != nil {
+			return nil, err
+		}
+		union.Types = append(union.Types, next)
+	}
+	return union, nil
+}
+
+func (r *typeReader) single() (parser.Type, error) {
+	if r.eat('*') {
+		base, err := r.single()
+		return &parser.PointerType{BaseType: base}, err
+	}
+
+	start := r.pos
+	for r.pos < len(r.s) {
+		c, size := utf8.DecodeRuneInString(r.s[r.pos:])
+		if c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c) && !unicode.Is(unicode.Mn, c) {
+			break
+		}
+		r.pos += size
+	}
+	name := r.s[start:r.pos]
+	if name == "" {
+		return nil, fmt.Errorf("invalid type '%s'", r.s)
+	}
+
+	var t parser.Type
+	if r.eat('<') {
+		var args []parser.Type
+		for {
+			arg, err := r.union()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if !r.eat(',') {
+				break
+			}
+		}
+		if !r.eat('>') {
+			return nil, fmt.Errorf("expected '>' in type '%s'", r.s)
+		}
+		switch {
+		case name == "map" && len(args) == 2:
+			t = &parser.MapType{KeyType: args[0], ValueType: args[1]}
+		case name == "set" && len(args) == 1:
+			t = &parser.SetType{ElementType: args[0]}
+		case name == "channel" && len(args) == 1:
+			t = &parser.ChannelType{ElementType: args[0]}
+		default:
+			t = &parser.GenericType{Name: name, TypeArgs: args}
+		}
+	} else {
+		// void fica como nas declarações (IdentifierType), que o gerador de
+		// código entende como "sem retorno"
+		switch name {
+		case "int", "float", "bool", "string", "char", "byte", "any", "error":
+			t = &parser.PrimitiveType{Name: name}
+		default:
+			t = &parser.IdentifierType{Name: name}
+		}
+	}
+
+	// Sufixos: T[], T[N] e T?
+	for {
+		switch {
+		case r.eat('['):
+			arr := &parser.ArrayType{ElementType: t}
+			start := r.pos
+			for r.pos < len(r.s) && r.s[r.pos] >= '0' && r.s[r.pos] <= '9' {
+				r.pos++
+			}
+			if r.pos > start {
+				size, _ := strconv.ParseInt(r.s[start:r.pos], 10, 64)
+				arr.Size = &parser.IntLiteral{Value: size}
+			}
+			if !r.eat(']') {
+				return nil, fmt.Errorf("expected ']' in type '%s'", r.s)
+			}
+			t = arr
+		case r.eat('?'):
+			t = &parser.NullableType{BaseType: t}
+		default:
+			return t, nil
+		}
+	}
+}
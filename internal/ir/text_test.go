@@ -0,0 +1,89 @@
+package ir
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// roundTrip falha o teste se o texto do módulo não sobrevive a
+// escrever -> ler -> escrever sem mudar
+func roundTrip(t *testing.T, m *Module) {
+	t.Helper()
+	printed := FormatModule(m)
+	parsed, err := ParseModule(printed)
+	if err != nil {
+		t.Fatalf("ParseModule: %v\n%s", err, printed)
+	}
+	if again := FormatModule(parsed); again != printed {
+		t.Fatalf("round trip changed the module:\n--- printed\n%s\n--- reprinted\n%s", printed, again)
+	}
+}
+
+func TestTextRoundTripSamples(t *testing.T) {
+	samples, err := filepath.Glob("../../docs/structs/*.txt")
+	if err != nil || len(samples) == 0 {
+		t.Fatalf("no samples found: %v", err)
+	}
+	for _, path := range samples {
+		if filepath.Base(path) == "rules.txt" {
+			continue // Texto sobre a linguagem, não um programa
+		}
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			src, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := generate(t, string(src))
+			roundTrip(t, m)
+
+			m.ToSSA()
+			roundTrip(t, m)
+		})
+	}
+}
+
+// handWritten usa as formas do texto que os exemplos não cobrem
+const handWritten = `; Alpha IR
+module main
+import "strings"
+
+struct Pair<K,V> {
+  key K
+  private value V
+}
+
+globals {
+  total:int = ALLOCA type:int
+  STORE total:int, 0:int
+}
+
+func ssa swap(n:int) int {
+.start:
+  a.1:int = MOV 1:int
+  b.1:int = MOV 2:int
+.loop:
+  a.2:int = PHI [a.1:int, .start], [b.2:int, .loop]
+  b.2:int = PHI [b.1:int, .start], [a.2:int, .loop]
+  %t0:bool = LT a.2:int, n:int
+  JMP_TRUE %t0:bool, .loop
+  RET a.2:int
+}
+
+func export variadic join(parts:string[]) string {
+  %t0:string = CALL @strings.Join (parts:string[], " - ":string) ...
+  %t1:string = CONCAT (%t0:string, "\n":string, "a b":string)
+  RET %t1:string
+}
+`
+
+func TestTextRoundTripHandWritten(t *testing.T) {
+	m, err := ParseModule(handWritten)
+	if err != nil {
+		t.Fatalf("ParseModule: %v", err)
+	}
+	if printed := FormatModule(m); printed != handWritten {
+		t.Fatalf("printed module differs from the source:\n%s", printed)
+	}
+	roundTrip(t, m)
+}
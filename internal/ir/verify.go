@@ -0,0 +1,381 @@
+package ir
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alpha/internal/semantic"
+)
+
+// ============================
+// VERIFICADOR DE IR
+// ============================
+
+// VerifyError descreve uma regra do IR violada em uma função (ou nas globais)
+type VerifyError struct {
+	Function string       // Nome da função ("" para as globais)
+	Instr    *Instruction // Instrução com problema (nil se for a função inteira)
+	Msg      string
+}
+
+func (e *VerifyError) Error() string {
+	where := "globals"
+	if e.Function != "" {
+		where = "func " + e.Function
+	}
+	if e.Instr != nil {
+		return fmt.Sprintf("%s: `%s`: %s", where, FormatInstruction(e.Instr), e.Msg)
+	}
+	return where + ": " + e.Msg
+}
+
+// Verify confere as regras que os passes e o gerador de código assumem:
+//   - todo label é definido uma única vez e todo salto aponta para um label
+//     definido
+//   - nenhuma instrução segue um JMP, RET, SELECT ou SWITCH com default sem
+//     um label antes (ela nunca executaria)
+//   - temporários são definidos antes do uso em todos os caminhos
+//   - parâmetros, variáveis declaradas, tipos e literais que não são números
+//     ou booleanos levam tipo
+//   - RET devolve tantos valores quanto o tipo de retorno da função
+//   - CALL passa tantos argumentos quanto a função chamada recebe
+//
+// Retorna nil se o módulo estiver correto, ou todos os problemas encontrados
+// (cada um é um *VerifyError)
+func Verify(m *Module) error {
+	v := &verifier{funcs: make(map[string]*Function)}
+	for _, fn := range m.Functions {
+		v.funcs[functionKey(fn)] = fn
+	}
+
+	v.verifyGlobals(m.Globals)
+	for _, fn := range m.Functions {
+		v.verifyFunction(fn)
+	}
+	return errors.Join(v.errs...)
+}
+
+// functionKey é o nome usado em CALL: "soma", ou "Point.area" para métodos
+func functionKey(fn *Function) string {
+	if fn.Receiver != "" {
+		return fn.Receiver + "." + fn.Name
+	}
+	return fn.Name
+}
+
+type verifier struct {
+	funcs map[string]*Function
+	fn    *Function
+	errs  []error
+}
+
+func (v *verifier) report(instr *Instruction, format string, args ...any) {
+	name := ""
+	if v.fn != nil {
+		name = functionKey(v.fn)
+	}
+	v.errs = append(v.errs, &VerifyError{Function: name, Instr: instr, Msg: fmt.Sprintf(format, args...)})
+}
+
+// verifyGlobals confere a inicialização das globais, que roda em sequência
+// (sem saltos)
+func (v *verifier) verifyGlobals(globals []*Instruction) {
+	v.fn = nil
+	defined := make(map[string]bool)
+	for _, instr := range globals {
+		if isTerminator(instr.Op) || instr.Op == LABEL {
+			v.report(instr, "control flow is not allowed in globals")
+		}
+		v.checkInstruction(instr)
+		v.checkTempUses(instr, defined)
+		if def := instr.Def(); def != nil && def.Kind == OpTemp {
+			defined[def.Value] = true
+		}
+	}
+}
+
+func (v *verifier) verifyFunction(fn *Function) {
+	v.fn = fn
+	for _, p := range fn.Params {
+		if p.Type == nil {
+			v.report(nil, "parameter %s has no type", p.Value)
+		}
+	}
+	v.checkLabels(fn)
+	v.checkTerminators(fn)
+	for _, instr := range fn.Instructions {
+		v.checkInstruction(instr)
+	}
+	v.checkTemps(fn)
+}
+
+// ============================
+// LABELS E FLUXO
+// ============================
+
+func (v *verifier) checkLabels(fn *Function) {
+	defined := make(map[string]int)
+	for _, instr := range fn.Instructions {
+		if instr.Op != LABEL {
+			continue
+		}
+		if instr.Arg1 == nil || instr.Arg1.Kind != OpLabel {
+			v.report(instr, "LABEL without a label operand")
+			continue
+		}
+		if defined[instr.Arg1.Value]++; defined[instr.Arg1.Value] == 2 {
+			v.report(instr, "label .%s is defined more than once", instr.Arg1.Value)
+		}
+	}
+
+	target := func(instr *Instruction, label *Operand) {
+		switch {
+		case label == nil || label.Kind != OpLabel:
+			v.report(instr, "jump target is not a label")
+		case defined[label.Value] == 0:
+			v.report(instr, "jump to undefined label .%s", label.Value)
+		}
+	}
+	for _, instr := range fn.Instructions {
+		switch instr.Op {
+		case JMP:
+			target(instr, instr.Arg1)
+		case JMP_TRUE, JMP_FALSE:
+			target(instr, instr.Arg2)
+		case SWITCH:
+			for _, c := range instr.Switch {
+				target(instr, c.Label)
+			}
+		case SELECT:
+			for _, c := range instr.Select {
+				target(instr, c.Label)
+			}
+		case PHI:
+			for _, from := range instr.From {
+				target(instr, from)
+			}
+		}
+	}
+}
+
+// endsFlow indica se a instrução nunca segue para a próxima
+func endsFlow(instr *Instruction) bool {
+	switch instr.Op {
+	case JMP, RET, SELECT:
+		return true
+	case SWITCH:
+		for _, c := range instr.Switch {
+			if c.IsDefault() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (v *verifier) checkTerminators(fn *Function) {
+	for i, instr := range fn.Instructions {
+		if i > 0 && endsFlow(fn.Instructions[i-1]) && instr.Op != LABEL {
+			v.report(instr, "instruction follows %s in the same block", fn.Instructions[i-1].opToString())
+		}
+	}
+}
+
+// checkTemps confere que todo temporário lido foi escrito antes em qualquer
+// caminho desde a entrada: um temporário está definido na entrada de um bloco
+// só se estiver definido na saída de todos os predecessores
+func (v *verifier) checkTemps(fn *Function) {
+	cfg := BuildCFG(fn)
+	out := make(map[*BasicBlock]map[string]bool) // nil = ainda não calculado (todos)
+
+	blockIn := func(b *BasicBlock) map[string]bool {
+		in := make(map[string]bool)
+		if b == cfg.Entry {
+			return in
+		}
+		first := true
+		for _, p := range b.Predecessors {
+			if !cfg.Reachable(p) || out[p] == nil {
+				continue
+			}
+			for t := range out[p] {
+				if first {
+					in[t] = true
+				}
+			}
+			if !first {
+				for t := range in {
+					if !out[p][t] {
+						delete(in, t)
+					}
+				}
+			}
+			first = false
+		}
+		return in
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, b := range cfg.Blocks {
+			if !cfg.Reachable(b) {
+				continue
+			}
+			defs := blockIn(b)
+			for _, instr := range b.Instructions {
+				if def := instr.Def(); def != nil && def.Kind == OpTemp {
+					defs[def.Value] = true
+				}
+			}
+			if out[b] == nil || len(out[b]) != len(defs) {
+				out[b] = defs
+				changed = true
+			}
+		}
+	}
+
+	for _, b := range cfg.Blocks {
+		if !cfg.Reachable(b) {
+			continue
+		}
+		defined := blockIn(b)
+		for _, instr := range b.Instructions {
+			if instr.Op == PHI {
+				// Cada valor da PHI precisa estar definido no fim do seu predecessor
+				for j, arg := range instr.Args {
+					pred := cfg.Block(instr.From[j].Value)
+					if arg != nil && arg.Kind == OpTemp && pred != nil && out[pred] != nil && !out[pred][arg.Value] {
+						v.report(instr, "%%%s is not defined at the end of .%s", arg.Value, pred.Label)
+					}
+				}
+			} else {
+				v.checkTempUses(instr, defined)
+			}
+			if def := instr.Def(); def != nil && def.Kind == OpTemp {
+				defined[def.Value] = true
+			}
+		}
+	}
+}
+
+func (v *verifier) checkTempUses(instr *Instruction, defined map[string]bool) {
+	for _, op := range instr.Uses() {
+		if op.Kind == OpTemp && !defined[op.Value] {
+			v.report(instr, "%%%s is used before it is defined on some path", op.Value)
+			defined[op.Value] = true // Reporta uma vez só
+		}
+	}
+}
+
+// ============================
+// OPERANDOS, RET E CALL
+// ============================
+
+func (v *verifier) checkInstruction(instr *Instruction) {
+	// Temporários sem tipo têm o tipo inferido pelo gerador de código a partir
+	// da operação; já variáveis, literais de texto e tipos não têm de onde tirar
+	for _, op := range instructionOperands(instr) {
+		if op.Type != nil {
+			continue
+		}
+		switch {
+		case op.Kind == OpType:
+			v.report(instr, "type operand without a type")
+		case op.Kind == OpLiteral && !isBareLiteral(op.Value):
+			v.report(instr, "literal %s has no type", formatOperand(op))
+		case op == instr.Result && instr.Op == ALLOCA:
+			v.report(instr, "variable %s is declared without a type", op.Value)
+		}
+	}
+
+	switch instr.Op {
+	case RET:
+		if v.fn != nil {
+			v.checkReturn(instr)
+		}
+	case CALL:
+		v.checkCall(instr)
+	}
+}
+
+// instructionOperands retorna todos os operandos de valor da instrução
+func instructionOperands(instr *Instruction) []*Operand {
+	ops := append([]*Operand{instr.Result, instr.Arg1, instr.Arg2}, instr.Args...)
+	for _, c := range instr.Switch {
+		ops = append(ops, c.Values...)
+	}
+	for _, c := range instr.Select {
+		ops = append(ops, c.Chan, c.Value)
+	}
+
+	var result []*Operand
+	for _, op := range ops {
+		if op != nil {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+// returnArity é quantos valores uma função com esse tipo de retorno devolve
+func returnArity(t semantic.Type) int {
+	if multi, ok := t.(*semantic.MultiValueType); ok {
+		return len(multi.Types)
+	}
+	if t == nil || semantic.StringifyType(t) == "void" {
+		return 0
+	}
+	return 1
+}
+
+func (v *verifier) checkReturn(instr *Instruction) {
+	got := len(instr.Args)
+	for _, op := range []*Operand{instr.Arg1, instr.Arg2} {
+		if op != nil {
+			got++
+		}
+	}
+	if want := returnArity(v.fn.ReturnType); got != want {
+		v.report(instr, "returns %d value(s) but the function returns %d", got, want)
+	}
+}
+
+// checkCall confere o número de argumentos quando a função chamada está no
+// módulo (funções importadas e ponteiros de função não são conferidos)
+func (v *verifier) checkCall(instr *Instruction) {
+	callee := v.callee(instr)
+	if callee == nil {
+		return
+	}
+
+	want := len(callee.Params)
+	if callee.Receiver != "" {
+		want-- // self não aparece em Args
+	}
+	got := len(instr.Args)
+
+	switch {
+	case callee.Variadic && !instr.Spread:
+		if got < want-1 {
+			v.report(instr, "%s expects at least %d argument(s), got %d", functionKey(callee), want-1, got)
+		}
+	case got != want:
+		v.report(instr, "%s expects %d argument(s), got %d", functionKey(callee), want, got)
+	}
+}
+
+// callee encontra a função chamada: CALL @nome, ou CALL #metodo, receptor
+// quando o tipo do receptor é conhecido
+func (v *verifier) callee(instr *Instruction) *Function {
+	switch {
+	case instr.Arg1 == nil:
+		return nil
+	case instr.Arg1.Kind == OpFunction:
+		return v.funcs[instr.Arg1.Value]
+	case instr.Arg1.Kind == OpField && instr.Arg2 != nil && instr.Arg2.Type != nil:
+		receiver := strings.TrimLeft(semantic.StringifyType(instr.Arg2.Type), "*")
+		return v.funcs[receiver+"."+instr.Arg1.Value]
+	}
+	return nil
+}
@@ -1,30 +1,48 @@
 package lexer
 
+import "github.com/alpha/internal/diag"
+
 func (s *Scanner) emit(t TokenType) Token {
 	tok := Token{
 		Type:   t,
 		Lexeme: string(s.src[s.start:s.index]),
 		Line:   s.tokenLine,
 		Col:    s.tokenCol,
+		Offset: s.base + s.start,
 	}
 
-	if t == STRING && s.index-s.start >= 2 {
+	switch {
+	case (t == STRING || t == CHAR) && s.index-s.start >= 2:
 		tok.Value = unescapeStringBytes(s.src[s.start+1 : s.index-1])
-	} else {
+	case t == TEMPLATE:
+		// Os escapes são processados por trecho, em SplitTemplate
+		tok.Value = string(s.src[s.start+1 : s.index-1])
+	case t == KEYWORD:
+		// Sinônimos ("se", "enquanto") chegam ao parser como a própria palavra-chave
+		if keyword, ok := keywordAlias(s.lang, tok.Lexeme); ok {
+			tok.Lexeme = keyword
+		}
+		tok.Value = tok.Lexeme
+	default:
 		tok.Value = tok.Lexeme
 	}
 
 	s.lastTokenType = t
+	s.trackDirective(tok)
 	return tok
 }
 
-func (s *Scanner) errorToken(msg string) Token {
+// errorToken cria um token de erro na posição atual com a mensagem do catálogo
+func (s *Scanner) errorToken(code diag.Code, args ...any) Token {
+	msg := diag.Msg(code, args...)
 	return Token{
 		Type:   ERROR,
 		Lexeme: msg,
 		Value:  msg,
 		Line:   s.line,
 		Col:    s.col,
+		Offset: s.base + s.index,
+		Code:   code,
 	}
 }
 
@@ -0,0 +1,132 @@
+package lexer
+
+import (
+	"regexp"
+)
+
+// ============================
+// IDIOMA DAS PALAVRAS-CHAVE
+// ============================
+
+// Language é o idioma das palavras-chave aceitas pelo scanner
+type Language string
+
+const (
+	LangEN Language = "en" // padrão: apenas as palavras-chave em inglês
+	LangPT Language = "pt" // aceita também os sinônimos em português
+)
+
+// ParseLanguage converte o nome de um idioma ("en", "pt") em Language
+func ParseLanguage(name string) (Language, bool) {
+	switch Language(name) {
+	case LangEN, LangPT:
+		return Language(name), true
+	}
+	return "", false
+}
+
+// ptKeywords lista os sinônimos em português de cada palavra-chave. O primeiro
+// é o nome usado nos diagnósticos; as formas acentuadas também são aceitas
+var ptKeywords = map[string][]string{
+	"if":          {"se"},
+	"else":        {"senao", "senão"},
+	"while":       {"enquanto"},
+	"do":          {"faca", "faça"},
+	"for":         {"para"},
+	"in":          {"em"},
+	"return":      {"retorne"},
+	"break":       {"pare"},
+	"switch":      {"escolha"},
+	"case":        {"caso"},
+	"default":     {"padrao", "padrão"},
+	"fallthrough": {"prossiga"},
+	"function":    {"funcao", "função"},
+	"const":       {"constante"},
+	"struct":      {"estrutura"},
+	"implement":   {"implemente"},
+	"package":     {"pacote"},
+	"import":      {"importe"},
+	"export":      {"exporte"},
+	"true":        {"verdadeiro"},
+	"false":       {"falso"},
+	"null":        {"nulo"},
+}
+
+// ptAliases é o índice inverso de ptKeywords (sinônimo -> palavra-chave)
+var ptAliases = func() map[string]string {
+	aliases := make(map[string]string)
+	for keyword, names := range ptKeywords {
+		for _, name := range names {
+			aliases[name] = keyword
+		}
+	}
+	return aliases
+}()
+
+// keywordAlias retorna a palavra-chave correspondente a um sinônimo do idioma
+func keywordAlias(lang Language, lex string) (string, bool) {
+	if lang != LangPT {
+		return "", false
+	}
+	keyword, ok := ptAliases[lex]
+	return keyword, ok
+}
+
+// KeywordName retorna o nome de uma palavra-chave no idioma indicado
+func KeywordName(keyword string, lang Language) string {
+	if names := ptKeywords[keyword]; lang == LangPT && len(names) > 0 {
+		return names[0]
+	}
+	return keyword
+}
+
+// keywordMention encontra palavras-chave citadas entre aspas em mensagens ('while')
+var keywordMention = regexp.MustCompile(`'([a-z]+)'`)
+
+// LocalizeKeywords reescreve as palavras-chave citadas em um diagnóstico com
+// os nomes do idioma ("expected 'while'" -> "expected 'enquanto'")
+func LocalizeKeywords(msg string, lang Language) string {
+	if lang != LangPT {
+		return msg
+	}
+	return keywordMention.ReplaceAllStringFunc(msg, func(m string) string {
+		return "'" + KeywordName(m[1:len(m)-1], lang) + "'"
+	})
+}
+
+// ============================
+// DIRETIVA "lang"
+// ============================
+
+// langState acompanha o cabeçalho "package nome lang pt"
+type langState int
+
+const (
+	langNone    langState = iota
+	langPackage           // após "package" (ou um '.' do nome)
+	langName              // após o nome do pacote
+	langKeyword           // após "lang"
+)
+
+// trackDirective reconhece "package nome lang pt" à medida que os tokens são
+// emitidos, trocando o idioma antes de o resto do arquivo ser lido. O parser
+// valida a diretiva; aqui um idioma desconhecido é apenas ignorado
+func (s *Scanner) trackDirective(tok Token) {
+	switch {
+	case tok.Type == KEYWORD && tok.Lexeme == "package":
+		s.directive = langPackage
+	case s.directive == langPackage && tok.Type == IDENT:
+		s.directive = langName
+	case s.directive == langName && tok.Lexeme == ".":
+		s.directive = langPackage
+	case s.directive == langName && tok.Type == IDENT && tok.Lexeme == "lang":
+		s.directive = langKeyword
+	case s.directive == langKeyword && tok.Type == IDENT:
+		if lang, ok := ParseLanguage(tok.Lexeme); ok {
+			s.lang = lang
+		}
+		s.directive = langNone
+	default:
+		s.directive = langNone
+	}
+}
@@ -4,7 +4,7 @@ func (s *Scanner) NextToken() Token {
 	s.skipSpaceAndComments()
 
 	if s.isEOF() {
-		return Token{Type: EOF, Line: s.line, Col: s.col}
+		return Token{Type: EOF, Line: s.line, Col: s.col, Offset: s.base + s.index}
 	}
 
 	s.start = s.index
@@ -14,12 +14,16 @@ func (s *Scanner) NextToken() Token {
 	ch := s.peek(0)
 
 	switch {
-	case isLetter(ch) || ch == '_':
+	case isIdentStart(s.peekRune()):
 		return s.lexIdentifier()
 	case isDigit(ch):
 		return s.lexNumber()
 	case ch == '"':
 		return s.lexString()
+	case ch == '\'':
+		return s.lexChar()
+	case ch == '`':
+		return s.lexRawString()
 	default:
 		return s.lexOperator()
 	}
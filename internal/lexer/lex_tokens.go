@@ -1,10 +1,23 @@
 package lexer
 
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alpha/internal/diag"
+)
+
 var (
+	threeCharOps = map[string]bool{
+		"<<=": true, ">>=": true,
+	}
+
 	twoCharOps = map[string]bool{
 		"==": true, "!=": true, "<=": true, ">=": true,
 		"&&": true, "||": true, "++": true, "--": true,
 		"+=": true, "-=": true, "*=": true, "/=": true,
+		"%=": true, "&=": true, "|=": true, "^=": true,
+		"<<": true, ">>": true, "<-": true,
 	}
 
 	oneCharOps = map[byte]bool{
@@ -13,22 +26,25 @@ var (
 		'|': true, ';': true, ',': true, '.': true,
 		':': true, '(': true, ')': true, '{': true,
 		'}': true, '[': true, ']': true, '<': true,
-		'>': true, '?': true,
+		'>': true, '?': true, '^': true, '~': true,
 	}
 )
 
 func (s *Scanner) lexIdentifier() Token {
-	// Consome letras, dígitos e underscore
-	for !s.isEOF() && (isLetter(s.peek(0)) || isDigit(s.peek(0)) || s.peek(0) == '_') {
+	// Consome letras, dígitos e underscore (em qualquer alfabeto)
+	for !s.isEOF() && isIdentPart(s.peekRune()) {
 		s.advance()
 	}
 
 	lex := string(s.src[s.start:s.index])
 
-	// Verifica se é keyword
+	// Verifica se é keyword (ou um sinônimo dela no idioma do arquivo)
 	if _, isKeyword := keywords[lex]; isKeyword {
 		return s.emit(KEYWORD)
 	}
+	if _, isAlias := keywordAlias(s.lang, lex); isAlias {
+		return s.emit(KEYWORD)
+	}
 
 	// Verifica se é tipo genérico (letra maiúscula única)
 	if len(lex) == 1 && lex[0] >= 'A' && lex[0] <= 'Z' {
@@ -39,9 +55,24 @@ func (s *Scanner) lexIdentifier() Token {
 }
 
 func (s *Scanner) lexNumber() Token {
+	// Literais com prefixo de base: 0x, 0o e 0b
+	if s.peek(0) == '0' {
+		if base, name := numberBase(s.peek(1)); base != 0 {
+			s.advance()
+			s.advance()
+			if !isDigitOf(s.peek(0), base) && s.peek(0) != '_' {
+				return s.errorToken(diag.NoDigits, diag.T(name))
+			}
+			if tok, ok := s.lexDigits(base, name); !ok {
+				return tok
+			}
+			return s.endNumber(INT, name)
+		}
+	}
+
 	// Parte inteira
-	for !s.isEOF() && isDigit(s.peek(0)) {
-		s.advance()
+	if tok, ok := s.lexDigits(10, "decimal"); !ok {
+		return tok
 	}
 
 	// Verifica se tem parte decimal
@@ -49,23 +80,80 @@ func (s *Scanner) lexNumber() Token {
 	if s.peek(0) == '.' && isDigit(s.peek(1)) {
 		s.advance() // consume '.'
 		hasDecimal = true
-		for !s.isEOF() && isDigit(s.peek(0)) {
-			s.advance()
+		if tok, ok := s.lexDigits(10, "decimal"); !ok {
+			return tok
 		}
 	}
 
 	// Verifica expoente
 	if ch := s.peek(0); ch == 'e' || ch == 'E' {
 		if !s.parseExponent() {
-			return s.errorToken("malformed exponent")
+			return s.errorToken(diag.MalformedExponent)
 		}
 		hasDecimal = true
 	}
 
+	// Sufixo de float: 1.5f, 10f
+	if ch := s.peek(0); ch == 'f' || ch == 'F' {
+		s.advance()
+		hasDecimal = true
+	}
+
 	if hasDecimal {
-		return s.emit(FLOAT)
+		return s.endNumber(FLOAT, "float")
+	}
+	return s.endNumber(INT, "decimal")
+}
+
+// numberBase retorna a base indicada pelo caractere após o '0' de um literal
+func numberBase(ch byte) (int, string) {
+	switch ch {
+	case 'x', 'X':
+		return 16, "hexadecimal"
+	case 'o', 'O':
+		return 8, "octal"
+	case 'b', 'B':
+		return 2, "binary"
+	}
+	return 0, ""
+}
+
+// lexDigits consome os dígitos de um literal na base indicada. O '_' só é
+// aceito entre dígitos (1_000_000) ou logo após o prefixo da base (0x_FF)
+func (s *Scanner) lexDigits(base int, name string) (Token, bool) {
+	for !s.isEOF() {
+		ch := s.peek(0)
+		switch {
+		case ch == '_':
+			if !isDigitOf(s.peek(1), base) {
+				return s.numberError(diag.MisplacedDigitSep), false
+			}
+		case isDigitOf(ch, base):
+		case isDigit(ch):
+			return s.numberError(diag.InvalidDigit, ch, diag.T(name)), false
+		default:
+			return Token{}, true
+		}
+		s.advance()
+	}
+	return Token{}, true
+}
+
+// endNumber emite o literal, rejeitando letras coladas ao número (12ab)
+func (s *Scanner) endNumber(t TokenType, name string) Token {
+	if r := s.peekRune(); isIdentStart(r) {
+		return s.numberError(diag.InvalidCharInLiteral, r, diag.T(name))
 	}
-	return s.emit(INT)
+	return s.emit(t)
+}
+
+// numberError cria um erro na posição atual e descarta o resto do literal
+func (s *Scanner) numberError(code diag.Code, args ...any) Token {
+	tok := s.errorToken(code, args...)
+	for !s.isEOF() && isIdentPart(s.peekRune()) {
+		s.advance()
+	}
+	return tok
 }
 
 func (s *Scanner) parseExponent() bool {
@@ -92,24 +180,102 @@ func (s *Scanner) parseExponent() bool {
 func (s *Scanner) lexString() Token {
 	s.advance() // consume opening "
 
+	interpolated := false
 	for !s.isEOF() {
 		ch := s.peek(0)
 		s.advance()
 
-		if ch == '\\' && !s.isEOF() {
+		switch {
+		case ch == '\\' && !s.isEOF():
 			s.advance() // skip escaped character
-		} else if ch == '"' {
+		case ch == '$' && s.peek(0) == '{':
+			// Interpolação: consome a expressão até a chave que a fecha
+			end := interpolationEnd(s.src, s.index+1)
+			if end < 0 {
+				return s.errorToken(diag.UnterminatedInterp)
+			}
+			for s.index <= end {
+				s.advance()
+			}
+			interpolated = true
+		case ch == '"':
+			if interpolated {
+				return s.emit(TEMPLATE)
+			}
 			return s.emit(STRING)
 		}
 	}
 
-	return s.errorToken("unterminated string")
+	return s.errorToken(diag.UnterminatedString)
+}
+
+// lexRawString lê uma string entre crases: pode ocupar várias linhas e não
+// processa escapes nem interpolação
+func (s *Scanner) lexRawString() Token {
+	s.advance() // consume opening `
+
+	for !s.isEOF() && s.peek(0) != '`' {
+		s.advance()
+	}
+	if s.isEOF() {
+		return s.errorToken(diag.UnterminatedRawString)
+	}
+	s.advance() // consume closing `
+
+	tok := s.emit(STRING)
+	// Como no Go, \r é descartado do conteúdo de strings brutas
+	tok.Value = strings.ReplaceAll(string(s.src[s.start+1:s.index-1]), "\r", "")
+	return tok
+}
+
+// lexChar lê um literal de caractere: 'a', 'é' ou um escape como '\n'
+func (s *Scanner) lexChar() Token {
+	s.advance() // consume opening '
+
+	switch ch := s.peek(0); {
+	case s.isEOF() || ch == '\n':
+		return s.errorToken(diag.UnterminatedChar)
+	case ch == '\'':
+		s.advance()
+		return s.errorToken(diag.EmptyChar)
+	case ch == '\\':
+		s.advance()
+		if !isEscape(s.peek(0)) {
+			return s.errorToken(diag.UnknownEscape, s.peekRune())
+		}
+		s.advance()
+	default:
+		s.advance() // uma runa, mesmo que ocupe vários bytes
+	}
+
+	if s.peek(0) != '\'' {
+		// Consome até o fim do literal para continuar a análise depois dele
+		for !s.isEOF() && s.peek(0) != '\'' && s.peek(0) != '\n' {
+			s.advance()
+		}
+		if s.peek(0) != '\'' {
+			return s.errorToken(diag.UnterminatedChar)
+		}
+		s.advance()
+		return s.errorToken(diag.CharTooLong)
+	}
+
+	s.advance() // consume closing '
+	return s.emit(CHAR)
 }
 
 func (s *Scanner) lexOperator() Token {
 	ch1 := s.peek(0)
 	ch2 := s.peek(1)
 
+	// Verifica operadores de 3 caracteres
+	if ch2 != 0 && threeCharOps[string(s.src[s.index:min(s.index+3, len(s.src))])] {
+		s.advance()
+		s.advance()
+		s.advance()
+		return s.emit(OP)
+	}
+
 	// Verifica operadores de 2 caracteres
 	if ch2 != 0 && twoCharOps[string(ch1)+string(ch2)] {
 		s.advance()
@@ -129,9 +295,10 @@ func (s *Scanner) lexOperator() Token {
 		return s.emit(OP)
 	}
 
-	// Operador não reconhecido
+	// Operador não reconhecido (pode ser um caractere de vários bytes)
+	tok := s.errorToken(diag.UnrecognizedOperator, string(s.peekRune()))
 	s.advance()
-	return s.errorToken("operador não reconhecido: " + string(ch1))
+	return tok
 }
 
 // isLikelyGeneric verifica se o próximo '<' provavelmente inicia um tipo genérico
@@ -154,7 +321,8 @@ func (s *Scanner) isLikelyGeneric() bool {
 
 	// Encontra o início da palavra anterior
 	start := i
-	for start >= 0 && (isLetter(s.src[start]) || isDigit(s.src[start]) || s.src[start] == '_') {
+	// Bytes >= 0x80 pertencem a letras UTF-8 de identificadores como "lista_ações"
+	for start >= 0 && (isLetter(s.src[start]) || isDigit(s.src[start]) || s.src[start] == '_' || s.src[start] >= utf8.RuneSelf) {
 		start--
 	}
 	start++ // Ajusta para o primeiro caractere da palavra
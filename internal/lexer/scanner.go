@@ -1,15 +1,21 @@
 package lexer
 
-// Scanner []byte
+import "unicode/utf8"
+
+// Scanner []byte. O código é lido como UTF-8: index e start contam bytes,
+// enquanto col conta runas, para que as colunas batam com o que o editor mostra
 type Scanner struct {
 	src           []byte
 	index         int       // próximo byte a ler
 	start         int       // início do token corrente
+	base          int       // deslocamento de src no arquivo original
 	line          int       // linha atual (1-based)
-	col           int       // coluna atual (1-based)
+	col           int       // coluna atual em runas (1-based)
 	tokenLine     int       // linha onde token corrente começou
 	tokenCol      int       // coluna onde token corrente começou
 	lastTokenType TokenType // último token emitido
+	lang          Language  // idioma das palavras-chave
+	directive     langState // progresso do cabeçalho "package nome lang pt"
 }
 
 func NewScanner(src string) *Scanner {
@@ -19,9 +25,24 @@ func NewScanner(src string) *Scanner {
 		index: 0,
 		line:  1,
 		col:   1,
+		lang:  LangEN,
 	}
 }
 
+// NewScannerAt cria um scanner para um trecho de código que começa na
+// posição indicada de outro arquivo (ex: a expressão de uma interpolação)
+func NewScannerAt(src string, line, col, offset int) *Scanner {
+	sc := NewScanner(src)
+	sc.line, sc.col, sc.base = line, col, offset
+	return sc
+}
+
+// SetLanguage define o idioma das palavras-chave dos próximos tokens
+func (s *Scanner) SetLanguage(lang Language) { s.lang = lang }
+
+// Language retorna o idioma das palavras-chave em uso
+func (s *Scanner) Language() Language { return s.lang }
+
 func (s *Scanner) isEOF() bool { return s.index >= len(s.src) }
 
 func (s *Scanner) peek(off int) byte {
@@ -32,12 +53,28 @@ func (s *Scanner) peek(off int) byte {
 	return 0
 }
 
+// peekRune decodifica a runa na posição atual (utf8.RuneError se inválida)
+func (s *Scanner) peekRune() rune {
+	if s.isEOF() {
+		return 0
+	}
+	r, _ := utf8.DecodeRune(s.src[s.index:])
+	return r
+}
+
+// advance consome uma runa inteira; bytes UTF-8 inválidos contam como uma coluna
 func (s *Scanner) advance() {
 	if s.isEOF() {
 		return
 	}
 
 	ch := s.src[s.index]
+	if ch >= utf8.RuneSelf {
+		_, size := utf8.DecodeRune(s.src[s.index:])
+		s.index += size
+		s.col++
+		return
+	}
 	s.index++
 
 	switch ch {
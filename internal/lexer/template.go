@@ -0,0 +1,94 @@
+package lexer
+
+// ============================
+// STRINGS INTERPOLADAS
+// ============================
+
+// TemplatePart é um trecho de uma string interpolada: texto ou o código de ${...}
+type TemplatePart struct {
+	Text   string // Texto já sem escapes ou o código da expressão
+	IsExpr bool
+	Offset int // Posição do trecho (em bytes) dentro do conteúdo da string
+}
+
+// SplitTemplate divide o conteúdo de um token TEMPLATE em trechos de texto e
+// expressões, na ordem em que aparecem
+func SplitTemplate(raw string) []TemplatePart {
+	src := []byte(raw)
+	var parts []TemplatePart
+
+	textStart := 0
+	flushText := func(end int) {
+		if end > textStart {
+			parts = append(parts, TemplatePart{
+				Text:   unescapeStringBytes(src[textStart:end]),
+				Offset: textStart,
+			})
+		}
+	}
+
+	for i := 0; i < len(src); i++ {
+		switch {
+		case src[i] == '\\':
+			i++ // O caractere escapado nunca inicia uma interpolação
+		case src[i] == '$' && i+1 < len(src) && src[i+1] == '{':
+			end := interpolationEnd(src, i+2)
+			if end < 0 {
+				// O lexer só emite TEMPLATE com interpolações fechadas
+				end = len(src)
+			}
+			flushText(i)
+			parts = append(parts, TemplatePart{Text: string(src[i+2 : end]), IsExpr: true, Offset: i + 2})
+			i = end
+			textStart = end + 1
+		}
+	}
+	flushText(len(src))
+
+	return parts
+}
+
+// interpolationEnd retorna o índice da '}' que fecha a interpolação cujo código
+// começa em src[i], considerando chaves e strings aninhadas. Retorna -1 se a
+// interpolação não termina na mesma linha
+func interpolationEnd(src []byte, i int) int {
+	depth := 1
+	for ; i < len(src); i++ {
+		switch src[i] {
+		case '\n':
+			return -1
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		case '"', '\'':
+			if i = quotedEnd(src, i); i < 0 {
+				return -1
+			}
+		}
+	}
+	return -1
+}
+
+// quotedEnd retorna o índice da aspa que fecha o literal iniciado em src[i]
+func quotedEnd(src []byte, i int) int {
+	quote := src[i]
+	for i++; i < len(src); i++ {
+		switch {
+		case src[i] == '\\':
+			i++
+		case src[i] == quote:
+			return i
+		case src[i] == '\n':
+			return -1
+		case quote == '"' && src[i] == '$' && i+1 < len(src) && src[i+1] == '{':
+			if i = interpolationEnd(src, i+2); i < 0 {
+				return -1
+			}
+		}
+	}
+	return -1
+}
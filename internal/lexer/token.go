@@ -1,5 +1,7 @@
 package lexer
 
+import "github.com/alpha/internal/diag"
+
 type TokenType int
 
 const (
@@ -10,16 +12,20 @@ const (
 	INT
 	FLOAT
 	STRING
+	CHAR     // 'a', '\n'
+	TEMPLATE // "Olá ${nome}": string com interpolação (Value é o conteúdo bruto)
 	OP
 	GENERIC // T, U, etc. (parâmetros genéricos)
 )
 
 type Token struct {
 	Type   TokenType
-	Lexeme string // texto bruto
+	Lexeme string // texto bruto (sinônimos de palavras-chave vêm normalizados)
 	Value  string // valor normalizado (p.ex. string sem aspas)
 	Line   int
-	Col    int
+	Col    int       // coluna em runas
+	Offset int       // deslocamento em bytes desde o início do arquivo
+	Code   diag.Code // código do diagnóstico (apenas tokens ERROR)
 }
 
 var keywords = map[string]struct{}{
@@ -33,6 +39,10 @@ var keywords = map[string]struct{}{
 	// Controle de fluxo
 	"if": {}, "else": {}, "while": {}, "do": {}, "for": {}, "in": {}, "return": {},
 	"break": {}, "continue": {}, "switch": {}, "case": {}, "default": {},
+	"fallthrough": {},
+
+	// Concorrência
+	"spawn": {}, "select": {},
 
 	// Literais e valores
 	"true": {}, "false": {}, "null": {},
@@ -45,5 +55,5 @@ var keywords = map[string]struct{}{
 
 	// Utilitários
 	"generic": {}, "length": {}, "append": {}, "remove": {}, "removeIndex": {},
-	"delete": {}, "add": {}, "clear": {},
+	"delete": {}, "add": {}, "clear": {}, "close": {}, "waitAll": {},
 }
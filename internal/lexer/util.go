@@ -1,15 +1,41 @@
 package lexer
 
-import "bytes"
+import (
+	"bytes"
+	"unicode"
+)
 
 func isLetter(ch byte) bool {
 	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
 }
 
+// isIdentStart indica se r pode iniciar um identificador: qualquer letra
+// Unicode (número, ação, π) ou '_'
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// isIdentPart indica se r pode continuar um identificador. Além de letras e
+// dígitos aceita marcas combinantes, para nomes escritos em forma decomposta (NFD)
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r) || unicode.In(r, unicode.Mn, unicode.Mc)
+}
+
 func isDigit(ch byte) bool {
 	return ch >= '0' && ch <= '9'
 }
 
+// isDigitOf indica se ch é um dígito válido na base indicada (2, 8, 10 ou 16)
+func isDigitOf(ch byte, base int) bool {
+	switch {
+	case isDigit(ch):
+		return int(ch-'0') < base
+	case base == 16:
+		return ch >= 'a' && ch <= 'f' || ch >= 'A' && ch <= 'F'
+	}
+	return false
+}
+
 func unescapeStringBytes(b []byte) string {
 	// Verifica se não há escapes para retornar rápido
 	if bytes.IndexByte(b, '\\') == -1 {
@@ -33,6 +59,14 @@ var escapeMap = [256]byte{
 	'n':  '\n',
 	't':  '\t',
 	'r':  '\r',
+	'0':  0,
 	'"':  '"',
+	'$':  '$',
+	'\'': '\'',
 	'\\': '\\',
 }
+
+// isEscape indica se \ch é uma sequência de escape conhecida
+func isEscape(ch byte) bool {
+	return ch == '0' || escapeMap[ch] != 0
+}
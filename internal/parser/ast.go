@@ -1,5 +1,7 @@
 package parser
 
+import "github.com/alpha/internal/lexer"
+
 // ============================
 // INTERFACES DA AST
 // ============================
@@ -27,6 +29,14 @@ type Type interface {
 	nodePos()
 }
 
+// Pos é a posição (1-based) de um nó no código fonte. Line 0 indica posição desconhecida.
+// Col conta runas; Offset é o deslocamento em bytes desde o início do arquivo
+type Pos struct {
+	Line   int
+	Col    int
+	Offset int
+}
+
 // ============================
 // NÓ RAIZ (PROGRAMA)
 // ============================
@@ -34,15 +44,17 @@ type Type interface {
 // Program representa um programa completo
 type Program struct {
 	Body []Stmt
+	Lang lexer.Language // idioma das palavras-chave (diagnósticos usam os mesmos nomes)
 }
 
 // ============================
 // DECLARAÇÕES DE MÓDULO
 // ============================
 
-// PackageDecl representa uma declaração de pacote
+// PackageDecl representa uma declaração de pacote ("package main lang pt")
 type PackageDecl struct {
 	Name string
+	Lang lexer.Language // vazio quando o arquivo não escolhe um idioma
 }
 
 func (p *PackageDecl) stmtNode() {}
@@ -160,17 +172,33 @@ func (g *GenericParam) nodePos()  {}
 
 // Param representa um parâmetro de função/método
 type Param struct {
-	Name string
-	Type Type
+	Name     string
+	Type     Type
+	Variadic bool // int... nums: recebe zero ou mais argumentos do tipo Type
+	Default  Expr // int age = 18: valor usado quando o argumento é omitido
 }
 
 func (p *Param) nodePos() {}
 
+// ValueType é o tipo do parâmetro dentro da função (T[] para variádicos)
+func (p *Param) ValueType() Type {
+	if p.Variadic {
+		return &ArrayType{ElementType: p.Type}
+	}
+	return p.Type
+}
+
+// IsVariadic indica se o último parâmetro da lista é variádico
+func IsVariadic(params []*Param) bool {
+	return len(params) > 0 && params[len(params)-1].Variadic
+}
+
 // FieldDecl representa uma declaração de campo
 type FieldDecl struct {
 	Name      string
 	Type      Type
 	IsPrivate bool // Flag para campos privados
+	Pos       Pos
 }
 
 func (f *FieldDecl) nodePos() {}
@@ -190,6 +218,8 @@ type MethodDecl struct {
 	Params      []*Param
 	ReturnTypes []Type
 	Body        []Stmt
+	IsPrivate   bool // Métodos privados só são visíveis no próprio implement
+	Pos         Pos
 }
 
 func (m *MethodDecl) nodePos() {}
@@ -256,23 +286,85 @@ type ForInStmt struct {
 func (f *ForInStmt) stmtNode() {}
 func (f *ForInStmt) nodePos()  {}
 
-// SwitchStmt representa um statement switch
+// SwitchStmt representa um statement switch. Sem Expr, cada caso é uma
+// condição booleana (switch { case x > 10: ... })
 type SwitchStmt struct {
 	Expr  Expr
 	Cases []*CaseClause
+	Pos   Pos
 }
 
 func (s *SwitchStmt) stmtNode() {}
 func (s *SwitchStmt) nodePos()  {}
 
-// CaseClause representa um caso em um switch
+// IsTypeSwitch indica se os casos comparam tipos (case int, string:)
+func (s *SwitchStmt) IsTypeSwitch() bool {
+	for _, clause := range s.Cases {
+		if len(clause.Types) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// CaseClause representa um caso em um switch. Um caso sem valores nem tipos
+// é o default
 type CaseClause struct {
-	Value Expr
-	Body  []Stmt
+	Values []Expr // case 1, 2, 3:
+	Types  []Type // case int, string: (switch de tipo)
+	Body   []Stmt
+	Pos    Pos
 }
 
 func (c *CaseClause) nodePos() {}
 
+// IsDefault indica se o caso é o default
+func (c *CaseClause) IsDefault() bool {
+	return len(c.Values) == 0 && len(c.Types) == 0
+}
+
+// Fallthrough indica se o caso termina com fallthrough
+func (c *CaseClause) Fallthrough() bool {
+	if len(c.Body) == 0 {
+		return false
+	}
+	_, ok := c.Body[len(c.Body)-1].(*FallthroughStmt)
+	return ok
+}
+
+// ============================
+// STATEMENTS DE CONCORRÊNCIA
+// ============================
+
+// SpawnStmt representa o disparo de uma tarefa concorrente (spawn f(x))
+type SpawnStmt struct {
+	Call Expr // CallExpr ou GenericCallExpr
+	Pos  Pos
+}
+
+func (s *SpawnStmt) stmtNode() {}
+func (s *SpawnStmt) nodePos()  {}
+
+// SelectStmt representa a espera por múltiplas operações de canal
+type SelectStmt struct {
+	Cases []*SelectCase
+	Pos   Pos
+}
+
+func (s *SelectStmt) stmtNode() {}
+func (s *SelectStmt) nodePos()  {}
+
+// SelectCase representa um caso de select. Comm é nil para o caso default e
+// pode ser um envio (ch <- v), um recebimento (<-ch) ou uma declaração/atribuição
+// recebendo de um canal (var msg = <-ch)
+type SelectCase struct {
+	Comm Stmt
+	Body []Stmt
+	Pos  Pos
+}
+
+func (s *SelectCase) nodePos() {}
+
 // ============================
 // STATEMENTS DE RETORNO E CONTROLE
 // ============================
@@ -291,6 +383,14 @@ type BreakStmt struct{}
 func (b *BreakStmt) stmtNode() {}
 func (b *BreakStmt) nodePos()  {}
 
+// FallthroughStmt representa um statement fallthrough (continua no próximo caso)
+type FallthroughStmt struct {
+	Pos Pos
+}
+
+func (f *FallthroughStmt) stmtNode() {}
+func (f *FallthroughStmt) nodePos()  {}
+
 // ContinueStmt representa um statement continue
 type ContinueStmt struct{}
 
@@ -316,6 +416,7 @@ func (b *BlockStmt) nodePos()  {}
 // Identifier representa um identificador (nome de variável/função)
 type Identifier struct {
 	Name string
+	Pos  Pos
 }
 
 func (i *Identifier) exprNode() {}
@@ -324,6 +425,7 @@ func (i *Identifier) nodePos()  {}
 // IntLiteral representa um literal inteiro
 type IntLiteral struct {
 	Value int64
+	Pos   Pos
 }
 
 func (i *IntLiteral) exprNode() {}
@@ -332,6 +434,7 @@ func (i *IntLiteral) nodePos()  {}
 // FloatLiteral representa um literal de ponto flutuante
 type FloatLiteral struct {
 	Value float64
+	Pos   Pos
 }
 
 func (f *FloatLiteral) exprNode() {}
@@ -345,6 +448,23 @@ type StringLiteral struct {
 func (s *StringLiteral) exprNode() {}
 func (s *StringLiteral) nodePos()  {}
 
+// InterpolatedString representa uma string com expressões embutidas: "Olá ${nome}"
+type InterpolatedString struct {
+	Parts []Expr // StringLiteral para o texto e a expressão de cada ${...}
+	Pos   Pos
+}
+
+func (i *InterpolatedString) exprNode() {}
+func (i *InterpolatedString) nodePos()  {}
+
+// CharLiteral representa um literal de caractere ('a', '\n')
+type CharLiteral struct {
+	Value rune
+}
+
+func (c *CharLiteral) exprNode() {}
+func (c *CharLiteral) nodePos()  {}
+
 // BoolLiteral representa um literal booleano
 type BoolLiteral struct {
 	Value bool
@@ -428,6 +548,7 @@ func (i *IndexExpr) nodePos()  {}
 type MemberExpr struct {
 	Object Expr
 	Member string
+	Pos    Pos // Posição do nome do membro
 }
 
 func (m *MemberExpr) exprNode() {}
@@ -446,6 +567,7 @@ func (s *SelfExpr) nodePos()  {}
 // ArrayLiteral representa um literal de array
 type ArrayLiteral struct {
 	Elements []Expr
+	Pos      Pos // Posição do '['
 }
 
 func (a *ArrayLiteral) exprNode() {}
@@ -488,6 +610,7 @@ func (s *StructLiteral) nodePos()  {}
 type StructField struct {
 	Name  string
 	Value Expr
+	Pos   Pos
 }
 
 func (s *StructField) nodePos() {}
@@ -543,6 +666,35 @@ type GenericSpecialization struct {
 func (g *GenericSpecialization) exprNode() {}
 func (g *GenericSpecialization) nodePos()  {}
 
+// ChannelExpr representa a criação de um canal: channel<int>() ou channel<int>(10)
+type ChannelExpr struct {
+	ElementType Type
+	Capacity    Expr // nil para canais sem buffer
+	Pos         Pos
+}
+
+func (c *ChannelExpr) exprNode() {}
+func (c *ChannelExpr) nodePos()  {}
+
+// SendExpr representa o envio de um valor para um canal (ch <- valor)
+type SendExpr struct {
+	Channel Expr
+	Value   Expr
+	Pos     Pos // Posição do operador <-
+}
+
+func (s *SendExpr) exprNode() {}
+func (s *SendExpr) nodePos()  {}
+
+// ReceiveExpr representa o recebimento de um valor de um canal (<-ch)
+type ReceiveExpr struct {
+	Channel Expr
+	Pos     Pos
+}
+
+func (r *ReceiveExpr) exprNode() {}
+func (r *ReceiveExpr) nodePos()  {}
+
 // ============================
 // TIPOS PRIMITIVOS E BÁSICOS
 // ============================
@@ -558,6 +710,7 @@ func (p *PrimitiveType) nodePos()  {}
 // IdentifierType representa um tipo identificador
 type IdentifierType struct {
 	Name string
+	Pos  Pos
 }
 
 func (i *IdentifierType) typeNode() {}
@@ -618,6 +771,14 @@ type MapType struct {
 func (m *MapType) typeNode() {}
 func (m *MapType) nodePos()  {}
 
+// ChannelType representa um tipo canal (channel<T>)
+type ChannelType struct {
+	ElementType Type
+}
+
+func (c *ChannelType) typeNode() {}
+func (c *ChannelType) nodePos()  {}
+
 // UnionType representa um tipo união (T1 | T2 | T3)
 type UnionType struct {
 	Types []Type
@@ -673,3 +834,48 @@ type SpreadExpr struct {
 
 func (s *SpreadExpr) exprNode() {}
 func (s *SpreadExpr) nodePos()  {}
+
+// NamedArg representa um argumento nomeado em uma chamada: age: 30
+type NamedArg struct {
+	Name  string
+	Value Expr
+	Pos   Pos
+}
+
+func (n *NamedArg) exprNode() {}
+func (n *NamedArg) nodePos()  {}
+
+// ============================
+// NÓS DE ERRO (RECUPERAÇÃO)
+// ============================
+
+// BadExpr ocupa o lugar de uma expressão com erro de sintaxe. From e To
+// delimitam o trecho descartado pelo parser
+type BadExpr struct {
+	From Pos
+	To   Pos
+}
+
+func (b *BadExpr) exprNode() {}
+func (b *BadExpr) nodePos()  {}
+
+// BadStmt ocupa o lugar de um statement com erro de sintaxe
+type BadStmt struct {
+	From Pos
+	To   Pos
+}
+
+func (b *BadStmt) stmtNode() {}
+func (b *BadStmt) nodePos()  {}
+
+// BadDecl ocupa o lugar de uma declaração com erro de sintaxe. Name guarda o
+// nome declarado quando o parser chegou a lê-lo, para que os usos desse nome
+// no resto do arquivo não virem erros em cascata
+type BadDecl struct {
+	Name string
+	From Pos
+	To   Pos
+}
+
+func (b *BadDecl) stmtNode() {}
+func (b *BadDecl) nodePos()  {}
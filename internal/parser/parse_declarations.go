@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"github.com/alpha/internal/diag"
 	"github.com/alpha/internal/lexer"
 )
 
@@ -15,11 +16,12 @@ func (p *Parser) parseVarDecl() Stmt {
 	// Parse uma lista de identificadores
 	var names []string
 	if p.cur.Type != lexer.IDENT {
-		p.errorf("expected identifier after 'var'")
+		p.error(diag.ExpectedVarName)
 		p.syncTo(";")
 		return nil
 	}
 	names = append(names, p.cur.Lexeme)
+	p.declName = p.cur.Lexeme
 	p.advanceToken()
 
 	// Parse identificadores adicionais separados por vírgula
@@ -27,7 +29,7 @@ func (p *Parser) parseVarDecl() Stmt {
 		p.advanceToken() // consome ','
 
 		if p.cur.Type != lexer.IDENT {
-			p.errorf("expected identifier after ',' in variable declaration")
+			p.error(diag.ExpectedVarNameAfterComma)
 			p.syncTo(";")
 			return nil
 		}
@@ -50,16 +52,16 @@ func (p *Parser) parseVarDecl() Stmt {
 	var init Expr
 	if p.cur.Lexeme == "=" {
 		p.advanceToken()
+		from := p.pos()
 		init = p.parseExpression(LOWEST)
 		if init == nil {
-			p.errorf("expected expression after '=' in variable declaration")
-			p.syncTo(";")
-			return nil
+			p.error(diag.ExpectedVarInit)
+			init = p.badExpr(from)
 		}
 	} else {
 		// Para múltiplas variáveis, o inicializador é obrigatório
 		if len(names) > 1 {
-			p.errorf("multiple variables declaration must have initializer")
+			p.error(diag.MultiVarNeedsInit)
 			p.syncTo(";")
 			return nil
 		}
@@ -81,11 +83,12 @@ func (p *Parser) parseConstDecl() Stmt {
 	// Parse uma lista de identificadores
 	var names []string
 	if p.cur.Type != lexer.IDENT {
-		p.errorf("expected identifier after 'const'")
+		p.error(diag.ExpectedConstName)
 		p.syncTo(";")
 		return nil
 	}
 	names = append(names, p.cur.Lexeme)
+	p.declName = p.cur.Lexeme
 	p.advanceToken()
 
 	// Parse identificadores adicionais separados por vírgula
@@ -93,7 +96,7 @@ func (p *Parser) parseConstDecl() Stmt {
 		p.advanceToken() // consome ','
 
 		if p.cur.Type != lexer.IDENT {
-			p.errorf("expected identifier after ',' in constant declaration")
+			p.error(diag.ExpectedConstNameAfterComma)
 			p.syncTo(";")
 			return nil
 		}
@@ -103,17 +106,17 @@ func (p *Parser) parseConstDecl() Stmt {
 
 	// Inicializador obrigatório
 	if p.cur.Lexeme != "=" {
-		p.errorf("expected '=' in const declaration")
+		p.error(diag.ExpectedConstAssign)
 		p.syncTo(";")
 		return nil
 	}
 
 	p.advanceToken()
+	from := p.pos()
 	init := p.parseExpression(LOWEST)
 	if init == nil {
-		p.errorf("expected expression for constant value")
-		p.syncTo(";")
-		return nil
+		p.error(diag.ExpectedConstValue)
+		init = p.badExpr(from)
 	}
 
 	// Retorna declaração apropriada
@@ -152,10 +155,11 @@ func (p *Parser) parseTypedVarDecl() Stmt {
 	var init Expr
 	if p.cur.Lexeme == "=" {
 		p.advanceToken()
+		from := p.pos()
 		init = p.parseExpression(LOWEST)
 		if init == nil {
-			p.errorf("expected expression after '='")
-			return nil
+			p.error(diag.ExpectedExprAfterAssign)
+			init = p.badExpr(from)
 		}
 	}
 
@@ -186,11 +190,12 @@ func (p *Parser) parseFunctionDecl(generic bool) Stmt {
 	}
 
 	if p.cur.Type != lexer.IDENT {
-		p.errorf("expected function name")
+		p.error(diag.ExpectedFuncName)
 		return nil
 	}
 
 	name := p.cur.Lexeme
+	p.declName = name
 	p.advanceToken()
 
 	params := p.parseFunctionParameters()
@@ -229,22 +234,45 @@ func (p *Parser) parseFunctionParameters() []*Param {
 		// Primeiro: parse do tipo
 		typ := p.parseType()
 		if typ == nil {
-			p.errorf("expected parameter type")
+			p.error(diag.ExpectedParamType)
 			return nil
 		}
 
+		// int... nums: parâmetro variádico
+		variadic := false
+		if p.cur.Lexeme == "." && p.nxt.Lexeme == "." {
+			p.advanceToken()
+			p.advanceToken()
+			p.expectAndConsume(".")
+			variadic = true
+		}
+
 		// Segundo: parse do nome do parâmetro
 		if p.cur.Type != lexer.IDENT {
-			p.errorf("expected parameter name")
+			p.error(diag.ExpectedParamName)
 			return nil
 		}
 		name := p.cur.Lexeme
 		p.advanceToken()
 
-		params = append(params, &Param{Name: name, Type: typ})
+		param := &Param{Name: name, Type: typ, Variadic: variadic}
+		params = append(params, param)
+
+		// int age = 18: valor padrão
+		if p.cur.Lexeme == "=" {
+			p.advanceToken()
+			param.Default = p.expectExpr(diag.ExpectedDefaultValue, name)
+			if param.Default == nil {
+				return nil
+			}
+		}
 
 		// Se tem vírgula, continua para próximo parâmetro
 		if p.cur.Lexeme == "," {
+			if variadic {
+				p.error(diag.VariadicNotLast, name)
+				return nil
+			}
 			p.advanceToken()
 			continue
 		}
@@ -255,7 +283,7 @@ func (p *Parser) parseFunctionParameters() []*Param {
 			break
 		}
 
-		p.errorf("expected ',' or ')' in parameter list")
+		p.error(diag.ExpectedParamSep)
 		return nil
 	}
 
@@ -265,7 +293,7 @@ func (p *Parser) parseFunctionParameters() []*Param {
 // parseFunctionBody processa corpo de função/método
 func (p *Parser) parseFunctionBody() []Stmt {
 	if p.cur.Lexeme != "{" {
-		p.errorf("expected '{' to start function/method body")
+		p.error(diag.ExpectedFuncBody)
 		return nil
 	}
 
@@ -292,12 +320,13 @@ func (p *Parser) parseStructDecl(generics []*GenericParam) Stmt {
 	}
 
 	if p.cur.Type != lexer.IDENT {
-		p.errorf("expected struct name")
+		p.error(diag.ExpectedStructName)
 		p.syncTo("}")
 		return nil
 	}
 
 	name := p.cur.Lexeme
+	p.declName = name
 	p.advanceToken()
 
 	// Suporte para generics após o nome (sintaxe alternativa)
@@ -344,21 +373,26 @@ func (p *Parser) parseStructFields() []*FieldDecl {
 		typ := p.parseType()
 		if typ == nil {
 			if isPrivate {
-				p.errorf("expected type after 'private'")
+				p.error(diag.ExpectedTypeAfterPrivate)
 			} else {
-				p.errorf("expected field type in struct, got '%s'", p.cur.Lexeme)
+				p.error(diag.ExpectedFieldType, p.cur.Lexeme)
+			}
+			// O token atual não inicia um tipo: descarta-o antes de sincronizar,
+			// senão um identificador minúsculo ("x int") repetiria o erro para sempre
+			if p.cur.Lexeme != "}" {
+				p.advanceToken()
 			}
 			p.syncStructField()
 			continue
 		}
 
 		if p.cur.Type != lexer.IDENT {
-			p.errorf("expected field name after type, got '%s'", p.cur.Lexeme)
+			p.error(diag.ExpectedFieldName, p.cur.Lexeme)
 			p.syncStructField()
 			continue
 		}
 
-		fieldName := p.cur.Lexeme
+		fieldName, pos := p.cur.Lexeme, p.pos()
 		p.advanceToken()
 
 		p.consumeOptionalSemicolon()
@@ -367,6 +401,7 @@ func (p *Parser) parseStructFields() []*FieldDecl {
 			Name:      fieldName,
 			Type:      typ,
 			IsPrivate: isPrivate,
+			Pos:       pos,
 		})
 	}
 
@@ -396,7 +431,7 @@ func (p *Parser) parseImplementDecl() Stmt {
 	p.advanceToken() // consome 'implement'
 
 	if p.cur.Type != lexer.IDENT {
-		p.errorf("expected struct name to implement")
+		p.error(diag.ExpectedImplTarget)
 		return nil
 	}
 
@@ -433,14 +468,24 @@ func (p *Parser) parseImplementBody() (*InitDecl, []*MethodDecl) {
 
 		if p.cur.Lexeme == "init" {
 			if initDecl != nil {
-				p.errorf("multiple init blocks defined")
+				p.error(diag.MultipleInit)
 			}
 			initDecl = p.parseInitDecl()
 			continue
 		}
 
+		isPrivate := false
+		switch p.cur.Lexeme {
+		case "private":
+			isPrivate = true
+			p.advanceToken()
+		case "public":
+			p.advanceToken()
+		}
+
 		method := p.parseMethodDecl()
 		if method != nil {
+			method.IsPrivate = isPrivate
 			methods = append(methods, method)
 		} else {
 			// Sincroniza se falhar no método
@@ -468,7 +513,7 @@ func (p *Parser) parseMethodDecl() *MethodDecl {
 		return nil
 	}
 
-	name := p.cur.Lexeme
+	name, pos := p.cur.Lexeme, p.pos()
 	p.advanceToken()
 
 	params := p.parseFunctionParameters()
@@ -480,6 +525,7 @@ func (p *Parser) parseMethodDecl() *MethodDecl {
 		Params:      params,
 		ReturnTypes: returnTypes,
 		Body:        body,
+		Pos:         pos,
 	}
 }
 
@@ -529,17 +575,16 @@ func (p *Parser) parseGenericDeclaration() Stmt {
 	default:
 		returnTypes := p.parseReturnTypeList()
 		if returnTypes == nil {
-			p.errorf("expected struct or return type after generics")
+			p.error(diag.ExpectedAfterGenerics)
 			return nil
 		}
 
-		if !p.expectAndConsume("function") {
-			p.errorf("expected 'function' keyword")
+		if !p.expectOr("function", diag.ExpectedFunctionKeyword) {
 			return nil
 		}
 
 		if p.cur.Type != lexer.IDENT {
-			p.errorf("expected function name")
+			p.error(diag.ExpectedFuncName)
 			return nil
 		}
 
@@ -615,11 +660,12 @@ func (p *Parser) parseTypeDecl() Stmt {
 	}
 
 	if p.cur.Type != lexer.IDENT {
-		p.errorf("expected type name")
+		p.error(diag.ExpectedTypeName)
 		return nil
 	}
 
 	name := p.cur.Lexeme
+	p.declName = name
 	p.advanceToken()
 
 	if !hasGenericPrefix && p.cur.Lexeme == "<" {
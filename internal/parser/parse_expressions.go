@@ -1,8 +1,12 @@
 package parser
 
 import (
+	"errors"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/alpha/internal/diag"
 	"github.com/alpha/internal/lexer"
 )
 
@@ -29,32 +33,44 @@ const (
 )
 
 var precedences = map[string]int{
-	"?":  TERNARY,
-	"=":  ASSIGNMENT,
-	"+=": ASSIGNMENT,
-	"-=": ASSIGNMENT,
-	"*=": ASSIGNMENT,
-	"/=": ASSIGNMENT,
-	"||": LOGICALOR,
-	"&&": LOGICALAND,
-	"==": EQUALITY,
-	"!=": EQUALITY,
-	"<":  COMPARISON,
-	">":  COMPARISON,
-	"<=": COMPARISON,
-	">=": COMPARISON,
+	"?":   TERNARY,
+	"=":   ASSIGNMENT,
+	"+=":  ASSIGNMENT,
+	"-=":  ASSIGNMENT,
+	"*=":  ASSIGNMENT,
+	"/=":  ASSIGNMENT,
+	"%=":  ASSIGNMENT,
+	"&=":  ASSIGNMENT,
+	"|=":  ASSIGNMENT,
+	"^=":  ASSIGNMENT,
+	"<<=": ASSIGNMENT,
+	">>=": ASSIGNMENT,
+	"<-":  ASSIGNMENT,
+	"||":  LOGICALOR,
+	"&&":  LOGICALAND,
+	"==":  EQUALITY,
+	"!=":  EQUALITY,
+	"<":   COMPARISON,
+	">":   COMPARISON,
+	"<=":  COMPARISON,
+	">=":  COMPARISON,
+	// Operadores bit a bit seguem a precedência do Go: | e ^ somam,
+	// & e os deslocamentos multiplicam
 	"+":  SUM,
 	"-":  SUM,
+	"|":  SUM,
+	"^":  SUM,
 	"*":  PRODUCT,
 	"/":  PRODUCT,
 	"%":  PRODUCT,
+	"&":  PRODUCT,
+	"<<": PRODUCT,
+	">>": PRODUCT,
 	"(":  CALL,
 	".":  MEMBER,
 	"[":  INDEX,
 	"++": POSTFIX,
 	"--": POSTFIX,
-	"&":  PREFIX,
-	"|":  LOWEST,
 }
 
 // Conjuntos de operadores para verificação rápida
@@ -63,7 +79,10 @@ var (
 		"+": true, "-": true, "*": true, "/": true, "%": true,
 		">=": true, "<=": true, ">": true, "<": true,
 		"==": true, "!=": true, "&&": true, "||": true,
+		"&": true, "|": true, "^": true, "<<": true, ">>": true,
 		"=": true, "+=": true, "-=": true, "*=": true, "/=": true,
+		"%=": true, "&=": true, "|=": true, "^=": true, "<<=": true, ">>=": true,
+		"<-": true,
 	}
 
 	postfixOperators = map[string]bool{
@@ -72,7 +91,7 @@ var (
 	}
 
 	prefixOperators = map[string]bool{
-		"-": true, "!": true, "+": true, "++": true, "--": true, "*": true, "&": true,
+		"-": true, "!": true, "~": true, "+": true, "++": true, "--": true, "*": true, "&": true,
 	}
 )
 
@@ -196,6 +215,10 @@ func (p *Parser) parsePrimary() Expr {
 		return p.parseFloatLiteral()
 	case lexer.STRING:
 		return p.parseStringLiteral()
+	case lexer.CHAR:
+		return p.parseCharLiteral()
+	case lexer.TEMPLATE:
+		return p.parseInterpolatedString()
 	case lexer.KEYWORD:
 		return p.parseKeywordExpr()
 	case lexer.OP:
@@ -213,6 +236,11 @@ func (p *Parser) parseIdentifierExpr() Expr {
 		return p.parseExplicitCollectionLiteral()
 	}
 
+	// Criação de canal (ex: channel<int>(10))
+	if p.cur.Lexeme == "channel" && p.nxt.Lexeme == "<" {
+		return p.parseChannelExpr()
+	}
+
 	// Struct literal tipado (ex: Point { ... })
 	if p.nxt.Lexeme == "{" {
 		return p.parseTypedStructLiteral()
@@ -223,33 +251,59 @@ func (p *Parser) parseIdentifierExpr() Expr {
 
 // parseIdentifier cria um nó de identificador
 func (p *Parser) parseIdentifier() Expr {
-	ident := &Identifier{Name: p.cur.Lexeme}
+	ident := &Identifier{Name: p.cur.Lexeme, Pos: p.pos()}
 	p.advanceToken()
 	return ident
 }
 
-// parseIntLiteral processa literais inteiros
+// parseIntLiteral processa literais inteiros (decimais, 0x, 0o e 0b)
 func (p *Parser) parseIntLiteral() Expr {
-	value, err := strconv.ParseInt(p.cur.Lexeme, 10, 64)
+	lexeme := p.cur.Lexeme
+	base := 10
+	if len(lexeme) > 1 && lexeme[0] == '0' && strings.ContainsRune("xXoObB", rune(lexeme[1])) {
+		base = 0 // O prefixo define a base
+	} else {
+		lexeme = strings.ReplaceAll(lexeme, "_", "")
+	}
+
+	value, err := strconv.ParseInt(lexeme, base, 64)
 	if err != nil {
-		p.errorf("invalid integer literal: %s", p.cur.Lexeme)
-		return nil
+		if errors.Is(err, strconv.ErrRange) {
+			p.errorAt(p.pos(), diag.IntOverflow, p.cur.Lexeme)
+		} else {
+			p.errorAt(p.pos(), diag.InvalidInt, p.cur.Lexeme)
+		}
+		// Mantém um literal no lugar para não gerar erros em cascata
+		lit := &IntLiteral{Pos: p.pos()}
+		p.advanceToken()
+		return lit
 	}
 
+	lit := &IntLiteral{Value: value, Pos: p.pos()}
 	p.advanceToken()
-	return &IntLiteral{Value: value}
+	return lit
 }
 
-// parseFloatLiteral processa literais de ponto flutuante
+// parseFloatLiteral processa literais de ponto flutuante, com o sufixo
+// opcional 'f' (1.5f, 10f)
 func (p *Parser) parseFloatLiteral() Expr {
-	value, err := strconv.ParseFloat(p.cur.Lexeme, 64)
+	lexeme := strings.TrimRight(strings.ReplaceAll(p.cur.Lexeme, "_", ""), "fF")
+	value, err := strconv.ParseFloat(lexeme, 64)
 	if err != nil {
-		p.errorf("invalid float literal: %s", p.cur.Lexeme)
-		return nil
+		if errors.Is(err, strconv.ErrRange) {
+			p.errorAt(p.pos(), diag.FloatOverflow, p.cur.Lexeme)
+		} else {
+			p.errorAt(p.pos(), diag.InvalidFloat, p.cur.Lexeme)
+		}
+		// Mantém um literal no lugar para não gerar erros em cascata
+		lit := &FloatLiteral{Pos: p.pos()}
+		p.advanceToken()
+		return lit
 	}
 
+	lit := &FloatLiteral{Value: value, Pos: p.pos()}
 	p.advanceToken()
-	return &FloatLiteral{Value: value}
+	return lit
 }
 
 // parseStringLiteral processa literais de string
@@ -259,6 +313,65 @@ func (p *Parser) parseStringLiteral() Expr {
 	return str
 }
 
+// parseInterpolatedString processa strings com interpolação ("Olá ${nome}"),
+// analisando cada expressão embutida na sua posição original
+func (p *Parser) parseInterpolatedString() Expr {
+	tok := p.cur
+	str := &InterpolatedString{Pos: p.pos()}
+	p.advanceToken()
+
+	for _, part := range lexer.SplitTemplate(tok.Value) {
+		if !part.IsExpr {
+			str.Parts = append(str.Parts, &StringLiteral{Value: part.Text})
+			continue
+		}
+
+		pos := templatePos(tok, part.Offset)
+		if strings.TrimSpace(part.Text) == "" {
+			p.errorAt(pos, diag.EmptyInterpolation)
+			continue
+		}
+
+		sc := lexer.NewScannerAt(part.Text, pos.Line, pos.Col, pos.Offset)
+		sc.SetLanguage(p.sc.Language())
+		sub := New(sc)
+		expr := sub.parseExpression(LOWEST)
+		switch {
+		case expr == nil && !sub.HasErrors():
+			sub.errorAt(pos, diag.InvalidInterpolation, part.Text)
+		case expr != nil && sub.cur.Type != lexer.EOF:
+			sub.errorAt(sub.pos(), diag.UnexpectedInInterpolation, sub.cur.Lexeme)
+		}
+		p.Errors = append(p.Errors, sub.Errors...)
+		if expr != nil {
+			str.Parts = append(str.Parts, expr)
+		}
+	}
+
+	return str
+}
+
+// templatePos calcula a posição de um trecho de uma string interpolada a
+// partir do seu deslocamento (em bytes) no conteúdo do token
+func templatePos(tok lexer.Token, offset int) Pos {
+	before := tok.Value[:offset]
+	pos := Pos{Line: tok.Line, Offset: tok.Offset + 1 + offset} // +1 pela aspa de abertura
+	if nl := strings.LastIndexByte(before, '\n'); nl >= 0 {
+		pos.Line += strings.Count(before, "\n")
+		pos.Col = utf8.RuneCountInString(before[nl+1:]) + 1
+		return pos
+	}
+	pos.Col = tok.Col + 1 + utf8.RuneCountInString(before)
+	return pos
+}
+
+// parseCharLiteral processa literais de caractere
+func (p *Parser) parseCharLiteral() Expr {
+	r, _ := utf8.DecodeRuneInString(p.cur.Value)
+	p.advanceToken()
+	return &CharLiteral{Value: r}
+}
+
 // parseKeywordExpr processa expressões iniciadas por keywords
 func (p *Parser) parseKeywordExpr() Expr {
 	switch p.cur.Lexeme {
@@ -270,7 +383,7 @@ func (p *Parser) parseKeywordExpr() Expr {
 	case "self":
 		p.advanceToken()
 		return &SelfExpr{}
-	case "length", "append", "remove", "removeIndex", "delete", "add", "clear":
+	case "length", "append", "remove", "removeIndex", "delete", "add", "clear", "close", "waitAll":
 		return p.parseBuiltinCall()
 	case "generic":
 		return p.parseGenericCallOrExpr()
@@ -279,10 +392,13 @@ func (p *Parser) parseKeywordExpr() Expr {
 			if p.nxt.Lexeme == "(" {
 				return p.parseTypeCast()
 			}
+			if p.nxt.Lexeme == "[" {
+				return p.parseArrayCast()
+			}
 			// Se for apenas o tipo solto sem '(', não é uma expressão válida
 			return nil
 		}
-		p.errorf("unexpected keyword: %s", p.cur.Lexeme)
+		p.error(diag.UnexpectedKeyword, lexer.KeywordName(p.cur.Lexeme, p.sc.Language()))
 		return nil
 	}
 }
@@ -290,13 +406,13 @@ func (p *Parser) parseKeywordExpr() Expr {
 // parseBuiltinCall processa chamadas de funções built-in (len, append, delete)
 func (p *Parser) parseBuiltinCall() Expr {
 	// Salva o nome da built-in
-	builtinName := p.cur.Lexeme
+	builtinName, pos := p.cur.Lexeme, p.pos()
 	p.advanceToken() // consome o nome da built-in
 
 	// Verifica se é uma chamada de função
 	if p.cur.Lexeme != "(" {
 		// Se não tem parênteses, retorna como identificador
-		return &Identifier{Name: builtinName}
+		return &Identifier{Name: builtinName, Pos: pos}
 	}
 
 	// Parseia os argumentos
@@ -311,7 +427,7 @@ func (p *Parser) parseBuiltinCall() Expr {
 
 	// Cria um CallExpr especial (ou poderia ser um BuiltinCallExpr se quiser diferenciar)
 	return &CallExpr{
-		Callee: &Identifier{Name: builtinName},
+		Callee: &Identifier{Name: builtinName, Pos: pos},
 		Args:   args,
 	}
 }
@@ -334,6 +450,8 @@ func (p *Parser) parseOperatorExpr() Expr {
 		return p.parseBraceLiteral()
 	case "[":
 		return p.parseArrayLiteral()
+	case "<-":
+		return p.parseReceiveExpr()
 	case ":":
 		return nil
 	case ".":
@@ -347,7 +465,7 @@ func (p *Parser) parseOperatorExpr() Expr {
 		if p.isPrefixOperator(p.cur) {
 			return p.parsePrefixExpr()
 		}
-		p.errorf("unexpected operator: %s", p.cur.Lexeme)
+		p.error(diag.UnexpectedOperator, p.cur.Lexeme)
 		return nil
 	}
 }
@@ -384,10 +502,17 @@ func (p *Parser) parsePrefixExpr() Expr {
 
 // parseInfix processa operadores infixos
 func (p *Parser) parseInfix(left Expr, precedence int) Expr {
-	op := p.cur.Lexeme
+	op, pos := p.cur.Lexeme, p.pos()
 	p.advanceToken()
 
-	right := p.parseExpression(precedence)
+	// Operadores binários associam à esquerda (a - b - c = (a - b) - c);
+	// atribuições associam à direita (a = b = c)
+	rightPrec := precedence
+	if precedence != ASSIGNMENT {
+		rightPrec++
+	}
+
+	right := p.parseExpression(rightPrec)
 	if right == nil {
 		return nil
 	}
@@ -396,6 +521,10 @@ func (p *Parser) parseInfix(left Expr, precedence int) Expr {
 		return &AssignExpr{Left: left, Right: right}
 	}
 
+	if op == "<-" {
+		return &SendExpr{Channel: left, Value: right, Pos: pos}
+	}
+
 	return &BinaryExpr{Left: left, Op: op, Right: right}
 }
 
@@ -413,13 +542,13 @@ func (p *Parser) parseTernary(cond Expr) Expr {
 	// Parse a expressão verdadeira
 	trueExpr := p.parseExpression(LOWEST)
 	if trueExpr == nil {
-		p.errorf("expected expression after '?'")
+		p.error(diag.ExpectedTernaryTrue)
 		return nil
 	}
 
 	// CORREÇÃO: Verificar explicitamente se temos ':' antes de tentar consumir
 	if p.cur.Lexeme != ":" {
-		p.errorf("expected ':' in ternary expression, got '%s'", p.cur.Lexeme)
+		p.error(diag.ExpectedTernaryColon, p.cur.Lexeme)
 		return nil
 	}
 
@@ -431,7 +560,7 @@ func (p *Parser) parseTernary(cond Expr) Expr {
 	// Parse a expressão falsa com precedência TERNARY
 	falseExpr := p.parseExpression(TERNARY)
 	if falseExpr == nil {
-		p.errorf("expected expression after ':' in ternary")
+		p.error(diag.ExpectedTernaryFalse)
 		return nil
 	}
 
@@ -501,10 +630,22 @@ func (p *Parser) parseArgumentList() []Expr {
 	args := make([]Expr, 0, 3)
 
 	for {
+		// age: 30 é um argumento nomeado
+		var named *NamedArg
+		if p.cur.Type == lexer.IDENT && p.nxt.Lexeme == ":" {
+			named = &NamedArg{Name: p.cur.Lexeme, Pos: p.pos()}
+			p.advanceToken()
+			p.advanceToken()
+		}
+
 		arg := p.parseExpression(LOWEST)
 		if arg == nil {
 			return nil
 		}
+		if named != nil {
+			named.Value = arg
+			arg = named
+		}
 		args = append(args, arg)
 
 		if !p.match(",") {
@@ -545,14 +686,14 @@ func (p *Parser) parseMemberAccess(left Expr) Expr {
 	p.advanceToken() // consume '.'
 
 	if p.cur.Type != lexer.IDENT {
-		p.errorf("expected member name after '.'")
+		p.error(diag.ExpectedMemberName)
 		return nil
 	}
 
-	member := p.cur.Lexeme
+	member, pos := p.cur.Lexeme, p.pos()
 	p.advanceToken()
 
-	return &MemberExpr{Object: left, Member: member}
+	return &MemberExpr{Object: left, Member: member, Pos: pos}
 }
 
 // ============================
@@ -570,6 +711,7 @@ func (p *Parser) parseBraceLiteral() Expr {
 	}
 
 	// Parse primeiro elemento para determinar tipo
+	firstPos := p.pos()
 	firstExpr := p.parseExpression(LOWEST)
 	if firstExpr == nil {
 		return nil
@@ -585,7 +727,7 @@ func (p *Parser) parseBraceLiteral() Expr {
 
 		// Struct se a chave for identificador simples
 		if ident, ok := firstExpr.(*Identifier); ok {
-			return p.continueStructLiteral(ident, firstValue)
+			return p.continueStructLiteral(&StructField{Name: ident.Name, Value: firstValue, Pos: firstPos})
 		}
 		return p.continueMapLiteral(firstExpr, firstValue)
 	}
@@ -609,8 +751,8 @@ func (p *Parser) parseTypedStructLiteral() Expr {
 }
 
 // continueStructLiteral continua parsing de struct literal
-func (p *Parser) continueStructLiteral(firstKey *Identifier, firstValue Expr) Expr {
-	fields := []*StructField{{Name: firstKey.Name, Value: firstValue}}
+func (p *Parser) continueStructLiteral(first *StructField) Expr {
+	fields := []*StructField{first}
 
 	for p.cur.Lexeme != "}" && p.cur.Type != lexer.EOF {
 		if p.cur.Lexeme == "," {
@@ -621,11 +763,11 @@ func (p *Parser) continueStructLiteral(firstKey *Identifier, firstValue Expr) Ex
 		}
 
 		if p.cur.Type != lexer.IDENT {
-			p.errorf("expected field name in struct literal, got %s", p.cur.Lexeme)
+			p.error(diag.ExpectedStructLitField, p.cur.Lexeme)
 			return nil
 		}
 
-		fieldName := p.cur.Lexeme
+		fieldName, pos := p.cur.Lexeme, p.pos()
 		p.advanceToken()
 
 		if !p.expectAndConsume(":") {
@@ -637,7 +779,7 @@ func (p *Parser) continueStructLiteral(firstKey *Identifier, firstValue Expr) Ex
 			return nil
 		}
 
-		fields = append(fields, &StructField{Name: fieldName, Value: val})
+		fields = append(fields, &StructField{Name: fieldName, Value: val, Pos: pos})
 	}
 
 	if !p.expectAndConsume("}") {
@@ -717,7 +859,7 @@ func (p *Parser) parseExplicitCollectionLiteral() Expr {
 	}
 
 	if p.cur.Lexeme != "{" {
-		p.errorf("expected '{' after %s type definition", typeName)
+		p.error(diag.ExpectedLiteralBody, typeName)
 		return nil
 	}
 
@@ -755,8 +897,52 @@ func (p *Parser) parseReferenceExpr() Expr {
 	return &ReferenceExpr{Expr: expr}
 }
 
+// parseReceiveExpr processa recebimento de canal (<-ch)
+func (p *Parser) parseReceiveExpr() Expr {
+	pos := p.pos()
+	p.advanceToken() // consome '<-'
+
+	ch := p.parseExpression(PREFIX)
+	if ch == nil {
+		p.error(diag.ExpectedChannelAfterRecv)
+		return nil
+	}
+
+	return &ReceiveExpr{Channel: ch, Pos: pos}
+}
+
+// parseChannelExpr processa a criação de canais: channel<T>() ou channel<T>(capacidade)
+func (p *Parser) parseChannelExpr() Expr {
+	pos := p.pos()
+	p.advanceToken() // consome 'channel'
+
+	typ, ok := p.parseGenericType("channel").(*ChannelType)
+	if !ok {
+		return nil
+	}
+
+	if !p.expectAndConsume("(") {
+		return nil
+	}
+
+	var capacity Expr
+	if p.cur.Lexeme != ")" {
+		capacity = p.expectExpr(diag.ExpectedChannelCap)
+		if capacity == nil {
+			return nil
+		}
+	}
+
+	if !p.expectAndConsume(")") {
+		return nil
+	}
+
+	return &ChannelExpr{ElementType: typ.ElementType, Capacity: capacity, Pos: pos}
+}
+
 // parseArrayLiteral processa literais de array
 func (p *Parser) parseArrayLiteral() Expr {
+	pos := p.pos()
 	p.advanceToken()
 
 	elements := p.parseArrayElements()
@@ -764,7 +950,7 @@ func (p *Parser) parseArrayLiteral() Expr {
 		return nil
 	}
 
-	return &ArrayLiteral{Elements: elements}
+	return &ArrayLiteral{Elements: elements, Pos: pos}
 }
 
 // parseArrayElements processa elementos de array
@@ -780,7 +966,7 @@ func (p *Parser) parseArrayElements() []Expr {
 
 		if !p.match(",") {
 			if p.cur.Lexeme != "]" {
-				p.errorf("expected ',' or ']'")
+				p.error(diag.ExpectedArraySep)
 				return nil
 			}
 		}
@@ -821,9 +1007,9 @@ func (p *Parser) parseSetLiteral() Expr {
 // handleSetLiteralError lida com erros em set literals
 func (p *Parser) handleSetLiteralError() {
 	if p.cur.Type == lexer.EOF {
-		p.errorf("unexpected end of file in set literal")
+		p.error(diag.UnterminatedSetLit)
 	} else {
-		p.errorf("expected ',' or '}' in set literal, got '%s'", p.cur.Lexeme)
+		p.error(diag.ExpectedSetSep, p.cur.Lexeme)
 	}
 }
 
@@ -869,9 +1055,9 @@ func (p *Parser) parseMapLiteral() Expr {
 // handleMapLiteralError lida com erros em map literals
 func (p *Parser) handleMapLiteralError() {
 	if p.cur.Type == lexer.EOF {
-		p.errorf("unexpected end of file in map literal")
+		p.error(diag.UnterminatedMapLit)
 	} else {
-		p.errorf("expected ',' or '}' in map literal, got '%s'", p.cur.Lexeme)
+		p.error(diag.ExpectedMapSep, p.cur.Lexeme)
 	}
 }
 
@@ -897,14 +1083,14 @@ func (p *Parser) parseGenericCallOrExpr() Expr {
 		return p.parseGenericArrayExpr(typeArgs)
 	}
 
-	p.errorf("expected identifier or array literal after generic type arguments, got %s", p.cur.Lexeme)
+	p.error(diag.ExpectedAfterTypeArgs, p.cur.Lexeme)
 	return nil
 }
 
 // parseGenericIdentExpr processa identificadores genéricos
 func (p *Parser) parseGenericIdentExpr(typeArgs []Type) Expr {
 	name := p.cur.Lexeme // Captura o nome
-	ident := &Identifier{Name: name}
+	ident := &Identifier{Name: name, Pos: p.pos()}
 
 	// Struct literal genérico
 	if p.nxt.Lexeme == "{" {
@@ -970,9 +1156,8 @@ func (p *Parser) parseTypeCast() Expr {
 	}
 
 	// 3. Parseia a expressão interna
-	expr := p.parseExpression(LOWEST)
+	expr := p.expectExpr(diag.ExpectedCastExpr)
 	if expr == nil {
-		p.errorf("expected expression inside type cast")
 		return nil
 	}
 
@@ -987,3 +1172,28 @@ func (p *Parser) parseTypeCast() Expr {
 		Expr: expr,
 	}
 }
+
+// parseArrayCast processa conversões explícitas de array: int[](x), string[2](y)
+func (p *Parser) parseArrayCast() Expr {
+	mark := p.markErrors()
+	typ := p.parseSingleType()
+	if _, ok := typ.(*ArrayType); !ok {
+		p.errorInstead(mark, diag.ExpectedArrayCastType)
+		return nil
+	}
+
+	if !p.expectAndConsume("(") {
+		return nil
+	}
+
+	expr := p.expectExpr(diag.ExpectedCastExpr)
+	if expr == nil {
+		return nil
+	}
+
+	if !p.expectAndConsume(")") {
+		return nil
+	}
+
+	return &TypeCastExpr{Type: typ, Expr: expr}
+}
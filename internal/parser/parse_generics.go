@@ -1,6 +1,9 @@
 package parser
 
-import "github.com/alpha/internal/lexer"
+import (
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/lexer"
+)
 
 // ============================
 // TIPOS E CONSTANTES
@@ -26,8 +29,8 @@ type ParsedGenerics struct {
 
 // parseGenericParamsWithPrefix parseia generics com prefixo opcional "generic<T>"
 func (p *Parser) parseGenericParamsWithPrefix() []*GenericParam {
-	// Verifica se tem prefixo "generic"
-	hasPrefix := p.cur.Lexeme == "generic" && p.nxt.Lexeme == "<"
+	// Verifica se tem prefixo "generic"; sem o "<" logo depois ele é um erro
+	hasPrefix := p.cur.Lexeme == "generic"
 	if hasPrefix {
 		p.advanceToken() // consome "generic"
 	}
@@ -35,7 +38,7 @@ func (p *Parser) parseGenericParamsWithPrefix() []*GenericParam {
 	// Se não tem "<", retorna nil
 	if p.cur.Lexeme != "<" {
 		if hasPrefix {
-			p.errorf("expected '<' after 'generic'")
+			p.error(diag.ExpectedGenericOpen)
 		}
 		return nil
 	}
@@ -50,7 +53,7 @@ func (p *Parser) parseGenericParamsList() []*GenericParam {
 	}
 
 	params := p.parseGenericParamListItems()
-	if !p.expectAndConsume(">") {
+	if !p.expectCloseAngle() {
 		return nil
 	}
 
@@ -63,7 +66,7 @@ func (p *Parser) parseGenericParamListItems() []*GenericParam {
 
 	// Primeiro parâmetro
 	if !p.isValidGenericParam() {
-		p.errorf("expected generic parameter name, got %s", p.cur.Lexeme)
+		p.error(diag.ExpectedGenericParam, p.cur.Lexeme)
 		return nil
 	}
 
@@ -75,7 +78,7 @@ func (p *Parser) parseGenericParamListItems() []*GenericParam {
 		p.advanceToken() // consome ","
 
 		if !p.isValidGenericParam() {
-			p.errorf("expected generic parameter name after ',', got %s", p.cur.Lexeme)
+			p.error(diag.ExpectedGenericParamAfter, p.cur.Lexeme)
 			return nil
 		}
 
@@ -97,7 +100,7 @@ func (p *Parser) parseTypeArgumentsList() []Type {
 	}
 
 	typeArgs := p.parseTypeArgumentListItems()
-	if !p.expectAndConsume(">") {
+	if !p.expectCloseAngle() {
 		return nil
 	}
 
@@ -111,7 +114,7 @@ func (p *Parser) parseTypeArgumentListItems() []Type {
 	// Primeiro tipo
 	typ := p.parseType()
 	if typ == nil {
-		p.errorf("expected type in generic arguments")
+		p.error(diag.ExpectedTypeArg)
 		return nil
 	}
 	typeArgs = append(typeArgs, typ)
@@ -122,7 +125,7 @@ func (p *Parser) parseTypeArgumentListItems() []Type {
 
 		typ = p.parseType()
 		if typ == nil {
-			p.errorf("expected type after ',' in generic arguments")
+			p.error(diag.ExpectedTypeArgAfterComma)
 			return nil
 		}
 		typeArgs = append(typeArgs, typ)
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"github.com/alpha/internal/diag"
 	"github.com/alpha/internal/lexer"
 )
 
@@ -13,7 +14,7 @@ func (p *Parser) parsePackageDecl() Stmt {
 	p.advanceToken() // consome 'package'
 
 	if p.cur.Type != lexer.IDENT && p.cur.Lexeme != "." {
-		p.errorf("expected package name after 'package'")
+		p.error(diag.ExpectedPackageName)
 		return nil
 	}
 
@@ -21,9 +22,22 @@ func (p *Parser) parsePackageDecl() Stmt {
 	if name == "" {
 		return nil
 	}
+	decl := &PackageDecl{Name: name}
+
+	// Diretiva de idioma: o scanner já trocou as palavras-chave ao lê-la
+	if p.cur.Type == lexer.IDENT && p.cur.Lexeme == "lang" {
+		p.advanceToken() // consome 'lang'
+		lang, ok := lexer.ParseLanguage(p.cur.Lexeme)
+		if p.cur.Type != lexer.IDENT || !ok {
+			p.errorAt(p.pos(), diag.UnknownLanguage, p.cur.Lexeme)
+			return nil
+		}
+		decl.Lang = lang
+		p.advanceToken()
+	}
 
 	p.consumeOptionalSemicolon()
-	return &PackageDecl{Name: name}
+	return decl
 }
 
 // parseQualifiedName parseia um nome qualificado (com pontos)
@@ -34,7 +48,7 @@ func (p *Parser) parseQualifiedName() string {
 	for p.cur.Lexeme == "." {
 		p.advanceToken() // consome '.'
 		if p.cur.Type != lexer.IDENT {
-			p.errorf("expected identifier after '.' in package name")
+			p.error(diag.ExpectedPackageSegment)
 			return ""
 		}
 		name += "." + p.cur.Lexeme
@@ -114,12 +128,12 @@ func (p *Parser) parseImportListWithFrom(specs []*ImportSpec) Stmt {
 // parseSimpleModuleImport parseia importação de módulo simples
 func (p *Parser) parseSimpleModuleImport(specs []*ImportSpec) Stmt {
 	if len(specs) != 1 {
-		p.errorf("expected single module import or list with 'from'")
+		p.error(diag.ExpectedImportForm)
 		return nil
 	}
 
 	if specs[0].Alias != "" {
-		p.errorf("module import cannot have alias")
+		p.error(diag.ModuleImportAlias)
 		return nil
 	}
 
@@ -140,7 +154,7 @@ func (p *Parser) parseModulePath() string {
 		p.advanceToken()
 		return path
 	default:
-		p.errorf("expected module name or path after 'from'")
+		p.error(diag.ExpectedModuleAfterFrom)
 		return ""
 	}
 }
@@ -164,8 +178,7 @@ func (p *Parser) parseSelectiveImport() Stmt {
 		return nil
 	}
 
-	if !p.expectAndConsume("from") {
-		p.errorf("expected 'from' after import list")
+	if !p.expectOr("from", diag.ExpectedFrom) {
 		return nil
 	}
 
@@ -205,7 +218,7 @@ func (p *Parser) parseImportSpecListInternal(withBraces bool) []*ImportSpec {
 		}
 
 		if p.cur.Type != lexer.IDENT {
-			p.errorf("expected identifier in import list, got %s", p.cur.Lexeme)
+			p.error(diag.ExpectedImportName, p.cur.Lexeme)
 			return nil
 		}
 
@@ -243,7 +256,7 @@ func (p *Parser) parseImportSpec() *ImportSpec {
 		p.advanceToken() // consome 'as'
 
 		if p.cur.Type != lexer.IDENT {
-			p.errorf("expected alias name after 'as'")
+			p.error(diag.ExpectedAlias)
 			return nil
 		}
 
@@ -284,7 +297,7 @@ func (p *Parser) parseExportSpecList() []*ExportSpec {
 
 	for {
 		if p.cur.Type != lexer.IDENT {
-			p.errorf("expected identifier in export list, got %s", p.cur.Lexeme)
+			p.error(diag.ExpectedExportName, p.cur.Lexeme)
 			return nil
 		}
 
@@ -296,7 +309,7 @@ func (p *Parser) parseExportSpecList() []*ExportSpec {
 			p.advanceToken() // consome 'as'
 
 			if p.cur.Type != lexer.IDENT {
-				p.errorf("expected alias name after 'as'")
+				p.error(diag.ExpectedAlias)
 				return nil
 			}
 
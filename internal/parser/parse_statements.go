@@ -1,6 +1,9 @@
 package parser
 
-import "github.com/alpha/internal/lexer"
+import (
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/lexer"
+)
 
 // ============================
 // PARSING DE NÍVEL SUPERIOR
@@ -40,10 +43,51 @@ func (p *Parser) parseTopLevel() Stmt {
 	}
 }
 
+// parseStmt analisa um statement com recuperação de erros. Se ele não puder
+// ser montado, o resto dele é descartado até a próxima fronteira e um BadStmt
+// (ou BadDecl) ocupa o seu lugar; erros em partes opcionais mantêm o nó parcial
+func (p *Parser) parseStmt() Stmt {
+	from := p.pos()
+	isDecl := isDeclKeyword(p.cur.Lexeme) || p.isFunctionDecl()
+
+	outer := p.declName
+	p.declName = ""
+	stmt := p.parseTopLevel()
+	name := p.declName
+	p.declName = outer
+
+	if !p.panicking {
+		return stmt
+	}
+
+	// Sem progresso o mesmo token seria analisado de novo para sempre; o '}'
+	// fica para o bloco que o contém
+	if p.cur.Offset == from.Offset && p.cur.Lexeme != "}" && p.cur.Type != lexer.EOF {
+		p.advanceToken()
+	}
+	p.syncToNextStmt()
+	p.recovered()
+
+	switch {
+	case stmt != nil:
+		return stmt
+	case isDecl || name != "":
+		return &BadDecl{Name: name, From: from, To: p.pos()}
+	default:
+		return &BadStmt{From: from, To: p.pos()}
+	}
+}
+
+// badExpr devolve um BadExpr no lugar da expressão iniciada em from; o resto do
+// statement é descartado depois por parseStmt
+func (p *Parser) badExpr(from Pos) Expr {
+	return &BadExpr{From: from, To: p.pos()}
+}
+
 // parseControlOrDefaultStmt decide entre statement de controle ou padrão
 func (p *Parser) parseControlOrDefaultStmt() Stmt {
 	switch p.cur.Lexeme {
-	case "if", "while", "do", "for", "switch", "return", "break", "continue":
+	case "if", "while", "do", "for", "switch", "return", "break", "continue", "fallthrough", "spawn", "select":
 		return p.parseControlStmt()
 	default:
 		return p.parseDefaultStmt()
@@ -99,9 +143,35 @@ func (p *Parser) isFunctionDecl() bool {
 		return true
 	}
 
+	// Padrão 3: "int[] function" / "int[3] function" -> Tipo array como retorno
+	if p.nxt.Lexeme == "[" {
+		return p.isArrayReturnType()
+	}
+
 	return false
 }
 
+// isArrayReturnType olha além dos colchetes de um tipo array procurando a keyword
+// function. O scanner é restaurado ao final, então nenhum token é consumido
+func (p *Parser) isArrayReturnType() bool {
+	saved := *p.sc
+	defer func() { *p.sc = saved }()
+
+	tok := p.nxt
+	for tok.Lexeme == "[" {
+		tok = p.sc.NextToken()
+		if tok.Type == lexer.INT {
+			tok = p.sc.NextToken()
+		}
+		if tok.Lexeme != "]" {
+			return false
+		}
+		tok = p.sc.NextToken()
+	}
+
+	return tok.Lexeme == "function"
+}
+
 // ============================
 // STATEMENTS DE EXPRESSÃO
 // ============================
@@ -138,6 +208,15 @@ func (p *Parser) parseControlStmt() Stmt {
 		return p.parseBreak()
 	case "continue":
 		return p.parseContinue()
+	case "fallthrough":
+		pos := p.pos()
+		p.advanceToken()
+		p.consumeOptionalSemicolon()
+		return &FallthroughStmt{Pos: pos}
+	case "spawn":
+		return p.parseSpawn()
+	case "select":
+		return p.parseSelect()
 	default:
 		return nil
 	}
@@ -178,15 +257,18 @@ func (p *Parser) parseOptionalElse() []Stmt {
 
 // parseSwitch analisa uma declaração switch
 func (p *Parser) parseSwitch() Stmt {
+	pos := p.pos()
 	p.advanceToken() // consome 'switch'
 
-	cond := p.parseCondition()
-	if cond == nil {
-		return nil
+	// switch sem condição: switch { case x > 10: ... }
+	var cond Expr
+	if p.cur.Lexeme != "{" {
+		if cond = p.parseCondition(); cond == nil {
+			return nil
+		}
 	}
 
-	if !p.expectAndConsume("{") {
-		p.errorf("expected '{' after switch condition")
+	if !p.expectOr("{", diag.ExpectedSwitchBody) {
 		return nil
 	}
 
@@ -195,7 +277,7 @@ func (p *Parser) parseSwitch() Stmt {
 		return nil
 	}
 
-	return &SwitchStmt{Expr: cond, Cases: cases}
+	return &SwitchStmt{Expr: cond, Cases: cases, Pos: pos}
 }
 
 // parseSwitchCases analisa todos os casos de um switch
@@ -217,21 +299,18 @@ func (p *Parser) parseSwitchCases() []*CaseClause {
 
 // parseCaseClause analisa um único caso ou default
 func (p *Parser) parseCaseClause() *CaseClause {
-	var value Expr
+	clause := &CaseClause{Pos: p.pos()}
 
 	switch p.cur.Lexeme {
 	case "case":
 		p.advanceToken()
-		value = p.parseExpression(LOWEST)
-		if value == nil {
-			p.errorf("expected expression after 'case'")
+		if !p.parseCaseItems(clause) {
 			return nil
 		}
 	case "default":
 		p.advanceToken()
-		value = nil
 	default:
-		p.errorf("expected 'case' or 'default', got '%s'", p.cur.Lexeme)
+		p.error(diag.ExpectedCase, p.cur.Lexeme)
 		return nil
 	}
 
@@ -239,9 +318,34 @@ func (p *Parser) parseCaseClause() *CaseClause {
 		return nil
 	}
 
-	return &CaseClause{
-		Value: value,
-		Body:  p.parseCaseBody(),
+	clause.Body = p.parseCaseBody()
+	return clause
+}
+
+// parseCaseItems analisa a lista de valores ou tipos de um caso (case 1, 2, 3:)
+func (p *Parser) parseCaseItems(clause *CaseClause) bool {
+	for {
+		// Tipos primitivos não são expressões: case int, string:
+		if isTypeKeyword(p.cur.Lexeme) && p.nxt.Lexeme != "(" {
+			typ := p.parseType()
+			if typ == nil {
+				p.error(diag.ExpectedCaseType)
+				return false
+			}
+			clause.Types = append(clause.Types, typ)
+		} else {
+			value := p.parseExpression(LOWEST)
+			if value == nil {
+				p.error(diag.ExpectedCaseExpr)
+				return false
+			}
+			clause.Values = append(clause.Values, value)
+		}
+
+		if p.cur.Lexeme != "," {
+			return true
+		}
+		p.advanceToken() // consome ','
 	}
 }
 
@@ -250,15 +354,8 @@ func (p *Parser) parseCaseBody() []Stmt {
 	body := make([]Stmt, 0, 3)
 
 	for !p.isAtCaseEnd() {
-		stmt := p.parseTopLevel()
-		if stmt != nil {
+		if stmt := p.parseStmt(); stmt != nil {
 			body = append(body, stmt)
-		} else {
-			// Avança token se falhar ao parsear statement
-			if p.cur.Type == lexer.EOF {
-				break
-			}
-			p.advanceToken()
 		}
 	}
 
@@ -287,12 +384,11 @@ func (p *Parser) parseDoWhile() Stmt {
 
 	body := p.parseBlockLike()
 	if body == nil {
-		p.errorf("expected block after 'do'")
+		p.error(diag.ExpectedDoBlock)
 		return nil
 	}
 
-	if !p.expectAndConsume("while") {
-		p.errorf("expected 'while' after do block")
+	if !p.expectOr("while", diag.ExpectedDoWhile) {
 		return nil
 	}
 
@@ -397,7 +493,7 @@ func (p *Parser) parseForIn() Stmt {
 	}
 
 	if p.cur.Type != lexer.IDENT {
-		p.errorf("expected identifier in for-in loop")
+		p.error(diag.ExpectedForInName)
 		return nil
 	}
 
@@ -422,18 +518,18 @@ func (p *Parser) parseForIn() Stmt {
 
 // parseForInIdentifiers parseia identificadores do for-in
 func (p *Parser) parseForInIdentifiers() (*Identifier, *Identifier) {
-	firstIdent := &Identifier{Name: p.cur.Lexeme}
+	firstIdent := &Identifier{Name: p.cur.Lexeme, Pos: p.pos()}
 	p.advanceToken()
 
 	if p.cur.Lexeme == "," {
 		p.advanceToken()
 
 		if p.cur.Type != lexer.IDENT {
-			p.errorf("expected second identifier in for-in loop")
+			p.error(diag.ExpectedForInSecond)
 			return nil, nil
 		}
 
-		secondIdent := &Identifier{Name: p.cur.Lexeme}
+		secondIdent := &Identifier{Name: p.cur.Lexeme, Pos: p.pos()}
 		p.advanceToken()
 		return firstIdent, secondIdent
 	}
@@ -449,6 +545,131 @@ func (p *Parser) isForInLoop() bool {
 		p.nxt.Lexeme != "var"
 }
 
+// ============================
+// CONCORRÊNCIA (SPAWN/SELECT)
+// ============================
+
+// parseSpawn analisa o disparo de uma tarefa: spawn f(x)
+func (p *Parser) parseSpawn() Stmt {
+	pos := p.pos()
+	p.advanceToken() // consome 'spawn'
+
+	call := p.parseExpression(LOWEST)
+	if call == nil {
+		p.error(diag.ExpectedSpawnCall)
+		return nil
+	}
+
+	switch call.(type) {
+	case *CallExpr, *GenericCallExpr:
+	default:
+		p.error(diag.ExpectedSpawnCall)
+		return nil
+	}
+
+	p.consumeOptionalSemicolon()
+	return &SpawnStmt{Call: call, Pos: pos}
+}
+
+// parseSelect analisa uma declaração select
+func (p *Parser) parseSelect() Stmt {
+	pos := p.pos()
+	p.advanceToken() // consome 'select'
+
+	if !p.expectAndConsume("{") {
+		return nil
+	}
+
+	cases := make([]*SelectCase, 0, 3)
+	for !p.isAtSwitchEnd() {
+		clause := p.parseSelectCase()
+		if clause != nil {
+			cases = append(cases, clause)
+		} else {
+			// Avança token se falhar ao parsear caso
+			p.advanceToken()
+		}
+	}
+
+	if !p.expectAndConsume("}") {
+		return nil
+	}
+
+	return &SelectStmt{Cases: cases, Pos: pos}
+}
+
+// parseSelectCase analisa um caso de select ou default
+func (p *Parser) parseSelectCase() *SelectCase {
+	var comm Stmt
+	pos := p.pos()
+
+	switch p.cur.Lexeme {
+	case "case":
+		p.advanceToken()
+		comm = p.parseSelectComm()
+		if comm == nil {
+			return nil
+		}
+	case "default":
+		p.advanceToken()
+	default:
+		p.error(diag.ExpectedCase, p.cur.Lexeme)
+		return nil
+	}
+
+	if !p.expectAndConsume(":") {
+		return nil
+	}
+
+	return &SelectCase{
+		Comm: comm,
+		Body: p.parseCaseBody(),
+		Pos:  pos,
+	}
+}
+
+// parseSelectComm analisa a operação de canal de um caso de select
+func (p *Parser) parseSelectComm() Stmt {
+	var comm Stmt
+	if p.cur.Lexeme == "var" {
+		comm = p.parseVarDecl()
+	} else if decl := p.parseTypedVarDecl(); decl != nil {
+		comm = decl
+	} else {
+		comm = p.parseExprStmt()
+	}
+
+	if comm == nil {
+		p.error(diag.ExpectedSelectCase)
+		return nil
+	}
+
+	if !isSelectComm(comm) {
+		p.error(diag.InvalidSelectCase)
+		return nil
+	}
+
+	return comm
+}
+
+// isSelectComm verifica se o statement é uma operação de canal válida em select
+func isSelectComm(stmt Stmt) bool {
+	switch s := stmt.(type) {
+	case *VarDecl:
+		_, ok := s.Init.(*ReceiveExpr)
+		return ok
+	case *ExprStmt:
+		switch e := s.Expr.(type) {
+		case *SendExpr, *ReceiveExpr:
+			return true
+		case *AssignExpr:
+			_, ok := e.Right.(*ReceiveExpr)
+			return ok
+		}
+	}
+	return false
+}
+
 // ============================
 // STATEMENTS DE FLUXO
 // ============================
@@ -473,9 +694,8 @@ func (p *Parser) parseReturn() Stmt {
 	// Loop para expressões subsequentes separadas por vírgula
 	for p.cur.Lexeme == "," {
 		p.advanceToken() // consome a vírgula
-		val := p.parseExpression(LOWEST)
+		val := p.expectExpr(diag.ExpectedReturnExpr)
 		if val == nil {
-			p.errorf("expected expression after ',' in return statement")
 			return nil
 		}
 		values = append(values, val)
@@ -511,20 +731,16 @@ func (p *Parser) isAtEndOfStatement() bool {
 
 // parseCondition analisa uma condição entre parênteses
 func (p *Parser) parseCondition() Expr {
-	if !p.expectAndConsume("(") {
-		p.errorf("expected '(' after %s", p.cur.Lexeme)
+	if !p.expectOr("(", diag.ExpectedParenAfter, "'"+p.prev.Lexeme+"'") {
 		return nil
 	}
 
-	cond := p.parseExpression(LOWEST)
+	cond := p.expectExpr(diag.ExpectedCondition)
 	if cond == nil {
-		p.errorf("expected condition expression")
-		p.syncToNextStmt()
 		return nil
 	}
 
-	if !p.expectAndConsume(")") {
-		p.errorf("expected ')' after condition")
+	if !p.expectOr(")", diag.ExpectedCloseParenCond) {
 		return nil
 	}
 
@@ -537,7 +753,7 @@ func (p *Parser) parseBlockLike() []Stmt {
 		return p.parseBlock()
 	}
 
-	if stmt := p.parseTopLevel(); stmt != nil {
+	if stmt := p.parseStmt(); stmt != nil {
 		return []Stmt{stmt}
 	}
 
@@ -552,15 +768,8 @@ func (p *Parser) parseBlock() []Stmt {
 
 	stmts := make([]Stmt, 0, 5)
 	for !p.isBlockEnd() {
-		stmt := p.parseTopLevel()
-		if stmt != nil {
+		if stmt := p.parseStmt(); stmt != nil {
 			stmts = append(stmts, stmt)
-		} else {
-			// Se parseTopLevel falhar, avança token manualmente para evitar loop
-			if p.cur.Type == lexer.EOF {
-				break
-			}
-			p.advanceToken()
 		}
 	}
 
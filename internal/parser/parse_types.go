@@ -1,6 +1,10 @@
 package parser
 
 import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/alpha/internal/diag"
 	"github.com/alpha/internal/lexer"
 )
 
@@ -10,21 +14,29 @@ import (
 
 // typeKeywords define as palavras-chave de tipo reconhecidas
 var typeKeywords = map[string]bool{
-	"int":    true,
-	"string": true,
-	"float":  true,
-	"bool":   true,
-	"void":   true,
-	"byte":   true,
-	"char":   true,
-	"error":  true,
-	"set":    true,
-	"map":    true,
+	"int":     true,
+	"string":  true,
+	"float":   true,
+	"bool":    true,
+	"void":    true,
+	"byte":    true,
+	"char":    true,
+	"error":   true,
+	"set":     true,
+	"map":     true,
+	"channel": true,
 }
 
-// isTypeKeyword verifica se uma string é uma palavra-chave de tipo
+// isTypeKeyword verifica se uma string é uma palavra-chave de tipo ou um
+// nome de tipo (começa com maiúscula, em qualquer alfabeto: User, Ônibus)
 func isTypeKeyword(lex string) bool {
-	return typeKeywords[lex] || (len(lex) > 0 && lex[0] >= 'A' && lex[0] <= 'Z')
+	return typeKeywords[lex] || startsUpper(lex)
+}
+
+// startsUpper indica se a primeira runa do nome é uma letra maiúscula
+func startsUpper(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
 }
 
 // ============================
@@ -78,7 +90,7 @@ func (p *Parser) parseSingleType() Type {
 
 	var typ Type
 	switch p.cur.Lexeme {
-	case "set", "map":
+	case "set", "map", "channel":
 		name := p.cur.Lexeme
 		p.advanceToken()
 		typ = p.parseGenericType(name)
@@ -96,7 +108,7 @@ func (p *Parser) isValidTypeStart() bool {
 
 // parseBaseType analisa um tipo base (primitivo ou identificador)
 func (p *Parser) parseBaseType() Type {
-	name := p.cur.Lexeme
+	name, pos := p.cur.Lexeme, p.pos()
 
 	if !p.isValidBaseTypeName(name) {
 		return nil
@@ -110,12 +122,12 @@ func (p *Parser) parseBaseType() Type {
 	}
 
 	// Tipo primitivo ou identificador de tipo
-	return &IdentifierType{Name: name}
+	return &IdentifierType{Name: name, Pos: pos}
 }
 
 // isValidBaseTypeName verifica se o nome é válido para tipo base
 func (p *Parser) isValidBaseTypeName(name string) bool {
-	return isTypeKeyword(name) || (len(name) > 0 && name[0] >= 'A' && name[0] <= 'Z')
+	return isTypeKeyword(name)
 }
 
 // ============================
@@ -137,6 +149,8 @@ func (p *Parser) parseGenericType(name string) Type {
 		return p.parseSetType()
 	case "map":
 		return p.parseMapType()
+	case "channel":
+		return p.parseChannelType()
 	default:
 		return p.parseUserDefinedGenericType(name)
 	}
@@ -145,7 +159,7 @@ func (p *Parser) parseGenericType(name string) Type {
 // parseSetType analisa tipo de conjunto (Set<T>)
 func (p *Parser) parseSetType() Type {
 	elemType := p.parseType()
-	if elemType == nil || !p.expectAndConsume(">") {
+	if elemType == nil || !p.expectCloseAngle() {
 		return nil
 	}
 
@@ -160,18 +174,28 @@ func (p *Parser) parseMapType() Type {
 	}
 
 	valueType := p.parseType()
-	if valueType == nil || !p.expectAndConsume(">") {
+	if valueType == nil || !p.expectCloseAngle() {
 		return nil
 	}
 
 	return &MapType{KeyType: keyType, ValueType: valueType}
 }
 
+// parseChannelType analisa tipo de canal (channel<T>)
+func (p *Parser) parseChannelType() Type {
+	elemType := p.parseType()
+	if elemType == nil || !p.expectCloseAngle() {
+		return nil
+	}
+
+	return &ChannelType{ElementType: elemType}
+}
+
 // parseUserDefinedGenericType analisa tipos genéricos definidos pelo usuário
 func (p *Parser) parseUserDefinedGenericType(name string) Type {
 	// Coletar todos os argumentos de tipo
 	typeArgs := p.parseTypeArgumentList()
-	if typeArgs == nil || !p.expectAndConsume(">") {
+	if typeArgs == nil || !p.expectCloseAngle() {
 		return nil
 	}
 
@@ -257,7 +281,7 @@ func (p *Parser) parseReturnTypeList() []Type {
 		p.advanceToken()
 		nextType := p.parseType()
 		if nextType == nil {
-			p.errorf("expected type after ',' in return list")
+			p.error(diag.ExpectedReturnListType)
 			return nil
 		}
 		types = append(types, nextType)
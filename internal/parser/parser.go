@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/alpha/internal/diag"
 	"github.com/alpha/internal/lexer"
 )
 
@@ -14,11 +15,21 @@ import (
 // Parser representa o analisador sintático
 type Parser struct {
 	sc     *lexer.Scanner
+	prev   lexer.Token // último token consumido (fronteiras de linha na recuperação)
 	cur    lexer.Token
 	nxt    lexer.Token
 	Errors []string
+
+	// Recuperação de erros: depois do primeiro erro de um statement, os erros
+	// seguintes são consequência dele até a próxima sincronização
+	panicking bool
+	declName  string // nome da declaração em andamento (para BadDecl)
 }
 
+// maxErrors limita os erros sintáticos reportados; depois dele o parser
+// continua montando a AST, mas em silêncio
+const maxErrors = 10
+
 // ============================
 // INICIALIZAÇÃO E CONFIGURAÇÃO
 // ============================
@@ -37,6 +48,7 @@ func New(sc *lexer.Scanner) *Parser {
 
 // advanceToken avança para o próximo token
 func (p *Parser) advanceToken() {
+	p.prev = p.cur
 	p.cur = p.nxt
 	p.nxt = p.sc.NextToken()
 }
@@ -45,24 +57,24 @@ func (p *Parser) advanceToken() {
 // FUNÇÕES DE PARSING PRINCIPAL
 // ============================
 
-// ParseProgram analisa um programa completo
+// ParseProgram analisa um programa completo. Statements com erro de sintaxe
+// viram nós BadStmt/BadDecl, então a AST cobre o arquivo inteiro mesmo quando
+// há erros e as etapas seguintes podem analisar o resto do código
 func (p *Parser) ParseProgram() *Program {
 	body := make([]Stmt, 0, 10)
 
 	for p.cur.Type != lexer.EOF {
-		stmt := p.parseTopLevel()
-		if stmt != nil {
+		start := p.cur.Offset
+		if stmt := p.parseStmt(); stmt != nil {
 			body = append(body, stmt)
-		} else {
-			// Evita loop infinito quando não consegue parsear
-			if p.cur.Type == lexer.EOF {
-				break
-			}
-			p.advanceToken() // Tenta sincronizar
+		}
+		// Um '}' sem bloco aberto não é consumido por parseStmt
+		if p.cur.Offset == start && p.cur.Type != lexer.EOF {
+			p.advanceToken()
 		}
 	}
 
-	return &Program{Body: body}
+	return &Program{Body: body, Lang: p.sc.Language()}
 }
 
 // ============================
@@ -75,30 +87,129 @@ func (p *Parser) expectAndConsume(expected string) bool {
 		p.advanceToken()
 		return true
 	}
-	p.errorf("expected '%s', got '%s'", expected, p.cur.Lexeme)
+	p.error(diag.ExpectedToken, expected, p.cur.Lexeme)
 	return false
 }
 
-// isAtStmtStart verifica se estamos no início de um statement
-func (p *Parser) isAtStmtStart() bool {
-	return p.cur.Lexeme == ";" ||
-		p.cur.Lexeme == "}" ||
-		p.cur.Type == lexer.KEYWORD ||
-		p.cur.Lexeme == "{" ||
-		isTypeKeyword(p.cur.Lexeme)
+// expectOr é expectAndConsume com o erro do contexto (code) no lugar do
+// genérico "expected X, got Y"
+func (p *Parser) expectOr(expected string, code diag.Code, args ...any) bool {
+	if p.cur.Lexeme == expected {
+		p.advanceToken()
+		return true
+	}
+	p.error(code, args...)
+	return false
+}
+
+// expectExpr analisa uma expressão obrigatória. Se nenhuma começa no token
+// atual, o erro do contexto (code) substitui o que parseExpression reportou
+// sobre o token; um erro mais adiante, dentro da expressão, é mantido
+func (p *Parser) expectExpr(code diag.Code, args ...any) Expr {
+	mark, from := p.markErrors(), p.cur.Offset
+	expr := p.parseExpression(LOWEST)
+	if expr == nil && p.cur.Offset == from {
+		p.errorInstead(mark, code, args...)
+	}
+	return expr
+}
+
+// errorMark guarda o estado dos erros antes de uma análise interna
+type errorMark struct {
+	errors    int
+	panicking bool
+}
+
+func (p *Parser) markErrors() errorMark {
+	return errorMark{errors: len(p.Errors), panicking: p.panicking}
+}
+
+// errorInstead descarta os erros reportados desde a marca e reporta o do
+// contexto, que explica melhor a mesma falha
+func (p *Parser) errorInstead(mark errorMark, code diag.Code, args ...any) {
+	p.Errors, p.panicking = p.Errors[:mark.errors], mark.panicking
+	p.error(code, args...)
+}
+
+// expectCloseAngle consome o '>' que fecha uma lista de tipos. Em tipos
+// aninhados (map<int, set<int>>) o lexer entrega '>>', que é dividido
+func (p *Parser) expectCloseAngle() bool {
+	if rest, ok := strings.CutPrefix(p.cur.Lexeme, ">"); ok && rest != "" && p.cur.Type == lexer.OP {
+		p.cur.Lexeme, p.cur.Value = rest, rest
+		p.cur.Col++
+		return true
+	}
+	return p.expectAndConsume(">")
 }
 
 // ============================
 // FUNÇÕES DE SINCRONIZAÇÃO
 // ============================
 
-// syncToNextStmt sincroniza para o próximo statement após um erro
+// syncToNextStmt descarta tokens até a fronteira do próximo statement: logo
+// após um ';', antes do '}' que fecha o bloco atual ou no primeiro token de uma
+// nova linha fora de parênteses. Blocos '{ ... }' no caminho são descartados
+// inteiros, e uma declaração no início de uma linha sempre encerra a busca
 func (p *Parser) syncToNextStmt() {
-	for !p.isAtStmtStart() && p.cur.Type != lexer.EOF {
+	depth := 0 // parênteses e colchetes abertos no trecho descartado
+	for p.cur.Type != lexer.EOF {
+		if p.cur.Line > p.prev.Line && (depth == 0 || isDeclKeyword(p.cur.Lexeme)) {
+			return
+		}
+
+		switch p.cur.Lexeme {
+		case ";":
+			if depth == 0 {
+				p.advanceToken()
+				return
+			}
+		case "}":
+			return
+		case "{":
+			p.skipBlock()
+			continue
+		case "(", "[":
+			depth++
+		case ")", "]":
+			if depth > 0 {
+				depth--
+			}
+		}
 		p.advanceToken()
 	}
 }
 
+// skipBlock descarta um bloco '{ ... }' inteiro, incluindo blocos aninhados
+func (p *Parser) skipBlock() {
+	depth := 0
+	for p.cur.Type != lexer.EOF {
+		switch p.cur.Lexeme {
+		case "{":
+			depth++
+		case "}":
+			depth--
+		}
+		p.advanceToken()
+		if depth == 0 {
+			return
+		}
+	}
+}
+
+// isDeclKeyword verifica se a palavra inicia uma declaração de nível superior
+func isDeclKeyword(lexeme string) bool {
+	switch lexeme {
+	case "package", "import", "export", "var", "const", "struct", "implement", "type", "generic":
+		return true
+	}
+	return false
+}
+
+// pos retorna a posição do token corrente
+func (p *Parser) pos() Pos {
+	return Pos{Line: p.cur.Line, Col: p.cur.Col, Offset: p.cur.Offset}
+}
+
 // syncTo sincroniza até encontrar um token específico
 func (p *Parser) syncTo(token string) {
 	for p.cur.Lexeme != token && p.cur.Type != lexer.EOF {
@@ -113,9 +224,36 @@ func (p *Parser) syncTo(token string) {
 // FUNÇÕES DE CONTROLE DE ERROS
 // ============================
 
-// errorf adiciona um erro à lista de erros
-func (p *Parser) errorf(format string, args ...interface{}) {
-	p.Errors = append(p.Errors, fmt.Sprintf(format, args...))
+// error adiciona o erro do catálogo à lista de erros ("[A1001] ...")
+func (p *Parser) error(code diag.Code, args ...any) {
+	p.addError(diag.Label(code, diag.Msg(code, args...)))
+}
+
+// errorAt adiciona um erro com a posição de origem ("line X:col Y: [A1001] ...")
+func (p *Parser) errorAt(pos Pos, code diag.Code, args ...any) {
+	p.addError(fmt.Sprintf("line %d:col %d: %s", pos.Line, pos.Col, diag.Label(code, diag.Msg(code, args...))))
+}
+
+// addError registra a mensagem; as palavras-chave citadas seguem o idioma do arquivo.
+// Só o primeiro erro de cada statement é registrado: os seguintes, mesmo no
+// mesmo token, são cascata dele e são descartados até a próxima sincronização
+func (p *Parser) addError(msg string) {
+	if p.panicking {
+		return
+	}
+	p.panicking = true
+
+	switch {
+	case len(p.Errors) < maxErrors:
+		p.Errors = append(p.Errors, lexer.LocalizeKeywords(msg, p.sc.Language()))
+	case len(p.Errors) == maxErrors:
+		p.Errors = append(p.Errors, diag.Label(diag.TooManyErrors, diag.Msg(diag.TooManyErrors, maxErrors)))
+	}
+}
+
+// recovered encerra a recuperação de erros depois de uma sincronização
+func (p *Parser) recovered() {
+	p.panicking = false
 }
 
 // HasErrors verifica se há erros no parser
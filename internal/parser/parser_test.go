@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alpha/internal/lexer"
+)
+
+// Depois do primeiro erro de um statement, os demais (mesmo no mesmo token)
+// são cascata dele e não são reportados
+func TestOneErrorPerStatement(t *testing.T) {
+	tests := []struct {
+		name, body, want string
+	}{
+		{"unclosed condition", `if (x > 1 {
+        x = 2
+    }`, "expected ')' after condition"},
+		{"unfinished initializer", `int y = (x +
+    return y`, "unexpected keyword: return"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package main\nint function f(int x) {\n    " + tt.body + "\n    return x\n}\n"
+			p := New(lexer.NewScanner(src))
+			p.ParseProgram()
+			if len(p.Errors) != 1 || !strings.Contains(p.Errors[0], tt.want) {
+				t.Errorf("errors %q, want only %q", p.Errors, tt.want)
+			}
+		})
+	}
+}
+
+// Um erro em cada statement continua sendo reportado
+func TestErrorsInSeparateStatements(t *testing.T) {
+	p := New(lexer.NewScanner(`package main
+int function f(int x) {
+    int a = (x +
+    int b = 2
+    if (x > 1 {
+    }
+    return x
+}
+`))
+	p.ParseProgram()
+	if len(p.Errors) != 2 {
+		t.Errorf("want one error per broken statement, got %q", p.Errors)
+	}
+}
@@ -0,0 +1,170 @@
+package semantic
+
+import (
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/parser"
+)
+
+// ============================
+// ARRAYS DE TAMANHO FIXO
+// ============================
+
+// checkArrayLiteral infere o tipo de um literal de array. O tamanho é fixo
+// quando todos os elementos são conhecidos em tempo de compilação
+// (["a", "b"] é string[2]) e dinâmico quando espalha um array dinâmico
+func (c *Checker) checkArrayLiteral(e *parser.ArrayLiteral) Type {
+	if len(e.Elements) == 0 {
+		return &ParserTypeWrapper{Type: &parser.ArrayType{ElementType: &parser.PrimitiveType{Name: "any"}}}
+	}
+
+	var elementType parser.Type
+	size := int64(0)
+	fixed := true
+
+	for i, elem := range e.Elements {
+		elemType := c.checkExpr(elem)
+
+		if spread, ok := elem.(*parser.SpreadExpr); ok {
+			if arr, ok := c.unwrapType(c.TypeOf(spread.Expr)).(*parser.ArrayType); ok {
+				if n, ok := ArraySize(arr); ok {
+					size += n
+				} else {
+					fixed = false
+				}
+			} else {
+				fixed = false
+			}
+		} else {
+			size++
+		}
+
+		if wrapper, ok := elemType.(*ParserTypeWrapper); ok {
+			if i == 0 {
+				elementType = wrapper.Type
+			} else if !AreParserTypesCompatible(elementType, wrapper.Type) {
+				// Verificar compatibilidade com o primeiro tipo
+				c.reportError(0, 0, diag.InconsistentArrayElems,
+					StringifyParserType(elementType), StringifyParserType(wrapper.Type))
+			}
+		}
+	}
+
+	if elementType == nil {
+		elementType = &parser.PrimitiveType{Name: "any"}
+	}
+
+	arrType := &parser.ArrayType{ElementType: elementType}
+	if fixed {
+		arrType.Size = &parser.IntLiteral{Value: size}
+	}
+	return &ParserTypeWrapper{Type: arrType}
+}
+
+// adaptArrayLiteral ajusta o tipo de um literal de array ao tipo de destino.
+// Literais podem inicializar arrays dinâmicos ou fixos do mesmo tamanho; o tipo
+// adaptado é registrado na tabela para que o backend emita o literal correto
+func (c *Checker) adaptArrayLiteral(target Type, expr parser.Expr, exprType Type, what diag.Term) Type {
+	lit, ok := expr.(*parser.ArrayLiteral)
+	if !ok {
+		return exprType
+	}
+
+	tArr, ok := c.resolveType(c.unwrapType(target)).(*parser.ArrayType)
+	if !ok {
+		return exprType
+	}
+	sArr, ok := c.unwrapType(exprType).(*parser.ArrayType)
+	if !ok || !AreParserTypesCompatible(tArr.ElementType, sArr.ElementType) {
+		return exprType
+	}
+
+	tSize, tFixed := ArraySize(tArr)
+	sSize, sFixed := ArraySize(sArr)
+	if tFixed {
+		if !sFixed {
+			return exprType
+		}
+		if tSize != sSize {
+			c.reportError(lit.Pos.Line, lit.Pos.Col, diag.ArrayLiteralSize, sSize, what, tSize)
+		}
+	}
+
+	adapted := &ParserTypeWrapper{Type: tArr}
+	c.ExprTypes[lit] = adapted
+	return adapted
+}
+
+// checkArrayIndex reporta índices constantes fora dos limites de um array fixo
+func (c *Checker) checkArrayIndex(arr *parser.ArrayType, index parser.Expr) {
+	size, fixed := ArraySize(arr)
+	if !fixed {
+		return
+	}
+
+	idx, ok := c.constInt(index)
+	if !ok {
+		return
+	}
+
+	if idx < 0 || idx >= size {
+		pos := exprPos(index)
+		c.reportError(pos.Line, pos.Col, diag.IndexOutOfBounds, idx, size)
+	}
+}
+
+// checkArrayConversion verifica conversões explícitas entre arrays: T[](x) e T[N](x)
+func (c *Checker) checkArrayConversion(e *parser.TypeCastExpr, target *parser.ArrayType, exprType Type) Type {
+	targetType := c.wrapType(target)
+	if StringifyType(exprType) == "error" {
+		return targetType
+	}
+
+	if _, ok := e.Expr.(*parser.ArrayLiteral); ok {
+		return c.adaptArrayLiteral(targetType, e.Expr, exprType, diag.T("the conversion target"))
+	}
+
+	pos := exprPos(e.Expr)
+	source, ok := c.resolveType(c.unwrapType(exprType)).(*parser.ArrayType)
+	if !ok || !AreParserTypesCompatible(c.resolveType(target.ElementType), source.ElementType) {
+		c.reportError(pos.Line, pos.Col, diag.CannotConvert, StringifyType(exprType), StringifyParserType(target))
+		return targetType
+	}
+
+	tSize, tFixed := ArraySize(target)
+	sSize, sFixed := ArraySize(source)
+	if tFixed && sFixed && tSize != sSize {
+		c.reportError(pos.Line, pos.Col, diag.ConvertSizeMismatch, StringifyType(exprType), StringifyParserType(target))
+	}
+
+	return targetType
+}
+
+// arrayConversionHint sugere a conversão explícita quando dois arrays diferem
+// apenas por serem fixos ou dinâmicos
+func (c *Checker) arrayConversionHint(target, source Type) diag.Term {
+	tArr, ok := c.unwrapType(target).(*parser.ArrayType)
+	if !ok {
+		return diag.T("")
+	}
+	sArr, ok := c.unwrapType(source).(*parser.ArrayType)
+	if !ok || !AreParserTypesCompatible(tArr.ElementType, sArr.ElementType) {
+		return diag.T("")
+	}
+	return diag.T("; use an explicit conversion %s(...)", StringifyParserType(tArr))
+}
+
+// checkResizable reporta built-ins que alteram o tamanho de um array fixo
+func (c *Checker) checkResizable(builtin string, arg parser.Expr) {
+	t := c.unwrapType(c.TypeOf(arg))
+	if ptr, ok := t.(*parser.PointerType); ok {
+		t = ptr.BaseType
+	}
+
+	if arr, ok := c.resolveType(t).(*parser.ArrayType); ok {
+		if _, fixed := ArraySize(arr); fixed {
+			pos := exprPos(arg)
+			c.reportError(pos.Line, pos.Col, diag.FixedArrayResize,
+				builtin, StringifyParserType(arr), StringifyParserType(arr.ElementType))
+		}
+	}
+}
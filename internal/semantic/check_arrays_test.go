@@ -0,0 +1,26 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/alpha/internal/diag"
+)
+
+func TestArrayErrorPositions(t *testing.T) {
+	tests := []struct {
+		name, src string
+		code      diag.Code
+		line, col int
+	}{
+		{"literal size", "package main\nint[3] xs = [1, 2]\n", diag.ArrayLiteralSize, 2, 13},
+		{"constant index", "package main\nint[3] xs = [1, 2, 3]\nint y = xs[3]\n", diag.IndexOutOfBounds, 3, 12},
+		{"conversion", "package main\nint[] xs = [1, 2]\nstring[] ys = string[](xs)\n", diag.CannotConvert, 3, 24},
+		{"conversion size", "package main\nint[2] xs = [1, 2]\nint[3] ys = int[3](xs)\n", diag.ConvertSizeMismatch, 3, 20},
+		{"resize", "package main\nint[2] xs = [1, 2]\nvoid function f() {\n    append(xs, 3)\n}\n", diag.FixedArrayResize, 4, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errorAt(t, tt.src, tt.code, tt.line, tt.col)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package semantic
+
+import (
+	"strings"
+
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/parser"
+)
+
+// ============================
+// OPERADORES BIT A BIT
+// ============================
+
+// bitwiseOps são os operadores binários que só aceitam inteiros
+var bitwiseOps = map[string]bool{
+	"&": true, "|": true, "^": true, "<<": true, ">>": true,
+}
+
+// isIntegerType indica se o tipo é inteiro (int, byte ou char)
+func isIntegerType(typeStr string) bool {
+	return typeStr == "int" || isCharType(typeStr)
+}
+
+// checkBitwiseBinary verifica os operadores bit a bit e as suas atribuições
+// compostas (&=, <<=, ...). Retorna false se o operador não é bit a bit
+func (c *Checker) checkBitwiseBinary(e *parser.BinaryExpr, leftType, rightType Type) (Type, bool) {
+	op, compound := strings.CutSuffix(e.Op, "=")
+	if !bitwiseOps[op] {
+		return nil, false
+	}
+
+	if compound {
+		c.checkConstAssign(e.Left)
+		c.checkSharedWrite(e.Left)
+	}
+
+	if c.isLooseType(leftType) || c.isLooseType(rightType) {
+		return leftType, true
+	}
+
+	left, right := StringifyType(leftType), StringifyType(rightType)
+	for i, typeStr := range []string{left, right} {
+		if !isIntegerType(typeStr) {
+			pos := exprPos([]parser.Expr{e.Left, e.Right}[i])
+			c.reportError(pos.Line, pos.Col, diag.IntegerOperands, e.Op, typeStr)
+			return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}, true
+		}
+	}
+
+	// Deslocamentos aceitam qualquer inteiro à direita; o resultado tem o tipo da
+	// esquerda. Com os dois lados constantes, o erro vem da avaliação constante
+	if op == "<<" || op == ">>" {
+		if val, err := c.evalConst(e.Right); err == nil && val.Int < 0 && !c.isConstExpr(e.Left) {
+			pos := exprPos(e.Right)
+			c.reportError(pos.Line, pos.Col, diag.NegativeShift, val.Int, e.Op)
+		}
+		return leftType, true
+	}
+
+	switch {
+	case left == right:
+		return leftType, true
+	case right == "int" && c.isConstExpr(e.Right):
+		return leftType, true
+	case left == "int" && c.isConstExpr(e.Left) && !compound:
+		return rightType, true
+	}
+
+	pos := exprPos(e)
+	c.reportError(pos.Line, pos.Col, diag.MismatchedTypes, left, right, e.Op)
+	return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}, true
+}
+
+// checkBitwiseNot verifica o complemento bit a bit (~x)
+func (c *Checker) checkBitwiseNot(e *parser.UnaryExpr, valType Type) Type {
+	if typeStr := StringifyType(valType); !c.isLooseType(valType) && !isIntegerType(typeStr) {
+		pos := exprPos(e.Expr)
+		c.reportError(pos.Line, pos.Col, diag.IntegerOperand, typeStr)
+		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
+	}
+	return valType
+}
+
+// isLooseType indica tipos que só são conhecidos em tempo de execução (any,
+// genéricos) ou cujo erro já foi reportado
+func (c *Checker) isLooseType(t Type) bool {
+	typeStr := StringifyType(t)
+	return t == nil || typeStr == "any" || typeStr == "error" || c.isGenericType(t)
+}
@@ -0,0 +1,26 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/alpha/internal/diag"
+)
+
+func TestBitwiseErrorPositions(t *testing.T) {
+	tests := []struct {
+		name, body string
+		code       diag.Code
+		line, col  int
+	}{
+		{"float operand", "int y = x & f", diag.IntegerOperands, 3, 17},
+		{"negative shift", "int y = x << -1", diag.NegativeShift, 3, 19},
+		{"mismatched", "int y = b | x", diag.MismatchedTypes, 3, 13},
+		{"complement", "float g = ~f", diag.IntegerOperand, 3, 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package main\nvoid function run(int x, float f, byte b) {\n    " + tt.body + "\n}\n"
+			errorAt(t, src, tt.code, tt.line, tt.col)
+		})
+	}
+}
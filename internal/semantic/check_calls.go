@@ -0,0 +1,234 @@
+package semantic
+
+import (
+	"errors"
+
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/parser"
+)
+
+// ============================
+// ARGUMENTOS DE CHAMADAS
+// ============================
+
+// callTarget descreve a função, método ou construtor chamado
+type callTarget struct {
+	name    string    // Nome usado nas mensagens de argumentos (sum, User)
+	desc    diag.Term // Descrição usada nas mensagens de quantidade (Function 'sum')
+	params  []*parser.Param
+	generic bool // Parâmetros genéricos não têm o tipo verificado
+}
+
+func functionTarget(fn *parser.FunctionDecl) callTarget {
+	return callTarget{
+		name:    fn.Name,
+		desc:    diag.T("Function '%s'", fn.Name),
+		params:  fn.Params,
+		generic: len(fn.Generics) > 0,
+	}
+}
+
+func methodTarget(m *parser.MethodDecl) callTarget {
+	return callTarget{
+		name:    m.Name,
+		desc:    diag.T("Method '%s'", m.Name),
+		params:  m.Params,
+		generic: len(m.Generics) > 0,
+	}
+}
+
+func constructorTarget(structName string, init *parser.InitDecl) callTarget {
+	return callTarget{
+		name:   structName,
+		desc:   diag.T("Constructor of '%s'", structName),
+		params: init.Params,
+	}
+}
+
+// checkCallArgs verifica os argumentos de uma chamada: posicionais, nomeados
+// (age: 30) e parâmetros omitidos com valor padrão. Em funções variádicas, os
+// argumentos extras (ou um ...array) são verificados contra o tipo do elemento
+// do último parâmetro
+func (c *Checker) checkCallArgs(t callTarget, e *parser.CallExpr) {
+	params := t.params
+	variadic := parser.IsVariadic(params)
+	fixed := len(params)
+	if variadic {
+		fixed--
+		c.VariadicCalls[e] = fixed
+	}
+
+	slots := make([]parser.Expr, fixed) // Argumento de cada parâmetro fixo
+	positional := 0
+	named := false
+
+	for i, arg := range e.Args {
+		if na, ok := arg.(*parser.NamedArg); ok {
+			named = true
+			c.checkNamedArg(t, na, slots)
+			continue
+		}
+		if named {
+			c.reportError(0, 0, diag.PositionalAfterNamed, t.name)
+			continue
+		}
+		positional++
+
+		spread, isSpread := arg.(*parser.SpreadExpr)
+		switch {
+		case isSpread && !variadic:
+			c.reportError(0, 0, diag.SpreadNonVariadic, t.name)
+			continue
+		case isSpread && i < fixed:
+			c.reportError(0, 0, diag.SpreadFixedParam, params[i].Name, t.name)
+			continue
+		case i >= len(params) && !variadic:
+			continue
+		}
+
+		if i < fixed {
+			slots[i] = arg
+		}
+
+		argType := c.TypeOf(arg)
+		if isSpread {
+			// ...lista entrega os elementos: compara o tipo do elemento
+			argType = c.spreadElementType(spread)
+		}
+		c.checkArgType(t, i, params[min(i, len(params)-1)], arg, argType)
+	}
+
+	required := 0
+	for _, param := range params[:fixed] {
+		if param.Default == nil {
+			required++
+		}
+	}
+
+	if !variadic && positional > fixed && !hasSpread(e.Args) {
+		if required == fixed {
+			c.reportError(0, 0, diag.ArgCount, t.desc, fixed, len(e.Args))
+		} else {
+			c.reportError(0, 0, diag.ArgCountMax, t.desc, fixed, len(e.Args))
+		}
+		return
+	}
+
+	// Parâmetros sem argumento precisam de um valor padrão
+	usesDefaults := false
+	for i, param := range params[:fixed] {
+		switch {
+		case slots[i] != nil:
+			continue
+		case param.Default != nil:
+			slots[i] = param.Default
+			usesDefaults = true
+			continue
+		case hasSpread(e.Args):
+			return
+		case !named && required == fixed && variadic:
+			c.reportError(0, 0, diag.ArgCountMin, t.desc, fixed, len(e.Args))
+			return
+		case !named && required == fixed:
+			c.reportError(0, 0, diag.ArgCount, t.desc, fixed, len(e.Args))
+			return
+		}
+		c.reportError(0, 0, diag.MissingArgument, param.Name, t.name)
+	}
+
+	// O IR usa a lista completa quando a chamada não é só posicional
+	if named || usesDefaults {
+		c.CallArgs[e] = slots
+	}
+}
+
+// checkNamedArg associa um argumento nomeado ao parâmetro correspondente
+func (c *Checker) checkNamedArg(t callTarget, na *parser.NamedArg, slots []parser.Expr) {
+	idx := -1
+	for i, param := range t.params {
+		if param.Name == na.Name {
+			idx = i
+			break
+		}
+	}
+
+	switch {
+	case idx < 0:
+		c.reportError(na.Pos.Line, na.Pos.Col, diag.UnknownNamedParam, t.desc, na.Name)
+	case t.params[idx].Variadic:
+		c.reportError(na.Pos.Line, na.Pos.Col, diag.VariadicByName, na.Name)
+	case slots[idx] != nil:
+		c.reportError(na.Pos.Line, na.Pos.Col, diag.ParamSetTwice, na.Name, t.name)
+	default:
+		slots[idx] = na.Value
+		c.checkArgType(t, idx, t.params[idx], na.Value, c.TypeOf(na.Value))
+	}
+}
+
+func (c *Checker) checkArgType(t callTarget, i int, param *parser.Param, arg parser.Expr, argType Type) {
+	if !t.generic && argType != nil {
+		argType = c.adaptCharConstant(c.wrapType(param.Type), arg, argType)
+	}
+	if t.generic || argType == nil || c.areTypesCompatible(c.wrapType(param.Type), argType) {
+		return
+	}
+	c.reportError(0, 0, diag.ArgTypeMismatch, i+1, t.name, StringifyParserType(param.Type), StringifyType(argType))
+}
+
+// checkParamDefaults verifica os valores padrão de uma lista de parâmetros:
+// precisam ser constantes do tipo do parâmetro e vir depois dos obrigatórios
+func (c *Checker) checkParamDefaults(params []*parser.Param) {
+	seenDefault := false
+	for _, param := range params {
+		if param.Default == nil {
+			if seenDefault && !param.Variadic {
+				c.reportError(0, 0, diag.DefaultOrder, param.Name)
+			}
+			continue
+		}
+		seenDefault = true
+
+		if param.Variadic {
+			c.reportError(0, 0, diag.VariadicDefault, param.Name)
+			continue
+		}
+
+		defType := c.checkExpr(param.Default)
+		val, err := c.evalConst(param.Default)
+		switch {
+		case err == nil:
+			c.ConstValues[param.Default] = val
+		case errors.Is(err, errNotConstant):
+			c.reportError(0, 0, diag.DefaultNotConst, param.Name)
+			continue
+		case errors.Is(err, errInvalidConst):
+			continue
+		default:
+			c.reportConstError(err, exprPos(param.Default))
+			continue
+		}
+
+		defType = c.adaptCharConstant(c.wrapType(param.Type), param.Default, defType)
+		if !c.isGenericType(c.wrapType(param.Type)) && !c.areTypesCompatible(c.wrapType(param.Type), defType) {
+			c.reportError(0, 0, diag.DefaultTypeMismatch,
+				param.Name, StringifyParserType(param.Type), StringifyType(defType))
+		}
+	}
+}
+
+// spreadElementType retorna o tipo do elemento do array espalhado por ...expr
+func (c *Checker) spreadElementType(e *parser.SpreadExpr) Type {
+	if arr, ok := c.unwrapType(c.TypeOf(e.Expr)).(*parser.ArrayType); ok {
+		return c.wrapType(arr.ElementType)
+	}
+	return nil
+}
+
+func hasSpread(args []parser.Expr) bool {
+	for _, arg := range args {
+		if _, ok := arg.(*parser.SpreadExpr); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package semantic
+
+import (
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/parser"
+)
+
+// ============================
+// CARACTERES (char e byte)
+// ============================
+
+// isCharType indica se o tipo é um dos tipos de caractere (char ou byte)
+func isCharType(typeStr string) bool {
+	return typeStr == "char" || typeStr == "byte"
+}
+
+// checkCharBinary verifica operações aritméticas e comparações envolvendo
+// char ou byte. Os dois lados precisam ter o mesmo tipo; um int só é aceito
+// quando é constante ('a' + 1). Retorna false se nenhum lado é um caractere
+func (c *Checker) checkCharBinary(e *parser.BinaryExpr, leftType, rightType Type) (Type, bool) {
+	left, right := StringifyType(leftType), StringifyType(rightType)
+	if !isCharType(left) && !isCharType(right) {
+		return nil, false
+	}
+
+	var result string
+	switch {
+	case left == right:
+		result = left
+	case isCharType(left) && right == "int" && c.isConstExpr(e.Right):
+		result = left
+	case isCharType(right) && left == "int" && c.isConstExpr(e.Left):
+		result = right
+	case left == "any" || right == "any" || left == "error" || right == "error" ||
+		c.isGenericType(leftType) || c.isGenericType(rightType):
+		return nil, false
+	default:
+		c.reportError(0, 0, diag.MismatchedTypes, left, right, e.Op)
+		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}, true
+	}
+
+	switch e.Op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "bool"}}, true
+	case "+", "-", "*", "/", "%":
+		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: result}}, true
+	}
+	return nil, false
+}
+
+// checkCharConversion verifica conversões de e para char e byte: apenas
+// números inteiros e outros caracteres podem virar caractere, e caracteres
+// podem virar int, float ou string
+func (c *Checker) checkCharConversion(source Type, target string) bool {
+	sourceStr := StringifyType(source)
+	switch {
+	case !isCharType(sourceStr) && !isCharType(target):
+		return true
+	case sourceStr == "any" || sourceStr == "error" || c.isGenericType(source):
+		return true
+	case isCharType(target):
+		if sourceStr == "int" || isCharType(sourceStr) {
+			return true
+		}
+	case target == "int" || target == "float" || target == "string" || isCharType(target):
+		return true
+	}
+
+	c.reportError(0, 0, diag.CannotConvert, sourceStr, target)
+	return false
+}
+
+// adaptCharConstant permite inicializar char e byte com constantes inteiras
+// (byte b = 10), como as constantes sem tipo do Go. Uma constante fora da
+// faixa do tipo é reportada na posição do literal (byte b = 300)
+func (c *Checker) adaptCharConstant(target Type, expr parser.Expr, exprType Type) Type {
+	targetStr := StringifyType(target)
+	if !isCharType(targetStr) || StringifyType(exprType) != "int" {
+		return exprType
+	}
+
+	val, err := c.evalConst(expr)
+	if err != nil {
+		return exprType
+	}
+	if _, err := ConvertConst(val, targetStr); err != nil {
+		pos := literalPos(expr)
+		c.reportError(pos.Line, pos.Col, diag.ConstOverflowsType, val, targetStr)
+		return target // O erro já foi reportado
+	}
+	c.ExprTypes[expr] = target
+	return target
+}
+
+// literalPos retorna a posição do primeiro literal numérico da expressão
+func literalPos(expr parser.Expr) parser.Pos {
+	switch e := expr.(type) {
+	case *parser.IntLiteral:
+		return e.Pos
+	case *parser.FloatLiteral:
+		return e.Pos
+	case *parser.UnaryExpr:
+		return literalPos(e.Expr)
+	case *parser.BinaryExpr:
+		if pos := literalPos(e.Left); pos.Line > 0 {
+			return pos
+		}
+		return literalPos(e.Right)
+	}
+	return parser.Pos{}
+}
+
+func (c *Checker) isConstExpr(expr parser.Expr) bool {
+	_, err := c.evalConst(expr)
+	return err == nil
+}
@@ -0,0 +1,271 @@
+package semantic
+
+import (
+	"strings"
+
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/parser"
+)
+
+// ============================
+// SPAWN / SELECT
+// ============================
+
+// sharedVar registra onde uma variável passou a ser compartilhada com uma tarefa
+type sharedVar struct {
+	pos    parser.Pos // Posição do spawn
+	inLoop bool       // Compartilhada dentro de um loop
+}
+
+func (c *Checker) checkSpawnStmt(s *parser.SpawnStmt) {
+	c.checkInsideFunction("spawn", s.Pos)
+
+	var args []parser.Expr
+	switch call := s.Call.(type) {
+	case *parser.CallExpr:
+		args = call.Args
+	case *parser.GenericCallExpr:
+		args = call.Args
+	}
+
+	// Compartilhar de novo é reportado abaixo (SharedTwice), não como leitura
+	for _, arg := range args {
+		if c.sharedSymbol(arg) != nil {
+			c.skipSharedRead(arg)
+		}
+	}
+	c.checkExpr(s.Call)
+
+	// Registrar as variáveis que a tarefa passa a compartilhar com quem a disparou
+	for _, arg := range args {
+		sym := c.sharedSymbol(arg)
+		if sym == nil {
+			continue
+		}
+
+		if _, already := c.sharedVars[sym]; already {
+			c.reportError(s.Pos.Line, s.Pos.Col, diag.SharedTwice, sym.Name)
+			continue
+		}
+		c.sharedVars[sym] = sharedVar{pos: s.Pos, inLoop: c.inLoop}
+	}
+}
+
+// sharedSymbol retorna a variável compartilhada por um argumento de spawn:
+// referências (&x) e valores de tipos de referência (arrays, maps e sets)
+func (c *Checker) sharedSymbol(arg parser.Expr) *Symbol {
+	if ref, ok := arg.(*parser.ReferenceExpr); ok {
+		return c.rootSymbol(ref.Expr)
+	}
+
+	ident, ok := arg.(*parser.Identifier)
+	if !ok {
+		return nil
+	}
+
+	sym := c.CurrentScope.Resolve(ident.Name)
+	if sym == nil || sym.Kind != KindVar {
+		return nil
+	}
+
+	switch c.unwrapType(sym.Type).(type) {
+	case *parser.ArrayType, *parser.MapType, *parser.SetType:
+		return sym
+	}
+	return nil
+}
+
+// rootSymbol retorna a variável base de um acesso (x, x[i], x.campo)
+func (c *Checker) rootSymbol(expr parser.Expr) *Symbol {
+	sym, _ := c.rootVar(expr)
+	return sym
+}
+
+// rootVar é rootSymbol junto da posição em que a variável aparece no acesso
+func (c *Checker) rootVar(expr parser.Expr) (*Symbol, parser.Pos) {
+	ident := rootIdent(expr)
+	if ident == nil {
+		return nil, parser.Pos{}
+	}
+	sym := c.CurrentScope.Resolve(ident.Name)
+	if sym == nil || sym.Kind != KindVar {
+		return nil, parser.Pos{}
+	}
+	return sym, ident.Pos
+}
+
+// rootIdent retorna o nome da variável base de um acesso (x, x[i], x.campo)
+func rootIdent(expr parser.Expr) *parser.Identifier {
+	switch e := expr.(type) {
+	case *parser.Identifier:
+		return e
+	case *parser.IndexExpr:
+		return rootIdent(e.Array)
+	case *parser.MemberExpr:
+		return rootIdent(e.Object)
+	case *parser.ReferenceExpr:
+		return rootIdent(e.Expr)
+	}
+	return nil
+}
+
+// compoundAssign indica se o operador binário também escreve no lado esquerdo (+=, <<=)
+func compoundAssign(op string) bool {
+	switch op {
+	case "==", "!=", "<=", ">=":
+		return false
+	}
+	return strings.HasSuffix(op, "=")
+}
+
+// skipSharedRead marca o acesso a uma variável que outra verificação já cobre
+// (escritas e argumentos de spawn), para que ele não conte também como leitura
+func (c *Checker) skipSharedRead(expr parser.Expr) {
+	if ident := rootIdent(expr); ident != nil {
+		c.sharedAccess[ident] = true
+	}
+}
+
+// checkSharedRead reporta leituras de variáveis ainda compartilhadas com
+// tarefas: a tarefa pode estar escrevendo nelas ao mesmo tempo
+func (c *Checker) checkSharedRead(ident *parser.Identifier, sym *Symbol) {
+	if c.sharedAccess[ident] {
+		return
+	}
+	if _, shared := c.sharedVars[sym]; shared {
+		c.reportError(ident.Pos.Line, ident.Pos.Col, diag.DataRaceRead, sym.Name)
+	}
+}
+
+// checkSharedWrite reporta escritas em variáveis ainda compartilhadas com tarefas
+func (c *Checker) checkSharedWrite(target parser.Expr) {
+	sym, pos := c.rootVar(target)
+	if sym == nil {
+		return
+	}
+	if _, shared := c.sharedVars[sym]; shared {
+		c.reportError(pos.Line, pos.Col, diag.DataRaceWrite, sym.Name)
+	}
+}
+
+// checkInsideFunction reporta spawn, select, close() e waitAll() no nível de
+// cima do arquivo, onde os comandos não são executados
+func (c *Checker) checkInsideFunction(name string, pos parser.Pos) {
+	if c.currentFuncReturnType == nil {
+		c.reportError(pos.Line, pos.Col, diag.ConcurrencyOutsideFunc, name)
+	}
+}
+
+// checkLoopSharing é chamada ao final de um loop: variáveis compartilhadas dentro
+// do corpo sem um waitAll() na mesma iteração são acessadas por várias tarefas
+func (c *Checker) checkLoopSharing() {
+	for sym, shared := range c.sharedVars {
+		if shared.inLoop && !c.inLoop {
+			c.reportError(shared.pos.Line, shared.pos.Col, diag.DataRaceLoop, sym.Name)
+			delete(c.sharedVars, sym)
+		}
+	}
+}
+
+func (c *Checker) checkSelectStmt(s *parser.SelectStmt) {
+	c.checkInsideFunction("select", s.Pos)
+	hasDefault := false
+
+	for _, clause := range s.Cases {
+		if clause.Comm == nil {
+			if hasDefault {
+				c.reportError(clause.Pos.Line, clause.Pos.Col, diag.SelectDuplicateDefault)
+			}
+			hasDefault = true
+		}
+
+		// Cada caso tem seu próprio escopo (var msg = <-ch fica visível apenas no corpo)
+		c.enterScope()
+		if clause.Comm != nil {
+			c.checkStmt(clause.Comm)
+		}
+		for _, stmt := range clause.Body {
+			c.checkStmt(stmt)
+		}
+		c.exitScope()
+	}
+}
+
+// ============================
+// EXPRESSÕES DE CANAL
+// ============================
+
+func (c *Checker) checkChannelExpr(e *parser.ChannelExpr) Type {
+	c.validateTypeExists(e.ElementType)
+
+	if e.Capacity != nil {
+		capType := StringifyType(c.checkExpr(e.Capacity))
+		if capType != "int" && capType != "error" {
+			c.reportError(e.Pos.Line, e.Pos.Col, diag.ChannelCapacity, capType)
+		}
+	}
+
+	return &ParserTypeWrapper{Type: &parser.ChannelType{ElementType: c.resolveType(e.ElementType)}}
+}
+
+func (c *Checker) checkSendExpr(e *parser.SendExpr) Type {
+	chanType := c.channelType(e.Channel, e.Pos)
+	valType := c.checkExpr(e.Value)
+
+	if chanType != nil {
+		elemType := c.wrapType(chanType.ElementType)
+		if !AreTypesCompatible(elemType, valType) {
+			c.reportError(e.Pos.Line, e.Pos.Col, diag.CannotSend, StringifyType(valType), StringifyParserType(chanType))
+		}
+	}
+
+	return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "void"}}
+}
+
+func (c *Checker) checkReceiveExpr(e *parser.ReceiveExpr) Type {
+	chanType := c.channelType(e.Channel, e.Pos)
+	if chanType == nil {
+		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
+	}
+	return &ParserTypeWrapper{Type: chanType.ElementType}
+}
+
+// channelType verifica uma expressão usada como canal e retorna seu tipo. pos
+// é a posição da operação, usada no erro quando a expressão não é um canal
+func (c *Checker) channelType(expr parser.Expr, pos parser.Pos) *parser.ChannelType {
+	exprType := c.checkExpr(expr)
+	typeStr := StringifyType(exprType)
+	if typeStr == "error" || typeStr == "any" {
+		return nil
+	}
+
+	if ch, ok := c.resolveType(c.unwrapType(exprType)).(*parser.ChannelType); ok {
+		return ch
+	}
+
+	c.reportError(pos.Line, pos.Col, diag.ExpectedChannel, typeStr)
+	return nil
+}
+
+// checkConcurrencyBuiltin verifica as chamadas de close(ch) e waitAll()
+func (c *Checker) checkConcurrencyBuiltin(ident *parser.Identifier, e *parser.CallExpr) Type {
+	pos := ident.Pos
+	c.checkInsideFunction(ident.Name, pos)
+	switch ident.Name {
+	case "close":
+		if len(e.Args) != 1 {
+			c.reportError(pos.Line, pos.Col, diag.CloseArgs, len(e.Args))
+			break
+		}
+		c.channelType(e.Args[0], pos)
+
+	case "waitAll":
+		if len(e.Args) != 0 {
+			c.reportError(pos.Line, pos.Col, diag.WaitAllArgs, len(e.Args))
+		}
+		// Todas as tarefas terminaram: nada mais é compartilhado
+		c.sharedVars = make(map[*Symbol]sharedVar)
+	}
+
+	return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "void"}}
+}
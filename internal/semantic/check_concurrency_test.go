@@ -0,0 +1,53 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/alpha/internal/diag"
+)
+
+func TestSharedVariableAccess(t *testing.T) {
+	tests := []struct {
+		name, body string
+		want       []diag.Code
+	}{
+		{"read before waitAll", `spawn inc(&x)
+    int y = x
+    waitAll()`, []diag.Code{diag.DataRaceRead}},
+		{"read after waitAll", `spawn inc(&x)
+    waitAll()
+    int y = x`, nil},
+		{"write", `spawn inc(&x)
+    x = 2
+    waitAll()`, []diag.Code{diag.DataRaceWrite}},
+		{"read and write", `spawn inc(&x)
+    x = x + 1
+    waitAll()`, []diag.Code{diag.DataRaceRead, diag.DataRaceWrite}},
+		{"increment", `spawn inc(&x)
+    x++
+    waitAll()`, []diag.Code{diag.DataRaceWrite}},
+		{"shared twice", `spawn inc(&x)
+    spawn inc(&x)
+    waitAll()`, []diag.Code{diag.SharedTwice}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package main\nvoid function inc(int* n) {}\nvoid function run() {\n    int x = 1\n    " + tt.body + "\n}\n"
+			var got []diag.Code
+			for _, err := range check(t, src).Errors {
+				got = append(got, err.Code)
+				if err.Line == 0 {
+					t.Errorf("%s reported without a position", err.Code)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("errors %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("errors %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
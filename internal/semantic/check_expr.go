@@ -1,13 +1,22 @@
 package semantic
 
 import (
-	"fmt"
 	"strings"
 
+	"github.com/alpha/internal/diag"
 	"github.com/alpha/internal/parser"
 )
 
+// checkExpr verifica uma expressão e registra o tipo inferido na tabela de tipos
 func (c *Checker) checkExpr(expr parser.Expr) Type {
+	t := c.inferExpr(expr)
+	if expr != nil {
+		c.ExprTypes[expr] = t
+	}
+	return t
+}
+
+func (c *Checker) inferExpr(expr parser.Expr) Type {
 	switch e := expr.(type) {
 	// ... (Mantenha os casos de literais simples e Identifier) ...
 	case *parser.IntLiteral:
@@ -18,8 +27,15 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "bool"}}
 	case *parser.StringLiteral:
 		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "string"}}
+	case *parser.InterpolatedString:
+		return c.checkInterpolatedString(e)
+	case *parser.CharLiteral:
+		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "char"}}
 	case *parser.NullLiteral:
 		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "null"}}
+	case *parser.BadExpr:
+		// O erro de sintaxe já foi reportado pelo parser
+		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 
 	// Em checkExpr, caso Identifier para tipos genéricos:
 	case *parser.Identifier:
@@ -39,7 +55,12 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 
 			// Verificar se é um parâmetro genérico (como T)
 			// Isso será verificado na função checkFunctionDecl
-			c.reportError(0, 0, fmt.Sprintf("Undeclared identifier '%s'", e.Name))
+			keywords := valueKeywords
+			if e == c.stmtExpr {
+				keywords = append(statementKeywords, valueKeywords...)
+			}
+			c.reportSuggestion(e.Pos, e.Name, [][]string{c.visibleNames(isValue), c.keywordNames(keywords)},
+				diag.UndeclaredIdentifier, e.Name)
 			return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 		}
 
@@ -52,17 +73,42 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 			return sym.Type
 		}
 
+		c.checkSharedRead(e, sym)
 		return sym.Type
 
 	case *parser.UnaryExpr:
+		if e.Op == "++" || e.Op == "--" {
+			c.skipSharedRead(e.Expr)
+		}
 		valType := c.checkExpr(e.Expr)
+		if e.Op == "++" || e.Op == "--" {
+			c.checkConstAssign(e.Expr)
+			c.checkSharedWrite(e.Expr)
+		}
+		if e.Op == "~" {
+			return c.checkBitwiseNot(e, valType)
+		}
 		return valType
 
 	case *parser.BinaryExpr:
+		if compoundAssign(e.Op) {
+			c.skipSharedRead(e.Left)
+		}
 		leftType := c.checkExpr(e.Left)
 		rightType := c.checkExpr(e.Right)
 
+		if t, ok := c.checkBitwiseBinary(e, leftType, rightType); ok {
+			return t
+		}
+		if t, ok := c.checkCharBinary(e, leftType, rightType); ok {
+			return t
+		}
+
 		switch e.Op {
+		case "+=", "-=", "*=", "/=", "%=":
+			c.checkConstAssign(e.Left)
+			c.checkSharedWrite(e.Left)
+			return leftType
 		case "+":
 			// Adição ou concatenação
 			leftTypeStr := StringifyType(leftType)
@@ -86,18 +132,18 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 			if leftTypeStr == "string" || rightTypeStr == "string" {
 				// Verificar se o outro lado é compatível com string
 				if leftTypeStr != "string" && !c.isGenericType(leftType) && leftTypeStr != "any" {
-					c.reportError(0, 0, fmt.Sprintf("Cannot concatenate string with non-string type %s", leftTypeStr))
+					c.reportError(0, 0, diag.ConcatNonString, leftTypeStr)
 					return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 				}
 				if rightTypeStr != "string" && !c.isGenericType(rightType) && rightTypeStr != "any" {
-					c.reportError(0, 0, fmt.Sprintf("Cannot concatenate string with non-string type %s", rightTypeStr))
+					c.reportError(0, 0, diag.ConcatNonString, rightTypeStr)
 					return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 				}
 				return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "string"}}
 			}
 
 			// Operação não suportada
-			c.reportError(0, 0, fmt.Sprintf("Operator '+' not supported for types %s and %s", leftTypeStr, rightTypeStr))
+			c.reportError(0, 0, diag.PlusUnsupported, leftTypeStr, rightTypeStr)
 			return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 		case ">", "<", ">=", "<=", "==", "!=":
 			// Operações de comparação - retornam bool
@@ -113,7 +159,11 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 		return trueType
 
 	case *parser.AssignExpr:
+		c.skipSharedRead(e.Left)
 		leftType := c.checkExpr(e.Left)
+		c.adaptCharConstant(leftType, e.Right, c.checkExpr(e.Right))
+		c.checkConstAssign(e.Left)
+		c.checkSharedWrite(e.Left)
 		return leftType
 
 	case *parser.CallExpr:
@@ -125,14 +175,23 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 				for i := 1; i < len(e.Args); i++ {
 					c.checkExpr(e.Args[i])
 				}
+				c.checkResizable("append", e.Args[0])
 				return argType
 			}
 		}
 
+		if ident, ok := e.Callee.(*parser.Identifier); ok && (ident.Name == "close" || ident.Name == "waitAll") {
+			return c.checkConcurrencyBuiltin(ident, e)
+		}
+
 		for _, arg := range e.Args {
 			c.checkExpr(arg)
 		}
 
+		if ident, ok := e.Callee.(*parser.Identifier); ok && (ident.Name == "remove" || ident.Name == "removeIndex") && len(e.Args) > 0 {
+			c.checkResizable(ident.Name, e.Args[0])
+		}
+
 		var returnType Type
 		if ident, ok := e.Callee.(*parser.Identifier); ok {
 			sym := c.CurrentScope.Resolve(ident.Name)
@@ -140,18 +199,33 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 				switch sym.Kind {
 				case KindFunction:
 					returnType = sym.Type
+					if fn, ok := sym.Node.(*parser.FunctionDecl); ok {
+						c.checkCallArgs(functionTarget(fn), e)
+					}
 				case KindImport:
 					returnType = &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "any"}}
+				case KindStruct:
+					returnType = c.checkConstructorCall(ident.Name, e)
+				case KindBad:
+					return sym.Type
 				default:
-					c.reportError(0, 0, fmt.Sprintf("'%s' is not a function", ident.Name))
+					c.reportError(0, 0, diag.NotAFunction, ident.Name)
 					return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 				}
 			} else {
 				// Verificar se é uma função genérica chamada sem especialização
 				// Ex: hello1(30) sem generic<int>
-				c.reportError(0, 0, fmt.Sprintf("Undeclared function '%s'", ident.Name))
+				c.reportSuggestion(ident.Pos, ident.Name, [][]string{c.visibleNames(isCallable)}, diag.UndeclaredFunction, ident.Name)
 				return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 			}
+		} else if member, ok := e.Callee.(*parser.MemberExpr); ok {
+			// Chamada de método: o membro resolve para o tipo de retorno
+			returnType = c.checkExpr(member)
+			if s := c.structDeclOf(c.TypeOf(member.Object)); s != nil {
+				if method := c.findMethod(s.Name, member.Member); method != nil {
+					c.checkCallArgs(methodTarget(method), e)
+				}
+			}
 		} else {
 			// Para chamadas complexas (como generic<int> hello1(30))
 			returnType = &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "any"}}
@@ -160,6 +234,8 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 		return returnType
 
 	case *parser.StructLiteral:
+		c.checkStructLiteral(e)
+
 		// Se o literal tem um nome (ex: Message { ... }), retorna esse tipo
 		if e.Name != "" {
 			return &ParserTypeWrapper{Type: &parser.IdentifierType{Name: e.Name}}
@@ -171,6 +247,8 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 		// Trata especializações como "generic<string> Car { ... }"
 		// O Parser coloca o StructLiteral dentro do Callee
 		if structLit, ok := e.Callee.(*parser.StructLiteral); ok {
+			c.checkStructLiteral(structLit)
+
 			// Se o struct literal tiver nome (Car), retorna um tipo genérico construído
 			if structLit.Name != "" {
 				return &ParserTypeWrapper{Type: &parser.GenericType{
@@ -185,34 +263,7 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 		return calleeType
 
 	case *parser.ArrayLiteral:
-		if len(e.Elements) == 0 {
-			return &ParserTypeWrapper{Type: &parser.ArrayType{ElementType: &parser.PrimitiveType{Name: "any"}}}
-		}
-
-		// Determinar o tipo base de todos os elementos
-		var elementType parser.Type
-		for i, elem := range e.Elements {
-			elemType := c.checkExpr(elem)
-			if wrapper, ok := elemType.(*ParserTypeWrapper); ok {
-				if i == 0 {
-					elementType = wrapper.Type
-				} else {
-					// Verificar compatibilidade com o primeiro tipo
-					if !AreParserTypesCompatible(elementType, wrapper.Type) {
-						c.reportError(0, 0, fmt.Sprintf("Inconsistent array element types: %s vs %s",
-							StringifyParserType(elementType), StringifyParserType(wrapper.Type)))
-						// Usar o primeiro tipo como fallback
-						break
-					}
-				}
-			}
-		}
-
-		if elementType == nil {
-			elementType = &parser.PrimitiveType{Name: "any"}
-		}
-
-		return &ParserTypeWrapper{Type: &parser.ArrayType{ElementType: elementType}}
+		return c.checkArrayLiteral(e)
 
 	case *parser.ReferenceExpr:
 		exprType := c.checkExpr(e.Expr)
@@ -237,6 +288,10 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 			ElementType: &parser.PrimitiveType{Name: "int"},
 		}}
 
+	case *parser.NamedArg:
+		// age: 30 tem o tipo do valor; o parâmetro é verificado em checkCallArgs
+		return c.checkExpr(e.Value)
+
 	case *parser.SpreadExpr:
 		// Para ...arr3, retorna o tipo do elemento do array sendo espalhado
 		arrayType := c.checkExpr(e.Expr)
@@ -249,6 +304,11 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 			}
 		}
 
+		if typeStr := StringifyType(arrayType); typeStr != "any" && typeStr != "error" {
+			c.reportError(0, 0, diag.CannotSpread, typeStr)
+			return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
+		}
+
 		// Fallback
 		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "any"}}
 
@@ -257,7 +317,7 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 		if ident, ok := e.Callee.(*parser.Identifier); ok {
 			sym := c.CurrentScope.Resolve(ident.Name)
 			if sym == nil || sym.Kind != KindFunction {
-				c.reportError(0, 0, fmt.Sprintf("Undeclared function '%s'", ident.Name))
+				c.reportSuggestion(ident.Pos, ident.Name, [][]string{c.visibleNames(isCallable)}, diag.UndeclaredFunction, ident.Name)
 				return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 			}
 
@@ -297,6 +357,11 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 		// Verificar se o tipo de destino existe
 		c.validateTypeExists(e.Type)
 
+		// Conversões entre arrays fixos e dinâmicos
+		if arrType, ok := e.Type.(*parser.ArrayType); ok {
+			return c.checkArrayConversion(e, arrType, exprType)
+		}
+
 		// Se a expressão é de tipo genérico, permitir a conversão
 		if c.isGenericType(exprType) {
 			// Permitir conversão de tipos genéricos para string
@@ -307,6 +372,10 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 		exprTypeStr := StringifyType(exprType)
 		targetTypeStr := StringifyParserType(e.Type)
 
+		if !c.checkCharConversion(exprType, targetTypeStr) {
+			return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
+		}
+
 		// Permitir conversões comuns
 		if targetTypeStr == "string" {
 			// Permitir conversão de int, float, bool para string
@@ -326,22 +395,22 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 
 		// Verificar se o array é um tipo que pode ser indexado
 		if wrapper, ok := arrayType.(*ParserTypeWrapper); ok {
-			switch t := wrapper.Type.(type) {
+			switch t := c.resolveType(wrapper.Type).(type) {
 			case *parser.ArrayType:
 				// Verificar se o índice é um tipo inteiro
 				indexStr := StringifyType(indexType)
 				if indexStr != "int" && indexStr != "int?" && !c.isGenericType(indexType) {
-					c.reportError(0, 0, fmt.Sprintf("Array index must be integer, got %s", indexStr))
+					c.reportError(0, 0, diag.ArrayIndexType, indexStr)
 					return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 				}
+				c.checkArrayIndex(t, e.Index)
 				// Retornar o tipo do elemento do array
 				return &ParserTypeWrapper{Type: t.ElementType}
 
 			case *parser.MapType:
 				// Verificar compatibilidade do tipo da chave
 				if !AreParserTypesCompatible(t.KeyType, c.unwrapType(indexType)) {
-					c.reportError(0, 0, fmt.Sprintf("Map key type mismatch: expected %s, got %s",
-						StringifyParserType(t.KeyType), StringifyType(indexType)))
+					c.reportError(0, 0, diag.MapKeyMismatch, StringifyParserType(t.KeyType), StringifyType(indexType))
 					return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 				}
 				// Retornar o tipo do valor do mapa
@@ -349,7 +418,7 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 
 			case *parser.SetType:
 				// Sets não suportam indexação direta
-				c.reportError(0, 0, "Cannot index a set directly. Use 'has()' to check membership.")
+				c.reportError(0, 0, diag.IndexSet)
 				return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 
 			case *parser.PrimitiveType:
@@ -357,16 +426,17 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 					// Strings podem ser indexadas para obter caracteres
 					indexStr := StringifyType(indexType)
 					if indexStr != "int" && indexStr != "int?" && !c.isGenericType(indexType) {
-						c.reportError(0, 0, fmt.Sprintf("String index must be integer, got %s", indexStr))
+						c.reportError(0, 0, diag.StringIndexType, indexStr)
 						return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 					}
-					return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "string"}}
+					// Indexar uma string retorna o caractere (não o byte) na posição
+					return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "char"}}
 				}
-				c.reportError(0, 0, fmt.Sprintf("Cannot index type %s", t.Name))
+				c.reportError(0, 0, diag.CannotIndex, t.Name)
 				return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 
 			default:
-				c.reportError(0, 0, fmt.Sprintf("Cannot index type %s", StringifyParserType(wrapper.Type)))
+				c.reportError(0, 0, diag.CannotIndex, StringifyParserType(wrapper.Type))
 				return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 			}
 		}
@@ -374,9 +444,24 @@ func (c *Checker) checkExpr(expr parser.Expr) Type {
 		// Fallback para tipos não wrapped
 		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "any"}}
 
+	case *parser.MemberExpr:
+		return c.checkMemberExpr(e)
+
+	case *parser.SelfExpr:
+		return c.checkSelfExpr()
+
+	case *parser.ChannelExpr:
+		return c.checkChannelExpr(e)
+
+	case *parser.SendExpr:
+		return c.checkSendExpr(e)
+
+	case *parser.ReceiveExpr:
+		return c.checkReceiveExpr(e)
+
 	default:
 		// Caso padrão para expressões não tratadas
-		c.reportError(0, 0, fmt.Sprintf("Unhandled expression type: %T", expr))
+		c.reportError(0, 0, diag.UnhandledExpr, expr)
 		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
 	}
 
@@ -0,0 +1,221 @@
+package semantic
+
+import (
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/parser"
+)
+
+// ============================
+// MEMBROS DE STRUCTS E VISIBILIDADE
+// ============================
+
+// registerImpls registra os blocos implement antes da verificação, para que
+// métodos possam ser usados antes do bloco que os define
+func (c *Checker) registerImpls(prog *parser.Program) {
+	for _, stmt := range prog.Body {
+		if impl, ok := stmt.(*parser.ImplDecl); ok {
+			c.impls[impl.TargetName] = append(c.impls[impl.TargetName], impl)
+		}
+	}
+}
+
+// structDeclOf retorna a declaração da struct de um tipo (User, *User, Car<T>)
+func (c *Checker) structDeclOf(t Type) *parser.StructDecl {
+	pt := c.unwrapType(t)
+	if ptr, ok := pt.(*parser.PointerType); ok {
+		pt = ptr.BaseType
+	}
+
+	var name string
+	switch v := pt.(type) {
+	case *parser.IdentifierType:
+		name = v.Name
+	case *parser.GenericType:
+		name = v.Name
+	default:
+		return nil
+	}
+
+	sym := c.CurrentScope.Resolve(name)
+	if sym == nil || sym.Kind != KindStruct {
+		return nil
+	}
+	decl, _ := sym.Node.(*parser.StructDecl)
+	return decl
+}
+
+// findField procura um campo declarado na struct
+func findField(s *parser.StructDecl, name string) *parser.FieldDecl {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// findMethod procura um método em todos os blocos implement da struct
+func (c *Checker) findMethod(structName, name string) *parser.MethodDecl {
+	for _, impl := range c.impls[structName] {
+		for _, method := range impl.Methods {
+			if method.Name == name {
+				return method
+			}
+		}
+	}
+	return nil
+}
+
+// findInit retorna o construtor (init) da struct, se existir
+func (c *Checker) findInit(structName string) *parser.InitDecl {
+	for _, impl := range c.impls[structName] {
+		if impl.Init != nil {
+			return impl.Init
+		}
+	}
+	return nil
+}
+
+// canAccessPrivate indica se membros privados da struct são visíveis aqui:
+// apenas dentro de um bloco implement da própria struct
+func (c *Checker) canAccessPrivate(structName string) bool {
+	return c.currentStruct == structName
+}
+
+func (c *Checker) checkMemberExpr(e *parser.MemberExpr) Type {
+	objType := c.checkExpr(e.Object)
+
+	s := c.structDeclOf(objType)
+	if s == nil {
+		// Tipos sem declaração conhecida (any, genéricos, imports) não são verificados
+		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "any"}}
+	}
+
+	if field := findField(s, e.Member); field != nil {
+		if field.IsPrivate && !c.canAccessPrivate(s.Name) {
+			c.reportError(e.Pos.Line, e.Pos.Col, diag.PrivateFieldAccess, e.Member, s.Name)
+		}
+		return c.wrapType(c.substituteGenerics(s, objType, field.Type))
+	}
+
+	if method := c.findMethod(s.Name, e.Member); method != nil {
+		if method.IsPrivate && !c.canAccessPrivate(s.Name) {
+			c.reportError(e.Pos.Line, e.Pos.Col, diag.PrivateMethodCall, e.Member, s.Name)
+		}
+		return ToMultiValueType(method.ReturnTypes)
+	}
+
+	c.reportSuggestion(e.Pos, e.Member, [][]string{c.memberNames(s)}, diag.NoMember, s.Name, e.Member)
+	return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
+}
+
+// fieldNames lista os campos da struct acessíveis no contexto atual
+func (c *Checker) fieldNames(s *parser.StructDecl) []string {
+	names := make([]string, 0, len(s.Fields))
+	for _, field := range s.Fields {
+		if !field.IsPrivate || c.canAccessPrivate(s.Name) {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
+
+// memberNames lista os campos e métodos da struct acessíveis no contexto atual
+func (c *Checker) memberNames(s *parser.StructDecl) []string {
+	names := c.fieldNames(s)
+	for _, impl := range c.impls[s.Name] {
+		for _, method := range impl.Methods {
+			if !method.IsPrivate || c.canAccessPrivate(s.Name) {
+				names = append(names, method.Name)
+			}
+		}
+	}
+	return names
+}
+
+// substituteGenerics troca os parâmetros genéricos de um campo pelos argumentos
+// do tipo do objeto (o campo T motor de Car<string> tem tipo string)
+func (c *Checker) substituteGenerics(s *parser.StructDecl, objType Type, fieldType parser.Type) parser.Type {
+	gen, ok := c.unwrapType(objType).(*parser.GenericType)
+	if !ok {
+		return fieldType
+	}
+
+	var name string
+	switch v := fieldType.(type) {
+	case *parser.IdentifierType:
+		name = v.Name
+	case *parser.GenericParam:
+		name = v.Name
+	default:
+		return fieldType
+	}
+
+	for i, param := range s.Generics {
+		if param.Name == name && i < len(gen.TypeArgs) {
+			return gen.TypeArgs[i]
+		}
+	}
+	return fieldType
+}
+
+// checkStructLiteral verifica os campos de um literal de struct nomeado
+func (c *Checker) checkStructLiteral(e *parser.StructLiteral) {
+	var s *parser.StructDecl
+	if e.Name != "" {
+		if sym := c.CurrentScope.Resolve(e.Name); sym != nil && sym.Kind == KindStruct {
+			s, _ = sym.Node.(*parser.StructDecl)
+		}
+	}
+
+	for _, f := range e.Fields {
+		c.checkExpr(f.Value)
+		if s == nil {
+			continue
+		}
+
+		field := findField(s, f.Name)
+		if field == nil {
+			c.reportSuggestion(f.Pos, f.Name, [][]string{c.fieldNames(s)}, diag.NoField, s.Name, f.Name)
+			continue
+		}
+		if field.IsPrivate && !c.canAccessPrivate(s.Name) {
+			c.reportError(f.Pos.Line, f.Pos.Col, diag.PrivateFieldInit, f.Name, s.Name)
+		}
+	}
+}
+
+// checkConstructorCall verifica User(args...), que chama o init da struct
+func (c *Checker) checkConstructorCall(name string, e *parser.CallExpr) Type {
+	structType := &ParserTypeWrapper{Type: &parser.IdentifierType{Name: name}}
+
+	init := c.findInit(name)
+	if init == nil {
+		c.reportError(0, 0, diag.NoInit, name)
+		return structType
+	}
+
+	c.checkCallArgs(constructorTarget(name, init), e)
+	return structType
+}
+
+func (c *Checker) checkSelfExpr() Type {
+	sym := c.CurrentScope.Resolve("self")
+	if sym == nil {
+		c.reportError(0, 0, diag.SelfOutsideImpl)
+		return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}}
+	}
+	return sym.Type
+}
+
+// checkImplMembers reporta métodos duplicados ou com o nome de um campo
+func (c *Checker) checkImplMembers(s *parser.StructDecl, impl *parser.ImplDecl) {
+	for _, method := range impl.Methods {
+		if findField(s, method.Name) != nil {
+			c.reportError(method.Pos.Line, method.Pos.Col, diag.MethodFieldConflict, method.Name, s.Name)
+		}
+		if first := c.findMethod(s.Name, method.Name); first != nil && first != method {
+			c.reportError(method.Pos.Line, method.Pos.Col, diag.MethodRedeclared, method.Name, s.Name)
+		}
+	}
+}
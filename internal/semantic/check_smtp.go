@@ -1,9 +1,9 @@
 package semantic
 
 import (
-	"fmt"
 	"strings"
 
+	"github.com/alpha/internal/diag"
 	"github.com/alpha/internal/parser"
 )
 
@@ -37,10 +37,24 @@ func (c *Checker) checkStmt(stmt parser.Stmt) {
 	case *parser.BlockStmt:
 		c.checkBlock(s.Body)
 
+	case *parser.BadStmt:
+		// O erro de sintaxe já foi reportado pelo parser
+
+	case *parser.BadDecl:
+		// O nome continua declarado para que seus usos não virem erros em cascata
+		if s.Name != "" {
+			c.CurrentScope.Define(s.Name, &Symbol{
+				Name: s.Name,
+				Kind: KindBad,
+				Type: &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "error"}},
+				Node: s,
+			})
+		}
+
 	case *parser.IfStmt:
 		condType := c.checkExpr(s.Cond)
 		if !c.isConditionableType(condType) {
-			c.reportError(0, 0, fmt.Sprintf("Condition in 'if' must be boolean or nullable, got %s", StringifyType(condType)))
+			c.reportError(0, 0, diag.IfCondition, StringifyType(condType))
 		}
 		c.checkBlockScope(s.Then)
 		if s.Else != nil {
@@ -50,22 +64,24 @@ func (c *Checker) checkStmt(stmt parser.Stmt) {
 	case *parser.WhileStmt:
 		condType := c.checkExpr(s.Cond)
 		if !c.isConditionableType(condType) {
-			c.reportError(0, 0, "Condition in 'while' must be boolean or nullable")
+			c.reportError(0, 0, diag.WhileCondition)
 		}
 		prevLoop := c.inLoop
 		c.inLoop = true
 		c.checkBlockScope(s.Body)
 		c.inLoop = prevLoop
+		c.checkLoopSharing()
 
 	case *parser.DoWhileStmt:
 		prevLoop := c.inLoop
 		c.inLoop = true
 		c.checkBlockScope(s.Body)
 		c.inLoop = prevLoop
+		c.checkLoopSharing()
 
 		condType := c.checkExpr(s.Cond)
 		if !c.isBooleanType(condType) {
-			c.reportError(0, 0, "Condition in 'do-while' must be boolean")
+			c.reportError(0, 0, diag.DoWhileCondition)
 		}
 
 	case *parser.ForStmt:
@@ -76,7 +92,7 @@ func (c *Checker) checkStmt(stmt parser.Stmt) {
 		if s.Cond != nil {
 			condType := c.checkExpr(s.Cond)
 			if !c.isBooleanType(condType) {
-				c.reportError(0, 0, "Condition in 'for' must be boolean")
+				c.reportError(0, 0, diag.ForCondition)
 			}
 		}
 		if s.Post != nil {
@@ -89,15 +105,22 @@ func (c *Checker) checkStmt(stmt parser.Stmt) {
 			c.checkStmt(bodyStmt)
 		}
 		c.inLoop = prevLoop
+		c.checkLoopSharing()
 
 		c.exitScope()
 
 	case *parser.SwitchStmt:
 		c.checkSwitchStmt(s)
 
+	case *parser.SpawnStmt:
+		c.checkSpawnStmt(s)
+
+	case *parser.SelectStmt:
+		c.checkSelectStmt(s)
+
 	case *parser.ReturnStmt:
 		if c.currentFuncReturnType == nil {
-			c.reportError(0, 0, "Return statement outside of function")
+			c.reportError(0, 0, diag.ReturnOutsideFunc)
 			return
 		}
 
@@ -106,7 +129,7 @@ func (c *Checker) checkStmt(stmt parser.Stmt) {
 			// Verificar se a função é void
 			if c.currentFuncReturnType != nil &&
 				StringifyType(c.currentFuncReturnType) != "void" {
-				c.reportError(0, 0, "Non-void function must return a value")
+				c.reportError(0, 0, diag.MissingReturnValue)
 			}
 			return
 		}
@@ -117,8 +140,7 @@ func (c *Checker) checkStmt(stmt parser.Stmt) {
 
 			// Verificar quantidade de valores
 			if len(s.Values) != len(multiRet.Types) {
-				c.reportError(0, 0, fmt.Sprintf("Function returns %d values, but return statement has %d",
-					len(multiRet.Types), len(s.Values)))
+				c.reportError(0, 0, diag.ReturnCountMismatch, len(multiRet.Types), len(s.Values))
 				return
 			}
 
@@ -128,36 +150,44 @@ func (c *Checker) checkStmt(stmt parser.Stmt) {
 				expectedType := multiRet.Types[i]
 
 				if !AreTypesCompatible(expectedType, valType) {
-					c.reportError(0, 0, fmt.Sprintf("Type mismatch in return value %d. Expected %s, got %s",
-						i+1, StringifyType(expectedType), StringifyType(valType)))
+					c.reportError(0, 0, diag.ReturnValueMismatch,
+						i+1, StringifyType(expectedType), StringifyType(valType))
 				}
 			}
 		} else {
 			// Função retorna único valor
 			if len(s.Values) > 1 {
-				c.reportError(0, 0, "Function returns single value, but return statement has multiple values")
+				c.reportError(0, 0, diag.ReturnSingleValue)
 				return
 			}
 
 			valType := c.checkExpr(s.Values[0])
+			valType = c.adaptArrayLiteral(c.currentFuncReturnType, s.Values[0], valType, diag.T("the return type"))
+			valType = c.adaptCharConstant(c.currentFuncReturnType, s.Values[0], valType)
 			if !AreTypesCompatible(c.currentFuncReturnType, valType) {
-				c.reportError(0, 0, fmt.Sprintf("Type mismatch in return value. Expected %s, got %s",
-					StringifyType(c.currentFuncReturnType), StringifyType(valType)))
+				c.reportError(0, 0, diag.ReturnTypeMismatch,
+					StringifyType(c.currentFuncReturnType), StringifyType(valType))
 			}
 		}
 
 	case *parser.BreakStmt:
-		if !c.inLoop {
-			c.reportError(0, 0, "'break' is only allowed inside loops")
+		if !c.inLoop && !c.inSwitch {
+			c.reportError(0, 0, diag.BreakOutside)
 		}
 
+	case *parser.FallthroughStmt:
+		// O fallthrough válido (último statement de um caso) é tratado em checkSwitchStmt
+		c.reportError(s.Pos.Line, s.Pos.Col, diag.FallthroughNotLast)
+
 	case *parser.ContinueStmt:
 		if !c.inLoop {
-			c.reportError(0, 0, "'continue' is only allowed inside loops")
+			c.reportError(0, 0, diag.ContinueOutside)
 		}
 
 	case *parser.ExprStmt:
+		c.stmtExpr = s.Expr
 		c.checkExpr(s.Expr)
+		c.stmtExpr = nil
 
 	case *parser.PackageDecl:
 		// Nada a verificar para declaração de pacote
@@ -181,33 +211,44 @@ func (c *Checker) checkConstDecl(decl *parser.ConstDecl) {
 		Node: decl,
 	}
 
+	if StringifyType(initType) != "error" {
+		if sym.Value = c.constValueFor(decl.Name, decl.Init); sym.Value != nil {
+			sym.Type = sym.Value.Type()
+		}
+	}
+
 	if !c.CurrentScope.Define(decl.Name, sym) {
-		c.reportError(0, 0, fmt.Sprintf("Constant '%s' redeclared in this scope", decl.Name))
+		c.reportError(0, 0, diag.ConstRedeclared, decl.Name)
 	}
 }
 
 // check_smtp.go - Modificar a função checkVarDecl
 
 func (c *Checker) checkVarDecl(decl *parser.VarDecl) {
+	if decl.Type != nil {
+		c.validateTypeExists(decl.Type)
+	}
+
 	var initType Type
 	if decl.Init != nil {
 		initType = c.checkExpr(decl.Init)
 
-		// Se o tipo do inicializador for "error", não prosseguir
-		if StringifyType(initType) == "error" {
-			return
-		}
-
-		if decl.Type == nil {
+		switch {
+		case StringifyType(initType) == "error":
+			// O erro já foi reportado; a variável ainda é declarada (com o tipo
+			// "error" se ele seria inferido) para não gerar erros em cascata
+		case decl.Type == nil:
 			// Inferência de tipo
 			decl.Type = c.unwrapType(initType)
-		} else {
+		default:
 			// Resolver o tipo declarado (pode ser um alias como "Number")
 			resolvedDeclType := c.resolveType(decl.Type)
 			declType := c.wrapType(resolvedDeclType)
+			initType = c.adaptArrayLiteral(declType, decl.Init, initType, diag.T("'%s'", decl.Name))
+			initType = c.adaptCharConstant(declType, decl.Init, initType)
 			if !c.areTypesCompatible(declType, initType) {
-				c.reportError(0, 0, fmt.Sprintf("Cannot assign type %s to variable '%s' of type %s",
-					StringifyType(initType), decl.Name, StringifyType(declType)))
+				c.reportError(0, 0, diag.AssignMismatch,
+					StringifyType(initType), decl.Name, StringifyType(declType), c.arrayConversionHint(declType, initType))
 			}
 		}
 	}
@@ -232,7 +273,7 @@ func (c *Checker) checkVarDecl(decl *parser.VarDecl) {
 	}
 
 	if !c.CurrentScope.Define(decl.Name, sym) {
-		c.reportError(0, 0, fmt.Sprintf("Variable '%s' already declared in this scope", decl.Name))
+		c.reportError(0, 0, diag.VarRedeclared, decl.Name)
 	}
 }
 
@@ -240,13 +281,13 @@ func (c *Checker) checkVarDecl(decl *parser.VarDecl) {
 func (c *Checker) checkMultiVarDecl(decl *parser.MultiVarDecl) {
 	// Verificar inicializador
 	if decl.Init == nil {
-		c.reportError(0, 0, "Multiple variables declaration must have initializer")
+		c.reportError(0, 0, diag.MultiVarInit)
 		return
 	}
 
 	initType := c.checkExpr(decl.Init)
 	if initType == nil {
-		c.reportError(0, 0, "Invalid initializer in multi-variable declaration")
+		c.reportError(0, 0, diag.InvalidMultiVarInit)
 		return
 	}
 
@@ -264,8 +305,7 @@ func (c *Checker) checkMultiVarDecl(decl *parser.MultiVarDecl) {
 
 	// Verificar compatibilidade de quantidade
 	if len(decl.Names) != len(valueTypes) {
-		c.reportError(0, 0, fmt.Sprintf("Mismatch in variable count: declared %d, but initializer provides %d values",
-			len(decl.Names), len(valueTypes)))
+		c.reportError(0, 0, diag.VarCountMismatch, len(decl.Names), len(valueTypes))
 		return
 	}
 
@@ -286,7 +326,7 @@ func (c *Checker) checkMultiVarDecl(decl *parser.MultiVarDecl) {
 		}
 
 		if !c.CurrentScope.Define(name, sym) {
-			c.reportError(0, 0, fmt.Sprintf("Variable '%s' already declared in this scope", name))
+			c.reportError(0, 0, diag.VarRedeclared, name)
 		}
 	}
 }
@@ -295,13 +335,13 @@ func (c *Checker) checkMultiVarDecl(decl *parser.MultiVarDecl) {
 func (c *Checker) checkMultiConstDecl(decl *parser.MultiConstDecl) {
 	// Similar ao checkMultiVarDecl, mas para constantes
 	if decl.Init == nil {
-		c.reportError(0, 0, "Multiple constants declaration must have initializer")
+		c.reportError(0, 0, diag.MultiConstInit)
 		return
 	}
 
 	initType := c.checkExpr(decl.Init)
 	if initType == nil {
-		c.reportError(0, 0, "Invalid initializer in multi-constant declaration")
+		c.reportError(0, 0, diag.InvalidMultiConstInit)
 		return
 	}
 
@@ -316,21 +356,32 @@ func (c *Checker) checkMultiConstDecl(decl *parser.MultiConstDecl) {
 	}
 
 	if len(decl.Names) != len(valueTypes) {
-		c.reportError(0, 0, fmt.Sprintf("Mismatch in constant count: declared %d, but initializer provides %d values",
-			len(decl.Names), len(valueTypes)))
+		c.reportError(0, 0, diag.ConstCountMismatch, len(decl.Names), len(valueTypes))
 		return
 	}
 
+	// Todas as constantes recebem o mesmo valor; retornos de função não são constantes
+	var value *ConstValue
+	if _, multi := initType.(*MultiValueType); multi {
+		c.reportError(0, 0, diag.ConstsNotConstant, strings.Join(decl.Names, ", "))
+	} else if StringifyType(initType) != "error" {
+		value = c.constValueFor(decl.Names[0], decl.Init)
+	}
+
 	for i, name := range decl.Names {
 		sym := &Symbol{
-			Name: name,
-			Kind: KindConst,
-			Type: valueTypes[i],
-			Node: decl,
+			Name:  name,
+			Kind:  KindConst,
+			Type:  valueTypes[i],
+			Node:  decl,
+			Value: value,
+		}
+		if value != nil {
+			sym.Type = value.Type()
 		}
 
 		if !c.CurrentScope.Define(name, sym) {
-			c.reportError(0, 0, fmt.Sprintf("Constant '%s' already declared in this scope", name))
+			c.reportError(0, 0, diag.ConstAlreadyDeclared, name)
 		}
 	}
 }
@@ -341,12 +392,14 @@ func (c *Checker) checkFunctionDecl(fn *parser.FunctionDecl) {
 
 	sym := &Symbol{Name: fn.Name, Kind: KindFunction, Type: returnType, Node: fn}
 	if !c.CurrentScope.Define(fn.Name, sym) {
-		c.reportError(0, 0, fmt.Sprintf("Function '%s' redeclared", fn.Name))
+		c.reportError(0, 0, diag.FuncRedeclared, fn.Name)
 	}
 
 	c.enterScope()
 	prevReturn := c.currentFuncReturnType
 	c.currentFuncReturnType = returnType
+	prevShared := c.sharedVars
+	c.sharedVars = make(map[*Symbol]sharedVar)
 
 	// Generics - registrar parâmetros genéricos como tipos
 	if fn.Generics != nil {
@@ -367,10 +420,11 @@ func (c *Checker) checkFunctionDecl(fn *parser.FunctionDecl) {
 	}
 
 	// Params
+	c.checkParamDefaults(fn.Params)
 	for _, param := range fn.Params {
 		// Validar se o tipo do parâmetro existe
 		c.validateTypeExists(param.Type)
-		paramType := c.wrapType(param.Type)
+		paramType := c.wrapType(param.ValueType())
 		c.CurrentScope.Define(param.Name, &Symbol{Name: param.Name, Kind: KindVar, Type: paramType})
 	}
 
@@ -379,6 +433,7 @@ func (c *Checker) checkFunctionDecl(fn *parser.FunctionDecl) {
 	}
 
 	c.currentFuncReturnType = prevReturn
+	c.sharedVars = prevShared
 	c.exitScope()
 }
 
@@ -391,7 +446,7 @@ func (c *Checker) checkStructDecl(s *parser.StructDecl) {
 	}
 	// Define o struct no escopo atual (geralmente global)
 	if !c.CurrentScope.Define(s.Name, sym) {
-		c.reportError(0, 0, fmt.Sprintf("Struct '%s' already defined", s.Name))
+		c.reportError(0, 0, diag.StructRedeclared, s.Name)
 	}
 
 	// Cria um escopo temporário para validar os campos
@@ -410,7 +465,7 @@ func (c *Checker) checkStructDecl(s *parser.StructDecl) {
 	for _, field := range s.Fields {
 		c.validateTypeExists(field.Type)
 		if fieldNames[field.Name] {
-			c.reportError(0, 0, fmt.Sprintf("Duplicate field '%s' in struct '%s'", field.Name, s.Name))
+			c.reportError(0, 0, diag.DuplicateField, field.Name, s.Name)
 		}
 		fieldNames[field.Name] = true
 	}
@@ -432,7 +487,7 @@ func (c *Checker) checkTypeDecl(s *parser.TypeDecl) {
 	}
 
 	if !c.CurrentScope.Define(s.Name, typeSym) {
-		c.reportError(0, 0, fmt.Sprintf("Type '%s' already defined", s.Name))
+		c.reportError(0, 0, diag.TypeRedeclared, s.Name)
 	}
 }
 
@@ -440,17 +495,51 @@ func (c *Checker) checkImplDecl(s *parser.ImplDecl) {
 	// Verificar se o Target existe
 	sym := c.CurrentScope.Resolve(s.TargetName)
 	if sym == nil || sym.Kind != KindStruct {
-		c.reportError(0, 0, fmt.Sprintf("Cannot implement methods for unknown struct '%s'", s.TargetName))
+		c.reportError(0, 0, diag.ImplUnknownStruct, s.TargetName)
 		return
 	}
 
-	// Simplificação: Checar os métodos como funções normais,
-	// mas injetando 'self' no escopo se necessário.
+	structDecl, _ := sym.Node.(*parser.StructDecl)
+	if structDecl != nil {
+		c.checkImplMembers(structDecl, s)
+	}
+
+	// Membros privados da struct são visíveis dentro do bloco
+	prevStruct := c.currentStruct
+	c.currentStruct = s.TargetName
+	defer func() { c.currentStruct = prevStruct }()
+
+	// Os métodos são checados como funções normais, com 'self' no escopo
+	selfType := &ParserTypeWrapper{Type: &parser.IdentifierType{Name: s.TargetName}}
+	if s.Init != nil {
+		c.checkInitDecl(s.Init, selfType)
+	}
 	for _, method := range s.Methods {
-		c.checkMethodDecl(method, sym.Type)
+		c.checkMethodDecl(method, selfType)
 	}
 }
 
+func (c *Checker) checkInitDecl(init *parser.InitDecl, structType Type) {
+	c.enterScope()
+	c.CurrentScope.Define("self", &Symbol{Name: "self", Kind: KindVar, Type: structType})
+
+	prevReturn := c.currentFuncReturnType
+	c.currentFuncReturnType = &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "void"}}
+
+	c.checkParamDefaults(init.Params)
+	for _, param := range init.Params {
+		c.validateTypeExists(param.Type)
+		c.CurrentScope.Define(param.Name, &Symbol{Name: param.Name, Kind: KindVar, Type: c.wrapType(param.ValueType())})
+	}
+
+	for _, stmt := range init.Body {
+		c.checkStmt(stmt)
+	}
+
+	c.currentFuncReturnType = prevReturn
+	c.exitScope()
+}
+
 func (c *Checker) checkMethodDecl(m *parser.MethodDecl, structType Type) {
 	c.enterScope()
 
@@ -468,9 +557,10 @@ func (c *Checker) checkMethodDecl(m *parser.MethodDecl, structType Type) {
 	}
 
 	// Params
+	c.checkParamDefaults(m.Params)
 	for _, param := range m.Params {
 		c.validateTypeExists(param.Type)
-		paramType := c.wrapType(param.Type)
+		paramType := c.wrapType(param.ValueType())
 		c.CurrentScope.Define(param.Name, &Symbol{Name: param.Name, Kind: KindVar, Type: paramType})
 	}
 
@@ -482,21 +572,6 @@ func (c *Checker) checkMethodDecl(m *parser.MethodDecl, structType Type) {
 	c.exitScope()
 }
 
-func (c *Checker) checkSwitchStmt(s *parser.SwitchStmt) {
-	exprType := c.checkExpr(s.Expr)
-
-	for _, clause := range s.Cases {
-		if clause.Value != nil {
-			caseType := c.checkExpr(clause.Value)
-			if !c.areTypesCompatible(exprType, caseType) {
-				c.reportError(0, 0, fmt.Sprintf("Case type mismatch. Switch on %s, but case is %s",
-					StringifyType(exprType), StringifyType(caseType)))
-			}
-		}
-		c.checkBlockScope(clause.Body)
-	}
-}
-
 // Helpers
 func (c *Checker) checkBlockScope(stmts []parser.Stmt) {
 	c.enterScope()
@@ -519,7 +594,7 @@ func (c *Checker) checkImportDecl(imp *parser.ImportDecl) {
 			}
 			sym := &Symbol{Name: symbolName, Kind: KindImport, Node: imp}
 			if !c.CurrentScope.Define(symbolName, sym) {
-				c.reportError(0, 0, fmt.Sprintf("Import '%s' already declared", symbolName))
+				c.reportError(0, 0, diag.ImportRedeclared, symbolName)
 			}
 		}
 	} else {
@@ -534,7 +609,7 @@ func (c *Checker) checkImportDecl(imp *parser.ImportDecl) {
 		}
 
 		if !c.CurrentScope.Define(moduleName, sym) {
-			c.reportError(0, 0, fmt.Sprintf("Module '%s' already declared", moduleName))
+			c.reportError(0, 0, diag.ModuleRedeclared, moduleName)
 		}
 	}
 }
@@ -543,7 +618,7 @@ func (c *Checker) checkExportDecl(exp *parser.ExportDecl) {
 	for _, spec := range exp.Exports {
 		sym := c.CurrentScope.Resolve(spec.Name)
 		if sym == nil {
-			c.reportError(0, 0, fmt.Sprintf("Cannot export undeclared symbol '%s'", spec.Name))
+			c.reportSuggestion(parser.Pos{}, spec.Name, [][]string{c.visibleNames(isValue)}, diag.ExportUndeclared, spec.Name)
 		}
 	}
 }
@@ -552,10 +627,20 @@ func (c *Checker) validateTypeExists(t parser.Type) {
 	switch v := t.(type) {
 	case *parser.IdentifierType:
 		if c.CurrentScope.Resolve(v.Name) == nil {
-			c.reportError(0, 0, fmt.Sprintf("Unknown type '%s'", v.Name))
+			c.reportSuggestion(v.Pos, v.Name, [][]string{c.visibleNames(isTypeName)}, diag.UnknownType, v.Name)
 		}
 	case *parser.ArrayType:
 		c.validateTypeExists(v.ElementType)
+		if v.Size != nil {
+			if size, ok := c.constInt(v.Size); !ok {
+				c.reportError(0, 0, diag.ArraySizeNotConst)
+			} else if size < 0 {
+				c.reportError(0, 0, diag.ArraySizeNegative, size)
+			} else {
+				// Tamanhos calculados (int[N * 2]) viram literais para o resto do pipeline
+				v.Size = &parser.IntLiteral{Value: size}
+			}
+		}
 	case *parser.PrimitiveType:
 		// Tipos primitivos sempre existem
 		return
@@ -566,6 +651,8 @@ func (c *Checker) validateTypeExists(t parser.Type) {
 		c.validateTypeExists(v.ValueType)
 	case *parser.PointerType:
 		c.validateTypeExists(v.BaseType)
+	case *parser.ChannelType:
+		c.validateTypeExists(v.ElementType)
 	case *parser.UnionType:
 		for _, typ := range v.Types {
 			c.validateTypeExists(typ)
@@ -0,0 +1,54 @@
+package semantic
+
+import (
+	"strings"
+
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/parser"
+)
+
+// ============================
+// STRINGS INTERPOLADAS
+// ============================
+
+// interpolableTypes são os tipos que podem aparecer em ${...}; o valor é
+// formatado como na conversão string(x)
+var interpolableTypes = map[string]bool{
+	"string": true, "int": true, "float": true, "bool": true, "char": true, "byte": true,
+}
+
+// checkInterpolatedString verifica cada expressão embutida na string. Quando
+// todas são constantes, o valor da string é calculado em tempo de compilação
+func (c *Checker) checkInterpolatedString(e *parser.InterpolatedString) Type {
+	for _, part := range e.Parts {
+		partType := c.checkExpr(part)
+		if c.isLooseType(partType) {
+			continue
+		}
+		if typeStr := StringifyType(partType); !interpolableTypes[typeStr] {
+			c.reportError(e.Pos.Line, e.Pos.Col, diag.CannotInterpolate, typeStr)
+		}
+	}
+
+	if val, err := c.evalConst(e); err == nil {
+		c.ConstValues[e] = val
+	}
+	return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "string"}}
+}
+
+// evalInterpolatedString concatena as partes constantes de uma string interpolada
+func (c *Checker) evalInterpolatedString(e *parser.InterpolatedString) (*ConstValue, error) {
+	var sb strings.Builder
+	for _, part := range e.Parts {
+		val, err := c.evalConst(part)
+		if err != nil {
+			return nil, err
+		}
+		str, err := ConvertConst(val, "string")
+		if err != nil {
+			return nil, err
+		}
+		sb.WriteString(str.Str)
+	}
+	return &ConstValue{Kind: ConstString, Str: sb.String()}, nil
+}
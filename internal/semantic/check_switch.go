@@ -0,0 +1,175 @@
+package semantic
+
+import (
+	"strconv"
+
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/parser"
+)
+
+// ============================
+// SWITCH
+// ============================
+
+func (c *Checker) checkSwitchStmt(s *parser.SwitchStmt) {
+	var exprType Type
+	if s.Expr != nil {
+		exprType = c.checkExpr(s.Expr)
+	}
+
+	// Identificadores de structs e aliases em um caso são tipos, não valores
+	for _, clause := range s.Cases {
+		c.classifyTypeCases(clause)
+	}
+
+	typeSwitch := s.IsTypeSwitch()
+	switch {
+	case typeSwitch && s.Expr == nil:
+		c.reportError(s.Pos.Line, s.Pos.Col, diag.SwitchNoValue)
+	case typeSwitch:
+		c.checkTypeSwitchSubject(s.Pos, exprType)
+	}
+
+	seenValues := make(map[string]bool)
+	seenTypes := make(map[string]bool)
+	hasDefault := false
+
+	for i, clause := range s.Cases {
+		if clause.IsDefault() {
+			if hasDefault {
+				c.reportError(clause.Pos.Line, clause.Pos.Col, diag.DuplicateDefault)
+			}
+			hasDefault = true
+		}
+
+		if typeSwitch && len(clause.Values) > 0 {
+			c.reportError(clause.Pos.Line, clause.Pos.Col, diag.MixedSwitchCases)
+		}
+
+		for _, value := range clause.Values {
+			c.checkCaseValue(s, clause.Pos, exprType, value, seenValues)
+		}
+		for _, typ := range clause.Types {
+			c.checkCaseType(clause.Pos, exprType, typ, seenTypes)
+		}
+
+		c.checkCaseBody(clause, typeSwitch, i == len(s.Cases)-1)
+	}
+}
+
+// classifyTypeCases move para Types os valores de um caso que nomeiam tipos
+// (case User:), já que o parser não distingue um tipo de uma variável
+func (c *Checker) classifyTypeCases(clause *parser.CaseClause) {
+	values := clause.Values[:0]
+	for _, value := range clause.Values {
+		if ident, ok := value.(*parser.Identifier); ok {
+			sym := c.CurrentScope.Resolve(ident.Name)
+			if sym != nil && (sym.Kind == KindStruct || sym.Kind == KindTypeAlias) {
+				clause.Types = append(clause.Types, &parser.IdentifierType{Name: ident.Name})
+				continue
+			}
+		}
+		values = append(values, value)
+	}
+	clause.Values = values
+}
+
+// checkTypeSwitchSubject exige um valor cujo tipo concreto só é conhecido em
+// tempo de execução: any, union types ou parâmetros genéricos
+func (c *Checker) checkTypeSwitchSubject(pos parser.Pos, exprType Type) {
+	typeStr := StringifyType(exprType)
+	if typeStr == "any" || typeStr == "error" || c.isGenericType(exprType) {
+		return
+	}
+	if _, ok := c.resolveType(c.unwrapType(exprType)).(*parser.UnionType); ok {
+		return
+	}
+	c.reportError(pos.Line, pos.Col, diag.TypeSwitchSubject, typeStr)
+}
+
+// checkCaseType verifica um tipo de um caso; pos é a posição do caso
+func (c *Checker) checkCaseType(pos parser.Pos, exprType Type, typ parser.Type, seen map[string]bool) {
+	c.validateTypeExists(typ)
+
+	key := StringifyParserType(c.resolveType(typ))
+	if seen[key] {
+		c.reportError(pos.Line, pos.Col, diag.DuplicateTypeCase, key)
+	}
+	seen[key] = true
+
+	// Em um union type, o caso precisa ser um dos tipos possíveis
+	union, ok := c.resolveType(c.unwrapType(exprType)).(*parser.UnionType)
+	if !ok {
+		return
+	}
+	for _, member := range union.Types {
+		if AreParserTypesCompatible(member, c.resolveType(typ)) {
+			return
+		}
+	}
+	c.reportError(pos.Line, pos.Col, diag.TypeNotInUnion, key, StringifyParserType(union))
+}
+
+// checkCaseValue verifica um valor de um caso; pos é a posição do caso
+func (c *Checker) checkCaseValue(s *parser.SwitchStmt, pos parser.Pos, exprType Type, value parser.Expr, seen map[string]bool) {
+	caseType := c.checkExpr(value)
+	if StringifyType(caseType) == "error" {
+		return
+	}
+
+	if s.Expr == nil {
+		// switch sem condição: cada caso é uma condição
+		if typeStr := StringifyType(caseType); typeStr != "bool" && typeStr != "any" {
+			c.reportError(pos.Line, pos.Col, diag.SwitchCaseNotBool, typeStr)
+		}
+		return
+	}
+
+	if StringifyType(exprType) != "error" && !c.areTypesCompatible(exprType, caseType) {
+		c.reportError(pos.Line, pos.Col, diag.CaseTypeMismatch, StringifyType(exprType), StringifyType(caseType))
+		return
+	}
+
+	// Casos constantes repetidos nunca seriam alcançados
+	val, err := c.evalConst(value)
+	if err != nil {
+		return
+	}
+	key := val.TypeName() + ":" + val.String()
+	if seen[key] {
+		c.reportError(pos.Line, pos.Col, diag.DuplicateCase, constLiteral(val))
+	}
+	seen[key] = true
+}
+
+// checkCaseBody verifica o corpo de um caso e o seu fallthrough final
+func (c *Checker) checkCaseBody(clause *parser.CaseClause, typeSwitch, last bool) {
+	body := clause.Body
+	if clause.Fallthrough() {
+		ft := body[len(body)-1].(*parser.FallthroughStmt)
+		body = body[:len(body)-1]
+
+		switch {
+		case typeSwitch:
+			c.reportError(ft.Pos.Line, ft.Pos.Col, diag.FallthroughTypeSwitch)
+		case last:
+			c.reportError(ft.Pos.Line, ft.Pos.Col, diag.FallthroughFinal)
+		}
+	}
+
+	prevSwitch := c.inSwitch
+	c.inSwitch = true
+	c.checkBlockScope(body)
+	c.inSwitch = prevSwitch
+}
+
+// constLiteral formata um valor constante como apareceria no código
+func constLiteral(v *ConstValue) string {
+	switch v.Kind {
+	case ConstString:
+		return strconv.Quote(v.Str)
+	case ConstChar:
+		return strconv.QuoteRune(rune(v.Int))
+	}
+	return v.String()
+}
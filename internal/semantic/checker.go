@@ -1,16 +1,46 @@
 package semantic
 
 import (
+	"strings"
+
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/lexer"
 	"github.com/alpha/internal/parser"
 )
 
 type Checker struct {
 	CurrentScope *Scope
 	Errors       []SemanticError
+	ExprTypes    map[parser.Expr]Type        // Tipo de cada expressão verificada
+	ConstValues  map[parser.Expr]*ConstValue // Valor dos inicializadores de constantes
+
+	// Chamadas a funções variádicas -> índice do primeiro argumento variádico
+	VariadicCalls map[*parser.CallExpr]int
+
+	// Chamadas com argumentos nomeados ou omitidos -> argumento de cada
+	// parâmetro fixo, na ordem da declaração (valores padrão preenchidos)
+	CallArgs map[*parser.CallExpr][]parser.Expr
 
 	// Contexto atual
 	currentFuncReturnType Type
 	inLoop                bool
+	inSwitch              bool
+	currentStruct         string // Struct do bloco implement sendo verificado
+
+	// Blocos implement de cada struct, registrados antes da verificação
+	impls map[string][]*parser.ImplDecl
+
+	// Variáveis compartilhadas com tarefas disparadas via spawn e ainda não
+	// aguardadas com waitAll()
+	sharedVars map[*Symbol]sharedVar
+	// Acessos a variáveis compartilhadas que não contam como leitura
+	sharedAccess map[*parser.Identifier]bool
+
+	// Idioma das palavras-chave do programa, usado nas mensagens de erro
+	lang lexer.Language
+
+	// Expressão que é um statement inteiro, enquanto ela é verificada
+	stmtExpr parser.Expr
 }
 
 // checker.go - função NewChecker()
@@ -22,6 +52,8 @@ func NewChecker() *Checker {
 	global.Define("float", &Symbol{Name: "float", Kind: KindTypeAlias, Type: &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "float"}}})
 	global.Define("string", &Symbol{Name: "string", Kind: KindTypeAlias, Type: &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "string"}}})
 	global.Define("bool", &Symbol{Name: "bool", Kind: KindTypeAlias, Type: &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "bool"}}})
+	global.Define("char", &Symbol{Name: "char", Kind: KindTypeAlias, Type: &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "char"}}})
+	global.Define("byte", &Symbol{Name: "byte", Kind: KindTypeAlias, Type: &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "byte"}}})
 	global.Define("void", &Symbol{Name: "void", Kind: KindTypeAlias, Type: &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "void"}}})
 	global.Define("any", &Symbol{Name: "any", Kind: KindTypeAlias, Type: &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "any"}}})
 
@@ -46,27 +78,177 @@ func NewChecker() *Checker {
 	global.Define("has", &Symbol{Name: "has", Kind: KindFunction, Type: &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "bool"}}})
 	global.Define("add", &Symbol{Name: "add", Kind: KindFunction, Type: &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "void"}}})
 
+	// Funções de concorrência
+	global.Define("close", &Symbol{Name: "close", Kind: KindFunction, Type: &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "void"}}})
+	global.Define("waitAll", &Symbol{Name: "waitAll", Kind: KindFunction, Type: &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: "void"}}})
+
 	return &Checker{
-		CurrentScope: global,
-		Errors:       make([]SemanticError, 0),
-		inLoop:       false,
+		CurrentScope:  global,
+		Errors:        make([]SemanticError, 0),
+		ExprTypes:     make(map[parser.Expr]Type),
+		ConstValues:   make(map[parser.Expr]*ConstValue),
+		VariadicCalls: make(map[*parser.CallExpr]int),
+		CallArgs:      make(map[*parser.CallExpr][]parser.Expr),
+		inLoop:        false,
+		sharedVars:    make(map[*Symbol]sharedVar),
+		sharedAccess:  make(map[*parser.Identifier]bool),
+		impls:         make(map[string][]*parser.ImplDecl),
 	}
 }
 
 func (c *Checker) CheckProgram(prog *parser.Program) {
+	c.lang = prog.Lang
+	c.registerImpls(prog)
+
 	for _, stmt := range prog.Body {
 		c.checkStmt(stmt)
 	}
 }
 
-func (c *Checker) reportError(line, col int, msg string) {
+// TypeOf retorna o tipo registrado para uma expressão durante a verificação
+func (c *Checker) TypeOf(expr parser.Expr) Type {
+	return c.ExprTypes[expr]
+}
+
+// reportError registra o erro do catálogo; a mensagem é formatada no idioma
+// dos diagnósticos e cita as palavras-chave no idioma do programa
+func (c *Checker) reportError(line, col int, code diag.Code, args ...any) {
 	c.Errors = append(c.Errors, SemanticError{
-		Msg:  msg,
+		Code: code,
+		Msg:  lexer.LocalizeKeywords(diag.Msg(code, args...), c.lang),
 		Line: line,
 		Col:  col,
 	})
 }
 
+// reportSuggestion registra o erro de um nome desconhecido. Se algum candidato
+// for parecido com o nome, a mensagem o sugere e o erro leva a correção que
+// troca um pelo outro (apenas quando a posição do nome é conhecida). Os
+// candidatos vêm em grupos, do preferido para o menos preferido (diag.Suggest)
+func (c *Checker) reportSuggestion(pos parser.Pos, name string, candidates [][]string, code diag.Code, args ...any) {
+	c.reportError(pos.Line, pos.Col, code, args...)
+
+	suggestion, ok := diag.Suggest(name, candidates...)
+	if !ok {
+		return
+	}
+	err := &c.Errors[len(c.Errors)-1]
+	err.Msg += lexer.LocalizeKeywords(diag.T("; did you mean '%s'?", suggestion).String(), c.lang)
+	if pos.Line > 0 {
+		err.Fix = &diag.Fix{Line: pos.Line, Col: pos.Col, Offset: pos.Offset, Old: name, New: suggestion}
+	}
+}
+
+// exprPos retorna a posição de uma expressão: a do primeiro trecho dela que
+// guarda a posição no código (nomes, números, canais). Zero se nenhum guarda
+func exprPos(expr parser.Expr) parser.Pos {
+	switch e := expr.(type) {
+	case *parser.Identifier:
+		return e.Pos
+	case *parser.IntLiteral:
+		return e.Pos
+	case *parser.FloatLiteral:
+		return e.Pos
+	case *parser.InterpolatedString:
+		return e.Pos
+	case *parser.ChannelExpr:
+		return e.Pos
+	case *parser.ReceiveExpr:
+		return e.Pos
+	case *parser.SendExpr:
+		return firstPos(e.Channel, e.Value)
+	case *parser.MemberExpr:
+		if pos := exprPos(e.Object); pos.Line > 0 {
+			return pos
+		}
+		return e.Pos
+	case *parser.UnaryExpr:
+		return exprPos(e.Expr)
+	case *parser.BinaryExpr:
+		return firstPos(e.Left, e.Right)
+	case *parser.TernaryExpr:
+		return firstPos(e.Cond, e.TrueExpr, e.FalseExpr)
+	case *parser.AssignExpr:
+		return firstPos(e.Left, e.Right)
+	case *parser.CallExpr:
+		return firstPos(append([]parser.Expr{e.Callee}, e.Args...)...)
+	case *parser.GenericCallExpr:
+		return firstPos(append([]parser.Expr{e.Callee}, e.Args...)...)
+	case *parser.IndexExpr:
+		return firstPos(e.Array, e.Index)
+	case *parser.TypeCastExpr:
+		return exprPos(e.Expr)
+	case *parser.ReferenceExpr:
+		return exprPos(e.Expr)
+	case *parser.ArrayLiteral:
+		return e.Pos
+	case *parser.SetLiteral:
+		return firstPos(e.Elements...)
+	}
+	return parser.Pos{}
+}
+
+// firstPos retorna a primeira posição conhecida entre as expressões
+func firstPos(exprs ...parser.Expr) parser.Pos {
+	for _, expr := range exprs {
+		if pos := exprPos(expr); pos.Line > 0 {
+			return pos
+		}
+	}
+	return parser.Pos{}
+}
+
+// visibleNames lista os nomes visíveis a partir do escopo atual cujos símbolos
+// passam no filtro, candidatos a sugestões para um nome desconhecido
+func (c *Checker) visibleNames(accept func(*Symbol) bool) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for scope := c.CurrentScope; scope != nil; scope = scope.Outer {
+		for name, sym := range scope.Symbols {
+			if seen[name] || strings.HasSuffix(name, "?") || !accept(sym) {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// valueKeywords são as palavras-chave que valem sozinhas como expressão, as
+// únicas que podem substituir um nome desconhecido no meio de uma expressão
+var valueKeywords = []string{"false", "null", "true"}
+
+// statementKeywords são as palavras-chave que formam sozinhas um statement:
+// um nome desconhecido que é o statement inteiro também pode ser uma delas
+// digitada errado. Outras palavras-chave precisam de mais texto em volta, e
+// a correção automática não pode criar um erro de sintaxe
+var statementKeywords = []string{"break", "continue", "fallthrough", "return"}
+
+// keywordNames traduz as palavras-chave para o idioma do arquivo
+func (c *Checker) keywordNames(keywords []string) []string {
+	names := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		names[i] = lexer.KeywordName(keyword, c.lang)
+	}
+	return names
+}
+
+// isValue aceita símbolos que podem aparecer em expressões
+func isValue(sym *Symbol) bool {
+	return sym.Kind != KindTypeAlias && sym.Kind != KindGenericParam
+}
+
+// isCallable aceita símbolos que podem ser chamados como função
+func isCallable(sym *Symbol) bool {
+	return sym.Kind == KindFunction || sym.Kind == KindStruct || sym.Kind == KindImport
+}
+
+// isTypeName aceita símbolos que nomeiam tipos
+func isTypeName(sym *Symbol) bool {
+	return sym.Kind == KindStruct || sym.Kind == KindTypeAlias || sym.Kind == KindGenericParam
+}
+
 func (c *Checker) enterScope() {
 	c.CurrentScope = NewScope(c.CurrentScope)
 }
@@ -100,7 +282,7 @@ func (c *Checker) resolveType(t parser.Type) parser.Type {
 		return t
 
 	case *parser.ArrayType:
-		return &parser.ArrayType{ElementType: c.resolveType(v.ElementType)}
+		return &parser.ArrayType{ElementType: c.resolveType(v.ElementType), Size: v.Size}
 
 	case *parser.PointerType:
 		return &parser.PointerType{BaseType: c.resolveType(v.BaseType)}
@@ -124,6 +306,9 @@ func (c *Checker) resolveType(t parser.Type) parser.Type {
 	case *parser.SetType:
 		return &parser.SetType{ElementType: c.resolveType(v.ElementType)}
 
+	case *parser.ChannelType:
+		return &parser.ChannelType{ElementType: c.resolveType(v.ElementType)}
+
 	case *parser.GenericType:
 		args := make([]parser.Type, len(v.TypeArgs))
 		for i, arg := range v.TypeArgs {
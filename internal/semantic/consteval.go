@@ -0,0 +1,516 @@
+package semantic
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/parser"
+)
+
+// ============================
+// VALORES CONSTANTES
+// ============================
+
+type ConstKind int
+
+const (
+	ConstInt ConstKind = iota
+	ConstFloat
+	ConstString
+	ConstBool
+	ConstChar // Código do caractere em Int
+	ConstByte // Valor em Int (0 a 255)
+)
+
+// ConstValue é o valor de uma expressão avaliada em tempo de compilação
+type ConstValue struct {
+	Kind  ConstKind
+	Int   int64
+	Float float64
+	Str   string
+	Bool  bool
+}
+
+// TypeName retorna o nome do tipo primitivo do valor
+func (v *ConstValue) TypeName() string {
+	switch v.Kind {
+	case ConstInt:
+		return "int"
+	case ConstFloat:
+		return "float"
+	case ConstString:
+		return "string"
+	case ConstChar:
+		return "char"
+	case ConstByte:
+		return "byte"
+	default:
+		return "bool"
+	}
+}
+
+// String retorna a representação literal do valor (strings e caracteres sem aspas)
+func (v *ConstValue) String() string {
+	switch v.Kind {
+	case ConstChar:
+		return string(rune(v.Int))
+	case ConstInt, ConstByte:
+		return strconv.FormatInt(v.Int, 10)
+	case ConstFloat:
+		return strconv.FormatFloat(v.Float, 'g', -1, 64)
+	case ConstString:
+		return v.Str
+	default:
+		return strconv.FormatBool(v.Bool)
+	}
+}
+
+// Type retorna o tipo semântico do valor
+func (v *ConstValue) Type() Type {
+	return &ParserTypeWrapper{Type: &parser.PrimitiveType{Name: v.TypeName()}}
+}
+
+func (v *ConstValue) asFloat() float64 {
+	if isIntegerConst(v) {
+		return float64(v.Int)
+	}
+	return v.Float
+}
+
+// isIntegerConst indica se o valor é inteiro (int, char ou byte)
+func isIntegerConst(v *ConstValue) bool {
+	return v.Kind == ConstInt || v.Kind == ConstChar || v.Kind == ConstByte
+}
+
+// integerKind é o tipo do resultado de uma operação entre inteiros: int se
+// adapta ao outro operando ('a' + 1 é char), mas char e byte não se misturam
+func integerKind(l, r *ConstValue) (ConstKind, bool) {
+	switch {
+	case l.Kind == r.Kind || r.Kind == ConstInt:
+		return l.Kind, true
+	case l.Kind == ConstInt:
+		return r.Kind, true
+	}
+	return 0, false
+}
+
+// constInRange indica se um valor inteiro cabe no tipo indicado por kind
+func constInRange(kind ConstKind, n int64) bool {
+	switch kind {
+	case ConstByte:
+		return n >= 0 && n <= math.MaxUint8
+	case ConstChar:
+		return n >= math.MinInt32 && n <= math.MaxInt32
+	}
+	return true
+}
+
+// ============================
+// AVALIAÇÃO
+// ============================
+
+// errNotConstant indica que a expressão depende de valores de tempo de execução
+var errNotConstant = errors.New("not a constant expression")
+
+// errInvalidConst indica um operando constante cujo erro já foi reportado
+var errInvalidConst = errors.New("invalid constant")
+
+// evalConst avalia uma expressão em tempo de compilação. Retorna errNotConstant
+// para expressões não constantes e um erro descritivo para overflow, divisão
+// por zero e operações inválidas
+func (c *Checker) evalConst(expr parser.Expr) (*ConstValue, error) {
+	switch e := expr.(type) {
+	case *parser.IntLiteral:
+		return &ConstValue{Kind: ConstInt, Int: e.Value}, nil
+	case *parser.FloatLiteral:
+		return &ConstValue{Kind: ConstFloat, Float: e.Value}, nil
+	case *parser.StringLiteral:
+		return &ConstValue{Kind: ConstString, Str: e.Value}, nil
+	case *parser.InterpolatedString:
+		return c.evalInterpolatedString(e)
+	case *parser.CharLiteral:
+		return &ConstValue{Kind: ConstChar, Int: int64(e.Value)}, nil
+	case *parser.BoolLiteral:
+		return &ConstValue{Kind: ConstBool, Bool: e.Value}, nil
+
+	case *parser.Identifier:
+		sym := c.CurrentScope.Resolve(e.Name)
+		if sym == nil || sym.Kind != KindConst {
+			return nil, errNotConstant
+		}
+		if sym.Value == nil {
+			return nil, errInvalidConst
+		}
+		return sym.Value, nil
+
+	case *parser.UnaryExpr:
+		if e.Postfix {
+			return nil, errNotConstant
+		}
+		val, err := c.evalConst(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return EvalConstUnary(e.Op, val)
+
+	case *parser.BinaryExpr:
+		left, err := c.evalConst(e.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := c.evalConst(e.Right)
+		if err != nil {
+			return nil, err
+		}
+		return EvalConstBinary(e.Op, left, right)
+
+	case *parser.TernaryExpr:
+		cond, err := c.evalConst(e.Cond)
+		if err != nil {
+			return nil, err
+		}
+		if cond.Kind != ConstBool {
+			return nil, diag.Errorf(diag.TernaryCondNotBool, cond.TypeName())
+		}
+		if cond.Bool {
+			return c.evalConst(e.TrueExpr)
+		}
+		return c.evalConst(e.FalseExpr)
+
+	case *parser.TypeCastExpr:
+		val, err := c.evalConst(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		prim, ok := c.resolveType(e.Type).(*parser.PrimitiveType)
+		if !ok {
+			return nil, errNotConstant
+		}
+		return ConvertConst(val, prim.Name)
+	}
+
+	return nil, errNotConstant
+}
+
+// EvalConstUnary aplica um operador unário a um valor constante
+func EvalConstUnary(op string, v *ConstValue) (*ConstValue, error) {
+	switch {
+	case op == "+" && (isIntegerConst(v) || v.Kind == ConstFloat):
+		return v, nil
+	case op == "-" && isIntegerConst(v):
+		if v.Int == math.MinInt64 || !constInRange(v.Kind, -v.Int) {
+			return nil, diag.Errorf(diag.NegateOverflow, v.Int, v.TypeName())
+		}
+		return &ConstValue{Kind: v.Kind, Int: -v.Int}, nil
+	case op == "-" && v.Kind == ConstFloat:
+		return &ConstValue{Kind: ConstFloat, Float: -v.Float}, nil
+	case op == "!" && v.Kind == ConstBool:
+		return &ConstValue{Kind: ConstBool, Bool: !v.Bool}, nil
+	case op == "~" && v.Kind == ConstByte:
+		// byte não tem sinal: o complemento fica entre 0 e 255
+		return &ConstValue{Kind: ConstByte, Int: v.Int ^ math.MaxUint8}, nil
+	case op == "~" && isIntegerConst(v):
+		return &ConstValue{Kind: v.Kind, Int: ^v.Int}, nil
+	}
+	return nil, diag.Errorf(diag.ConstUnaryUnsupported, op, v.TypeName())
+}
+
+// EvalConstBinary aplica um operador binário a dois valores constantes
+func EvalConstBinary(op string, l, r *ConstValue) (*ConstValue, error) {
+	switch op {
+	case "&&", "||":
+		if l.Kind != ConstBool || r.Kind != ConstBool {
+			break
+		}
+		if op == "&&" {
+			return &ConstValue{Kind: ConstBool, Bool: l.Bool && r.Bool}, nil
+		}
+		return &ConstValue{Kind: ConstBool, Bool: l.Bool || r.Bool}, nil
+
+	case "==", "!=", "<", "<=", ">", ">=":
+		cmp, ok := compareConst(l, r)
+		if !ok || (l.Kind == ConstBool && op != "==" && op != "!=") {
+			break
+		}
+		return &ConstValue{Kind: ConstBool, Bool: cmpResult(op, cmp)}, nil
+
+	case "<<", ">>":
+		if !isIntegerConst(l) || !isIntegerConst(r) {
+			break
+		}
+		if r.Int < 0 {
+			return nil, diag.Errorf(diag.ConstNegativeShift, l.Int, op, r.Int)
+		}
+		res, err := evalConstInt(op, l.Int, r.Int)
+		if err != nil || !constInRange(l.Kind, res.Int) {
+			return nil, diag.Errorf(diag.ConstOverflow, l.Int, op, r.Int, l.TypeName())
+		}
+		res.Kind = l.Kind
+		return res, nil
+
+	case "+", "-", "*", "/", "%", "&", "|", "^":
+		switch {
+		case l.Kind == ConstInt && r.Kind == ConstInt:
+			return evalConstInt(op, l.Int, r.Int)
+		case isIntegerConst(l) && isIntegerConst(r):
+			kind, ok := integerKind(l, r)
+			if !ok {
+				break
+			}
+			res, err := evalConstInt(op, l.Int, r.Int)
+			if err != nil {
+				return nil, err
+			}
+			if !constInRange(kind, res.Int) {
+				return nil, diag.Errorf(diag.ConstOverflow, l.Int, op, r.Int, l.TypeName())
+			}
+			res.Kind = kind
+			return res, nil
+		case isNumericConst(l) && isNumericConst(r) && op != "%" && !bitwiseOps[op]:
+			return evalConstFloat(op, l.asFloat(), r.asFloat())
+		case l.Kind == ConstString && r.Kind == ConstString && op == "+":
+			return &ConstValue{Kind: ConstString, Str: l.Str + r.Str}, nil
+		}
+	}
+
+	return nil, diag.Errorf(diag.ConstBinaryUnsupported, op, l.TypeName(), r.TypeName())
+}
+
+func evalConstInt(op string, a, b int64) (*ConstValue, error) {
+	x, y := big.NewInt(a), big.NewInt(b)
+	res := new(big.Int)
+
+	switch op {
+	case "+":
+		res.Add(x, y)
+	case "-":
+		res.Sub(x, y)
+	case "*":
+		res.Mul(x, y)
+	case "&":
+		res.And(x, y)
+	case "|":
+		res.Or(x, y)
+	case "^":
+		res.Xor(x, y)
+	case "<<", ">>":
+		// Deslocamentos além de 64 bits têm o mesmo resultado que 64
+		if op == "<<" {
+			res.Lsh(x, uint(min(b, 64)))
+		} else {
+			res.Rsh(x, uint(min(b, 64)))
+		}
+	case "/", "%":
+		if b == 0 {
+			return nil, diag.Errorf(diag.ConstDivByZero, a, op)
+		}
+		// Quo e Rem truncam em direção a zero, como os operadores do Go
+		if op == "/" {
+			res.Quo(x, y)
+		} else {
+			res.Rem(x, y)
+		}
+	}
+
+	if !res.IsInt64() {
+		return nil, diag.Errorf(diag.ConstOverflow, a, op, b, "int")
+	}
+	return &ConstValue{Kind: ConstInt, Int: res.Int64()}, nil
+}
+
+func evalConstFloat(op string, a, b float64) (*ConstValue, error) {
+	var res float64
+
+	switch op {
+	case "+":
+		res = a + b
+	case "-":
+		res = a - b
+	case "*":
+		res = a * b
+	case "/":
+		if b == 0 {
+			return nil, diag.Errorf(diag.ConstFloatDivByZero, a)
+		}
+		res = a / b
+	}
+
+	if math.IsInf(res, 0) {
+		return nil, diag.Errorf(diag.ConstFloatOverflow, a, op, b)
+	}
+	return &ConstValue{Kind: ConstFloat, Float: res}, nil
+}
+
+func isNumericConst(v *ConstValue) bool {
+	return v.Kind == ConstInt || v.Kind == ConstFloat
+}
+
+// compareConst retorna -1, 0 ou 1 comparando dois valores do mesmo tipo
+// (int e float são comparáveis entre si)
+func compareConst(l, r *ConstValue) (int, bool) {
+	switch {
+	case isIntegerConst(l) && isIntegerConst(r):
+		if _, ok := integerKind(l, r); !ok {
+			return 0, false
+		}
+		return cmpOrdered(l.Int, r.Int), true
+	case isNumericConst(l) && isNumericConst(r):
+		return cmpOrdered(l.asFloat(), r.asFloat()), true
+	case l.Kind == ConstString && r.Kind == ConstString:
+		return cmpOrdered(l.Str, r.Str), true
+	case l.Kind == ConstBool && r.Kind == ConstBool:
+		if l.Bool == r.Bool {
+			return 0, true
+		}
+		return 1, true
+	}
+	return 0, false
+}
+
+func cmpOrdered[T int64 | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func cmpResult(op string, cmp int) bool {
+	switch op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// ConvertConst aplica uma conversão de tipo primitivo a um valor constante,
+// com a mesma semântica da conversão em tempo de execução
+func ConvertConst(v *ConstValue, target string) (*ConstValue, error) {
+	switch target {
+	case "int":
+		switch v.Kind {
+		case ConstInt:
+			return v, nil
+		case ConstChar, ConstByte:
+			return &ConstValue{Kind: ConstInt, Int: v.Int}, nil
+		case ConstFloat:
+			if math.IsNaN(v.Float) || v.Float >= math.MaxInt64 || v.Float < math.MinInt64 {
+				return nil, diag.Errorf(diag.ConstFloatToInt, v.Float)
+			}
+			return &ConstValue{Kind: ConstInt, Int: int64(v.Float)}, nil
+		}
+
+	case "float":
+		if isNumericConst(v) || isIntegerConst(v) {
+			return &ConstValue{Kind: ConstFloat, Float: v.asFloat()}, nil
+		}
+
+	case "char", "byte":
+		if !isIntegerConst(v) {
+			break
+		}
+		kind := ConstChar
+		if target == "byte" {
+			kind = ConstByte
+		}
+		if !constInRange(kind, v.Int) {
+			return nil, diag.Errorf(diag.ConstConvertOverflow, v.Int, target)
+		}
+		return &ConstValue{Kind: kind, Int: v.Int}, nil
+
+	case "string":
+		if v.Kind == ConstByte {
+			// Como no Go, string(byte) é o caractere com aquele código
+			return &ConstValue{Kind: ConstString, Str: string(rune(v.Int))}, nil
+		}
+		return &ConstValue{Kind: ConstString, Str: v.String()}, nil
+
+	case "bool":
+		if v.Kind == ConstBool {
+			return v, nil
+		}
+
+	default:
+		return nil, errNotConstant
+	}
+
+	return nil, diag.Errorf(diag.ConstCannotConvert, v.TypeName(), target)
+}
+
+// ============================
+// INTEGRAÇÃO COM O CHECKER
+// ============================
+
+// constValueFor avalia o inicializador de uma constante, reportando erros.
+// Retorna nil quando o valor não pode ser determinado
+func (c *Checker) constValueFor(name string, init parser.Expr) *ConstValue {
+	val, err := c.evalConst(init)
+	switch {
+	case err == nil:
+		c.ConstValues[init] = val
+		return val
+	case errors.Is(err, errNotConstant):
+		pos := exprPos(init)
+		c.reportError(pos.Line, pos.Col, diag.ConstNotConstant, name)
+	case errors.Is(err, errInvalidConst):
+		// Erro já reportado na declaração da constante referenciada
+	default:
+		c.reportConstError(err, exprPos(init))
+	}
+	return nil
+}
+
+// constInt avalia uma expressão inteira constante (tamanhos e índices de arrays).
+// Overflow e divisão por zero são reportados; expressões não constantes não
+func (c *Checker) constInt(expr parser.Expr) (int64, bool) {
+	val, err := c.evalConst(expr)
+	if err != nil {
+		if !errors.Is(err, errNotConstant) && !errors.Is(err, errInvalidConst) {
+			c.reportConstError(err, exprPos(expr))
+		}
+		return 0, false
+	}
+	if val.Kind != ConstInt {
+		return 0, false
+	}
+	return val.Int, true
+}
+
+// reportConstError reporta um erro da avaliação de constantes (overflow,
+// divisão por zero, operação inválida) na posição da expressão avaliada
+func (c *Checker) reportConstError(err error, pos parser.Pos) {
+	var d *diag.Error
+	if errors.As(err, &d) {
+		c.reportError(pos.Line, pos.Col, d.Code, d.Args...)
+	}
+}
+
+// checkConstAssign reporta atribuições a constantes
+func (c *Checker) checkConstAssign(target parser.Expr) {
+	ident, ok := target.(*parser.Identifier)
+	if !ok {
+		return
+	}
+	if sym := c.CurrentScope.Resolve(ident.Name); sym != nil && sym.Kind == KindConst {
+		c.reportError(ident.Pos.Line, ident.Pos.Col, diag.AssignToConst, ident.Name)
+	}
+}
+
+// ConstValueOf retorna o valor calculado para o inicializador de uma constante
+func (c *Checker) ConstValueOf(expr parser.Expr) *ConstValue {
+	return c.ConstValues[expr]
+}
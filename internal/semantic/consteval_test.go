@@ -0,0 +1,38 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/alpha/internal/diag"
+)
+
+// errorAt falha o teste se o programa não tem exatamente o erro dado, na
+// linha e coluna dadas
+func errorAt(t *testing.T, src string, code diag.Code, line, col int) {
+	t.Helper()
+	errs := check(t, src).Errors
+	if len(errs) != 1 || errs[0].Code != code {
+		t.Fatalf("errors %v, want only %s", errs, code)
+	}
+	if errs[0].Line != line || errs[0].Col != col {
+		t.Errorf("%s reported at %d:%d, want %d:%d", code, errs[0].Line, errs[0].Col, line, col)
+	}
+}
+
+func TestConstErrorPositions(t *testing.T) {
+	tests := []struct {
+		name, src string
+		code      diag.Code
+		line, col int
+	}{
+		{"division by zero", "package main\nconst b = 10 / 0\n", diag.ConstDivByZero, 2, 11},
+		{"overflow", "package main\nconst big = 1 << 70\n", diag.ConstOverflow, 2, 13},
+		{"not constant", "package main\nint x = 1\nconst c = x + 1\n", diag.ConstNotConstant, 3, 11},
+		{"assignment", "package main\nconst d = 2\nvoid function f() {\n    d = 3\n}\n", diag.AssignToConst, 4, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errorAt(t, tt.src, tt.code, tt.line, tt.col)
+		})
+	}
+}
@@ -2,16 +2,20 @@ package semantic
 
 import (
 	"fmt"
+
+	"github.com/alpha/internal/diag"
 )
 
 type SemanticError struct {
+	Code diag.Code // Código estável do catálogo de diagnósticos
 	Msg  string
 	Line int
 	Col  int
+	Fix  *diag.Fix // Correção sugerida ("você quis dizer"), quando houver
 }
 
 func (e SemanticError) Error() string {
-	return fmt.Sprintf("[Semantic Error] @ %d:%d: %s", e.Line, e.Col, e.Msg)
+	return fmt.Sprintf("[Semantic Error] @ %d:%d: %s", e.Line, e.Col, diag.Label(e.Code, e.Msg))
 }
 
 // Interface para nós que têm posição (já definida no seu parser)
@@ -0,0 +1,85 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/alpha/internal/diag"
+	"github.com/alpha/internal/lexer"
+	"github.com/alpha/internal/parser"
+)
+
+// check analisa o programa, falhando o teste em erros de sintaxe
+func check(t *testing.T, src string) *Checker {
+	t.Helper()
+	p := parser.New(lexer.NewScanner(src))
+	prog := p.ParseProgram()
+	if p.HasErrors() {
+		t.Fatalf("parse errors: %v\n%s", p.Errors, src)
+	}
+	c := NewChecker()
+	c.CheckProgram(prog)
+	return c
+}
+
+// Aplicar as correções sugeridas nunca pode gerar um erro de sintaxe, e nos
+// casos abaixo deixa o programa sem erros
+func TestFixesRecheckClean(t *testing.T) {
+	tests := []struct {
+		name, src string
+		want      []string // Sugestões esperadas, na ordem dos erros
+	}{
+		{"scope names win ties with keywords", `package main
+int function sum() {
+    int count = 1
+    int total = cont + conut
+    return total
+}
+`, []string{"count", "count"}},
+		{"value keywords", `package main
+bool function yes() {
+    bool ok = ture
+    return ok
+}
+`, []string{"true"}},
+		{"statement keywords", `package main
+void function loop() {
+    while (true) {
+        brek
+    }
+}
+`, []string{"break"}},
+		{"keywords stay out of expressions", `package main
+int function pick() {
+    int whale = 1
+    return whle
+}
+`, []string{"whale"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := check(t, tt.src)
+			var fixes []diag.Fix
+			var got []string
+			for _, err := range c.Errors {
+				if err.Fix != nil {
+					fixes = append(fixes, *err.Fix)
+					got = append(got, err.Fix.New)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("suggestions %v, want %v (errors: %v)", got, tt.want, c.Errors)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("suggestions %v, want %v", got, tt.want)
+					break
+				}
+			}
+
+			fixed, _ := diag.ApplyFixes(tt.src, fixes)
+			if c := check(t, fixed); len(c.Errors) > 0 {
+				t.Errorf("errors after --fix: %v\n%s", c.Errors, fixed)
+			}
+		})
+	}
+}
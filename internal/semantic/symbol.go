@@ -12,11 +12,13 @@ const (
 	KindTypeAlias
 	KindGenericParam
 	KindImport
+	KindBad // nome de uma declaração com erro de sintaxe: seus usos não são verificados
 )
 
 type Symbol struct {
-	Name string
-	Kind SymbolKind
-	Type Type // Alterado para semantic.Type
-	Node parser.Node
+	Name  string
+	Kind  SymbolKind
+	Type  Type // Alterado para semantic.Type
+	Node  parser.Node
+	Value *ConstValue // Valor das constantes, calculado em tempo de compilação
 }
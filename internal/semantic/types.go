@@ -88,6 +88,9 @@ func StringifyParserType(t parser.Type) string {
 	case *parser.IdentifierType:
 		return v.Name
 	case *parser.ArrayType:
+		if size, ok := ArraySize(v); ok {
+			return fmt.Sprintf("%s[%d]", StringifyParserType(v.ElementType), size)
+		}
 		return StringifyParserType(v.ElementType) + "[]"
 	case *parser.PointerType:
 		return "*" + StringifyParserType(v.BaseType)
@@ -105,6 +108,8 @@ func StringifyParserType(t parser.Type) string {
 		return strings.Join(typeStrings, " | ")
 	case *parser.SetType:
 		return fmt.Sprintf("set<%s>", StringifyParserType(v.ElementType))
+	case *parser.ChannelType:
+		return fmt.Sprintf("channel<%s>", StringifyParserType(v.ElementType))
 	case *parser.GenericType:
 		if len(v.TypeArgs) == 0 {
 			return v.Name
@@ -217,6 +222,13 @@ func AreParserTypesCompatible(target, source parser.Type) bool {
 	// Caso especial: compatibilidade de arrays
 	if tArr, ok := target.(*parser.ArrayType); ok {
 		if sArr, ok := source.(*parser.ArrayType); ok {
+			// Arrays fixos só aceitam arrays do mesmo tamanho; a conversão entre
+			// T[N] e T[] precisa ser explícita
+			tSize, tFixed := ArraySize(tArr)
+			sSize, sFixed := ArraySize(sArr)
+			if tFixed != sFixed || tSize != sSize {
+				return false
+			}
 			// Permitir any[] para string[] e vice-versa
 			if targetStr == "any[]" || sourceStr == "any[]" {
 				return true
@@ -345,6 +357,15 @@ func IsGenericTypeName(name string) bool {
 	return false
 }
 
+// ArraySize retorna o tamanho de um array fixo (T[N]).
+// Retorna false para arrays dinâmicos (T[]) ou tamanhos não constantes
+func ArraySize(t *parser.ArrayType) (int64, bool) {
+	if lit, ok := t.Size.(*parser.IntLiteral); ok {
+		return lit.Value, true
+	}
+	return 0, false
+}
+
 // GetBaseTypeName obtém o nome base de um tipo (remove modificadores)
 func GetBaseTypeName(t Type) string {
 	if t == nil {